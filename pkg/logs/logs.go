@@ -30,6 +30,9 @@ const (
 	jobKey           = "job"
 )
 
+// correlationIDContextKey is the key of the correlation ID in the context, see [CorrelationID].
+type correlationIDContextKey struct{}
+
 // SetupLogger configures the global log level for the zerolog logger.
 //
 // It takes a string `logLevel` representing the desired logging level (e.g. "debug", "info", "warn").
@@ -102,10 +105,19 @@ func WithJob(ctx context.Context, jobName string) context.Context {
 }
 
 // WithCorrelationID adds a correlation ID to the logger in the context and returns the updated context.
+// The correlation ID can be read from the context with [CorrelationID], e.g. to pass it on to other services.
 func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	ctx = context.WithValue(ctx, correlationIDContextKey{}, correlationID)
 	return WithCtxField(ctx, correlationIDKey, correlationID)
 }
 
+// CorrelationID returns the correlation ID added to the context with [WithCorrelationID].
+// If the context has no correlation ID, an empty string is returned.
+func CorrelationID(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDContextKey{}).(string)
+	return correlationID
+}
+
 // WithNewCorrelationID generates a new correlation ID, adds it to the logger in the context,
 // and returns the updated context.
 func WithNewCorrelationID(ctx context.Context) context.Context {
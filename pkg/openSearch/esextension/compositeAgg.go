@@ -13,7 +13,7 @@ type CompositeAgg struct {
 	name         string
 	size         uint64
 	sources      []esquery.Mappable
-	after        map[string]string
+	after        map[string]any
 	aggregations []esquery.Aggregation
 }
 
@@ -43,6 +43,20 @@ func (agg *CompositeAgg) Sources(sources ...esquery.Mappable) *CompositeAgg {
 
 // After sets the identification for the entry after which the next results should be returned.
 func (agg *CompositeAgg) After(after map[string]string) *CompositeAgg {
+	if after == nil {
+		agg.after = nil
+		return agg
+	}
+	agg.after = make(map[string]any, len(after))
+	for key, value := range after {
+		agg.after[key] = value
+	}
+	return agg
+}
+
+// AfterKey is like [CompositeAgg.After], but allows non-string values, e.g. the `after_key` of a previous
+// response containing numbers.
+func (agg *CompositeAgg) AfterKey(after map[string]any) *CompositeAgg {
 	agg.after = after
 	return agg
 }
@@ -49,6 +49,15 @@ func TestComposite(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "after key",
+			given: Composite("myComposite").AfterKey(map[string]any{"key": "value", "number": 1}),
+			expected: map[string]interface{}{
+				"composite": map[string]interface{}{
+					"after": map[string]interface{}{"key": "value", "number": 1},
+				},
+			},
+		},
 		{
 			name: "aggregations",
 			given: Composite("myComposite").Aggregations(
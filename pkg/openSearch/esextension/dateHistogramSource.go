@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package esextensions
+
+// DateHistogramSource represents a date histogram value source in composite aggregations.
+type DateHistogramSource struct {
+	name             string
+	field            string
+	calendarInterval string
+	order            string
+	missingBucket    bool
+}
+
+// DateHistogram creates a new DateHistogramSource.
+//
+// name: The name of the DateHistogramSource.
+// field: The name of the date field referenced.
+// calendarInterval: The calendar interval of the buckets, e.g. "day" or "month".
+func DateHistogram(name string, field string, calendarInterval string) *DateHistogramSource {
+	return &DateHistogramSource{
+		name:             name,
+		field:            field,
+		calendarInterval: calendarInterval,
+		order:            "asc", // Default order is ascending
+	}
+}
+
+// MissingBucket sets the missing_bucket flag to true in the DateHistogramSource.
+func (d *DateHistogramSource) MissingBucket() *DateHistogramSource {
+	d.missingBucket = true
+	return d
+}
+
+// Order sets the sorting order for the DateHistogramSource.
+// Valid values: "asc", "desc".
+func (d *DateHistogramSource) Order(order string) *DateHistogramSource {
+	d.order = order
+	return d
+}
+
+// Map returns a map representation of the DateHistogramSource.
+func (d *DateHistogramSource) Map() map[string]interface{} {
+	histogramMap := map[string]interface{}{
+		"field":             d.field,
+		"calendar_interval": d.calendarInterval,
+	}
+
+	if d.order != "" {
+		histogramMap["order"] = d.order
+	}
+
+	if d.missingBucket {
+		histogramMap["missing_bucket"] = true
+	}
+
+	return map[string]interface{}{
+		d.name: map[string]interface{}{
+			"date_histogram": histogramMap,
+		},
+	}
+}
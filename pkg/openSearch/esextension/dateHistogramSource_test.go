@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package esextensions
+
+import "testing"
+
+func TestDateHistogramSource(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			name:  "default",
+			given: DateHistogram("n1", "f1", "day"),
+			expected: map[string]interface{}{
+				"n1": map[string]interface{}{
+					"date_histogram": map[string]interface{}{"field": "f1", "calendar_interval": "day", "order": "asc"},
+				},
+			},
+		},
+		{
+			name:  "order and missing bucket",
+			given: DateHistogram("n1", "f1", "month").Order("desc").MissingBucket(),
+			expected: map[string]interface{}{
+				"n1": map[string]interface{}{
+					"date_histogram": map[string]interface{}{
+						"field": "f1", "calendar_interval": "month", "order": "desc", "missing_bucket": true,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package esextensions
+
+import "github.com/aquasecurity/esquery"
+
+// OrderedTermsAggregation extends esquery.TermsAggregation by ordering the buckets by multiple criteria.
+// esquery only supports a single map of criteria, which has no defined order.
+type OrderedTermsAggregation struct {
+	*esquery.TermsAggregation
+	order []map[string]string
+}
+
+// OrderedTerms wraps the given terms aggregation. Orders set on the wrapped aggregation are replaced
+// by the ones added via [OrderedTermsAggregation.Order].
+func OrderedTerms(aggregation *esquery.TermsAggregation) *OrderedTermsAggregation {
+	return &OrderedTermsAggregation{TermsAggregation: aggregation}
+}
+
+// Order appends an order criterion. Criteria are applied in the order they were added.
+func (agg *OrderedTermsAggregation) Order(key string, direction string) *OrderedTermsAggregation {
+	agg.order = append(agg.order, map[string]string{key: direction})
+	return agg
+}
+
+func (agg *OrderedTermsAggregation) Map() map[string]interface{} {
+	result := agg.TermsAggregation.Map()
+	if len(agg.order) > 0 {
+		if termsMap, ok := result["terms"].(map[string]interface{}); ok {
+			termsMap["order"] = agg.order
+		}
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package esextensions
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/esquery"
+)
+
+func TestOrderedTerms(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			name:  "without order",
+			given: OrderedTerms(esquery.TermsAgg("myTerms", "f1").Size(5)),
+			expected: map[string]interface{}{
+				"terms": map[string]interface{}{
+					"field": "f1",
+					"size":  5,
+				},
+			},
+		},
+		{
+			name: "multiple orders",
+			given: OrderedTerms(esquery.TermsAgg("myTerms", "f1").Order(map[string]string{"_key": "asc"})).
+				Order("max.value", "desc").
+				Order("_key", "asc"),
+			expected: map[string]interface{}{
+				"terms": map[string]interface{}{
+					"field": "f1",
+					"order": []map[string]string{
+						{"max.value": "desc"},
+						{"_key": "asc"},
+					},
+				},
+			},
+		},
+		{
+			name: "embedded in Search().Aggs(...)",
+			given: esquery.Search().Aggs(
+				OrderedTerms(esquery.TermsAgg("myTerms", "f1")).Order("_count", "desc"),
+			),
+			expected: map[string]interface{}{
+				"aggs": map[string]interface{}{
+					"myTerms": map[string]interface{}{
+						"terms": map[string]interface{}{
+							"field": "f1",
+							"order": []map[string]string{{"_count": "desc"}},
+						},
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package esextensions
+
+// PrefixQuery represents an OpenSearch prefix query, which in contrast to esquery.PrefixQuery
+// supports case-insensitive matching.
+// https://opensearch.org/docs/latest/query-dsl/term/prefix/
+type PrefixQuery struct {
+	Field           string
+	Value           string
+	CaseInsensitive bool
+}
+
+// Map returns a map representation of the PrefixQuery, thus implementing the esquery.Mappable interface.
+// Used for serialization to JSON.
+func (pq *PrefixQuery) Map() map[string]interface{} {
+	params := map[string]interface{}{
+		"value": pq.Value,
+	}
+	if pq.CaseInsensitive {
+		params["case_insensitive"] = true
+	}
+	return map[string]interface{}{
+		"prefix": map[string]interface{}{
+			pq.Field: params,
+		},
+	}
+}
+
+// Prefix creates a new PrefixQuery.
+func Prefix(field string, value string) *PrefixQuery {
+	return &PrefixQuery{
+		Field: field,
+		Value: value,
+	}
+}
+
+// CaseInsensitiveMatch sets the query to match the prefix case-insensitively.
+func (pq *PrefixQuery) CaseInsensitiveMatch() *PrefixQuery {
+	pq.CaseInsensitive = true
+	return pq
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package esextensions
+
+import (
+	"testing"
+)
+
+func TestPrefix(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			name:  "prefix query map",
+			given: Prefix("field_name", "val"),
+			expected: map[string]interface{}{
+				"prefix": map[string]interface{}{
+					"field_name": map[string]interface{}{
+						"value": "val",
+					},
+				},
+			},
+		},
+		{
+			name:  "case-insensitive prefix query map",
+			given: Prefix("field_name", "val").CaseInsensitiveMatch(),
+			expected: map[string]interface{}{
+				"prefix": map[string]interface{}{
+					"field_name": map[string]interface{}{
+						"value":            "val",
+						"case_insensitive": true,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package esextensions
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SimpleQueryStringQuery represents an OpenSearch simple_query_string query, which is not supported by esquery.
+// https://opensearch.org/docs/latest/query-dsl/full-text/simple-query-string/
+type SimpleQueryStringQuery struct {
+	Query           string
+	Fields          []string
+	DefaultOperator string
+}
+
+// Map returns a map representation of the SimpleQueryStringQuery, thus implementing the esquery.Mappable interface.
+// Used for serialization to JSON.
+func (sq *SimpleQueryStringQuery) Map() map[string]interface{} {
+	params := map[string]interface{}{
+		"query": sq.Query,
+	}
+	if len(sq.Fields) > 0 {
+		params["fields"] = sq.Fields
+	}
+	if sq.DefaultOperator != "" {
+		params["default_operator"] = sq.DefaultOperator
+	}
+	return map[string]interface{}{
+		"simple_query_string": params,
+	}
+}
+
+// SimpleQueryString creates a new SimpleQueryStringQuery matching the query against the given fields.
+func SimpleQueryString(query string, fields ...string) *SimpleQueryStringQuery {
+	return &SimpleQueryStringQuery{
+		Query:  query,
+		Fields: fields,
+	}
+}
+
+// AllTermsRequired sets the default operator to `and`, so that all terms not joined by `|` have to match.
+func (sq *SimpleQueryStringQuery) AllTermsRequired() *SimpleQueryStringQuery {
+	sq.DefaultOperator = "and"
+	return sq
+}
+
+// WebSearch creates a SimpleQueryStringQuery from a search text in web search syntax, like it is accepted
+// by `websearch_to_tsquery` of PostgreSQL: words have to match all, `"quoted text"` matches a phrase,
+// `-word` excludes a word and `or` matches either of the adjacent words.
+//
+// The operators of simple_query_string which are not part of the web search syntax are escaped.
+func WebSearch(text string, fields ...string) *SimpleQueryStringQuery {
+	return SimpleQueryString(webSearchToSimpleQueryString(text), fields...).AllTermsRequired()
+}
+
+// webSearchToSimpleQueryString translates the web search syntax into the syntax of simple_query_string.
+func webSearchToSimpleQueryString(text string) string {
+	var words []string
+	var word strings.Builder
+	inQuotes := false
+	flush := func() {
+		if word.Len() == 0 {
+			return
+		}
+		if w := word.String(); strings.EqualFold(w, "or") {
+			words = append(words, "|")
+		} else {
+			words = append(words, w)
+		}
+		word.Reset()
+	}
+
+	for _, r := range text {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			word.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuotes:
+			flush()
+		case r == '-' && word.Len() == 0 && !inQuotes:
+			word.WriteRune(r)
+		case strings.ContainsRune(`+|-()*~\`, r):
+			word.WriteRune('\\')
+			word.WriteRune(r)
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+	if inQuotes {
+		// an unterminated phrase is matched up to the end of the text, like in PostgreSQL
+		words[len(words)-1] += `"`
+	}
+	return strings.Join(words, " ")
+}
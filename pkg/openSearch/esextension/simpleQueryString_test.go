@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package esextensions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimpleQueryString(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			name:  "simple query string map",
+			given: SimpleQueryString("web server", "hostname^2", "description"),
+			expected: map[string]interface{}{
+				"simple_query_string": map[string]interface{}{
+					"query":  "web server",
+					"fields": []string{"hostname^2", "description"},
+				},
+			},
+		},
+		{
+			name:  "web search map",
+			given: WebSearch("web or db", "hostname"),
+			expected: map[string]interface{}{
+				"simple_query_string": map[string]interface{}{
+					"query":            "web | db",
+					"fields":           []string{"hostname"},
+					"default_operator": "and",
+				},
+			},
+		},
+	})
+}
+
+func TestWebSearchToSimpleQueryString(t *testing.T) {
+	tests := map[string]struct {
+		text string
+		want string
+	}{
+		"words":                 {text: " web  server ", want: "web server"},
+		"or":                    {text: "linux OR macos", want: "linux | macos"},
+		"or within words":       {text: "oracle order", want: "oracle order"},
+		"phrase":                {text: `"windows server" or macos`, want: `"windows server" | macos`},
+		"or within phrase":      {text: `"this or that"`, want: `"this or that"`},
+		"exclusion":             {text: `linux -"windows server"`, want: `linux -"windows server"`},
+		"hyphen within word":    {text: "x-ray", want: `x\-ray`},
+		"operators are escaped": {text: `a+b (c|d) e* ~f \g`, want: `a\+b \(c\|d\) e\* \~f \\g`},
+		"unterminated phrase":   {text: `"web server`, want: `"web server"`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, webSearchToSimpleQueryString(tt.text))
+		})
+	}
+}
@@ -0,0 +1,354 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openSearchClient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+	"github.com/rs/zerolog/log"
+)
+
+// VersionConflictPolicy defines how [Client.BulkWithOptions] handles items failed with a version conflict.
+type VersionConflictPolicy int
+
+const (
+	// VersionConflictFail reports version conflicts as failed items, this is the default.
+	VersionConflictFail VersionConflictPolicy = iota
+	// VersionConflictRetry retries items failed with a version conflict, like items rejected by an overloaded cluster.
+	// This is useful for update actions whose result doesn't depend on the current version of the document.
+	VersionConflictRetry
+)
+
+const defaultBulkRetryDelay = time.Second
+
+// BulkErrorTypeNotSent is the error type of items which were not sent, because an earlier request of
+// [Client.BulkWithOptions] failed.
+const BulkErrorTypeNotSent = "not_sent"
+
+// BulkOptions configures a request of [Client.BulkWithOptions]. The zero value sends a single request without retries.
+type BulkOptions struct {
+	// Refresh controls the visibility of the changes, e.g. [RefreshWaitFor].
+	// If empty, the default of OpenSearch is used.
+	Refresh string
+	// MaxRetries is the number of retries of retryable items. Items rejected by an overloaded cluster (429) are
+	// retryable, items with a version conflict (409) depending on VersionConflictPolicy. If the whole request fails
+	// with a transient error, all of its items are retried.
+	MaxRetries int
+	// RetryDelay is the delay before the first retry, it is doubled for each further retry. Defaults to one second.
+	RetryDelay time.Duration
+	// VersionConflictPolicy defines whether items with a version conflict are retried.
+	VersionConflictPolicy VersionConflictPolicy
+	// MaxRequestBytes splits the items into several requests of at most this size. An item larger than the limit
+	// is sent in a request of its own. If zero, all items are sent in a single request.
+	MaxRequestBytes int
+}
+
+// BulkResult is the result of a bulk request, listing the result of each item in the order of the request.
+type BulkResult struct {
+	Items []BulkItemResult
+}
+
+// BulkItemResult is the result of a single action of a bulk request.
+type BulkItemResult struct {
+	// Action is the action of the item, i.e. index, create, update or delete.
+	Action     string
+	IndexName  string
+	DocumentId string
+	StatusCode int
+	// Error is the reason of the failure, nil if the item succeeded.
+	Error *DocumentErrorType
+}
+
+// Failed returns true if the action of the item failed.
+func (r BulkItemResult) Failed() bool {
+	return r.Error != nil || r.StatusCode >= http.StatusMultipleChoices
+}
+
+// HasFailures returns true if at least one item failed.
+func (r *BulkResult) HasFailures() bool {
+	for _, item := range r.Items {
+		if item.Failed() {
+			return true
+		}
+	}
+	return false
+}
+
+// Failed returns the results of the failed items.
+func (r *BulkResult) Failed() []BulkItemResult {
+	var failed []BulkItemResult
+	for _, item := range r.Items {
+		if item.Failed() {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// bulkItem is a single action of a bulk request body, consisting of the action line and the source line if any.
+type bulkItem struct {
+	action     string
+	indexName  string
+	documentId string
+	body       []byte
+}
+
+// bulkItemMeta is the metadata of an action line.
+type bulkItemMeta struct {
+	IndexName  string `json:"_index"`
+	DocumentId string `json:"_id"`
+}
+
+// bulkItemsResponse is the part of a bulk response needed to get the result of each item.
+type bulkItemsResponse struct {
+	Items []map[string]DocumentError `json:"items"`
+}
+
+// BulkWithOptions is like [Client.Bulk], but returns the result of each item in the order of the request,
+// which can be checked e.g. with [BulkResult.Failed].
+//
+// requestBody contains the actions in the newline delimited format of the bulk API.
+// options configure the retries of failed items and the splitting of large request bodies. If the items are
+// split into several requests and one of them fails, the error is returned together with the results of
+// the items sent so far. The items without a response are failed with the error type [BulkErrorTypeNotSent].
+func (c *Client) BulkWithOptions(ctx context.Context, indexName string, requestBody []byte, options BulkOptions,
+) (*BulkResult, error) {
+	items, err := parseBulkItems(requestBody)
+	if err != nil {
+		return nil, err
+	}
+	if options.RetryDelay <= 0 {
+		options.RetryDelay = defaultBulkRetryDelay
+	}
+
+	result := &BulkResult{Items: make([]BulkItemResult, len(items))}
+	pending := make([]int, len(items))
+	for i := range items {
+		pending[i] = i
+	}
+	delay := options.RetryDelay
+	for attempt := 0; ; attempt++ {
+		var retry []int
+		for _, chunk := range splitBulkItems(items, pending, options.MaxRequestBytes) {
+			var body bytes.Buffer
+			for _, index := range chunk {
+				body.Write(items[index].body)
+			}
+
+			itemResults, statusCode, err := c.bulkRequest(ctx, indexName, body.Bytes(), options.Refresh)
+			if err != nil {
+				if attempt < options.MaxRetries && ctx.Err() == nil && isTransientStatus(statusCode) {
+					log.Warn().Err(err).Msgf("attempt %d: transient error in bulk request, retrying", attempt+1)
+					retry = append(retry, chunk...)
+					continue
+				}
+				result.markNotSent(items, pending, indexName, err)
+				return result, err
+			}
+			if len(itemResults) != len(chunk) {
+				err := fmt.Errorf("bulk response contains %d items, expected %d", len(itemResults), len(chunk))
+				result.markNotSent(items, pending, indexName, err)
+				return result, err
+			}
+
+			for i, index := range chunk {
+				result.Items[index] = itemResults[i]
+				if options.isRetryable(itemResults[i]) {
+					retry = append(retry, index)
+				}
+			}
+		}
+
+		if len(retry) == 0 || attempt >= options.MaxRetries {
+			return result, nil
+		}
+		log.Warn().
+			Int("attempt_number", attempt+1).
+			Msgf("attempt %d: retrying %d items of bulk request", attempt+1, len(retry))
+		if err := sleepContext(ctx, delay); err != nil {
+			result.markNotSent(items, retry, indexName, err)
+			return result, err
+		}
+		delay *= 2
+		pending = retry
+	}
+}
+
+// markNotSent fails the items with the given indexes which have no response yet, so that they are not
+// mistaken for stored items. Items with a response of an earlier attempt keep it.
+func (r *BulkResult) markNotSent(items []bulkItem, indexes []int, indexName string, cause error) {
+	for _, index := range indexes {
+		if r.Items[index].StatusCode != 0 {
+			continue
+		}
+		item := items[index]
+		itemIndexName := item.indexName
+		if itemIndexName == "" {
+			itemIndexName = indexName
+		}
+		r.Items[index] = BulkItemResult{
+			Action:     item.action,
+			IndexName:  itemIndexName,
+			DocumentId: item.documentId,
+			Error:      &DocumentErrorType{Type: BulkErrorTypeNotSent, Reason: cause.Error()},
+		}
+	}
+}
+
+// isRetryable returns true if the failed item should be retried.
+func (o BulkOptions) isRetryable(item BulkItemResult) bool {
+	switch item.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusConflict:
+		return o.VersionConflictPolicy == VersionConflictRetry
+	default:
+		return false
+	}
+}
+
+// bulkRequest sends a single bulk request and returns the results of its items. On error, the status code
+// of the response is returned as well, it is 0 if there is no response.
+func (c *Client) bulkRequest(ctx context.Context, indexName string, requestBody []byte, refresh string,
+) ([]BulkItemResult, int, error) {
+	body, statusCode, err := c.bulkResponseBody(ctx, indexName, requestBody, refresh)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	var bulkResponse bulkItemsResponse
+	if err := jsoniter.Unmarshal(body, &bulkResponse); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse bulk response: %w", err)
+	}
+
+	results := make([]BulkItemResult, len(bulkResponse.Items))
+	for i, item := range bulkResponse.Items {
+		results[i] = newBulkItemResult(item)
+	}
+	return results, 0, nil
+}
+
+// bulkResponseBody sends a single bulk request and returns the body of the response. On error, the status code
+// of the response is returned as well, it is 0 if there is no response.
+func (c *Client) bulkResponseBody(ctx context.Context, indexName string, requestBody []byte, refresh string,
+) ([]byte, int, error) {
+	response, err := c.openSearchProjectClient.Bulk(ctx, opensearchapi.BulkReq{
+		Index: indexName,
+		Body:  bytes.NewReader(requestBody),
+		Params: opensearchapi.BulkParams{
+			Refresh: refresh,
+		},
+		Header: requestHeader(ctx),
+	})
+	// failed items are reported as error as well, but their details are part of the response
+	if err != nil && (response.Inspect().Response == nil || response.Inspect().Response.IsError()) {
+		statusCode := 0
+		if response.Inspect().Response != nil {
+			statusCode = response.Inspect().Response.StatusCode
+		}
+		return nil, statusCode, requestError(response.Inspect().Response, err,
+			fmt.Sprintf("error while performing bulk request on index %s", indexName))
+	}
+	defer response.Inspect().Response.Body.Close()
+
+	body, err := io.ReadAll(response.Inspect().Response.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read bulk response: %w", err)
+	}
+	return body, 0, nil
+}
+
+// newBulkItemResult converts an item of the bulk response, which is keyed by its action.
+func newBulkItemResult(item map[string]DocumentError) BulkItemResult {
+	for action, document := range item {
+		result := BulkItemResult{
+			Action:     action,
+			IndexName:  document.IndexName,
+			DocumentId: document.DocumentId,
+			StatusCode: int(document.StatusCode),
+		}
+		if document.Error.Type != "" {
+			documentError := document.Error
+			result.Error = &documentError
+		}
+		return result
+	}
+	return BulkItemResult{}
+}
+
+// parseBulkItems splits a bulk request body into its items. All actions except delete are followed by a source.
+func parseBulkItems(requestBody []byte) ([]bulkItem, error) {
+	lines := bytes.Split(requestBody, []byte("\n"))
+	var items []bulkItem
+	for i := 0; i < len(lines); i++ {
+		line := bytes.TrimSpace(lines[i])
+		if len(line) == 0 {
+			continue
+		}
+
+		var action map[string]json.RawMessage
+		if err := json.Unmarshal(line, &action); err != nil {
+			return nil, fmt.Errorf("invalid action in line %d of bulk request: %w", i+1, err)
+		}
+		if len(action) != 1 {
+			return nil, fmt.Errorf("invalid action in line %d of bulk request: expected a single action", i+1)
+		}
+		item := bulkItem{body: append(append([]byte{}, line...), '\n')}
+		for name, meta := range action {
+			item.action = name
+			var itemMeta bulkItemMeta
+			if err := json.Unmarshal(meta, &itemMeta); err != nil {
+				return nil, fmt.Errorf("invalid action in line %d of bulk request: %w", i+1, err)
+			}
+			item.indexName, item.documentId = itemMeta.IndexName, itemMeta.DocumentId
+		}
+
+		if item.action != "delete" {
+			i++
+			if i >= len(lines) || len(bytes.TrimSpace(lines[i])) == 0 {
+				return nil, fmt.Errorf("missing source of %s action in line %d of bulk request", item.action, i)
+			}
+			item.body = append(append(item.body, bytes.TrimSpace(lines[i])...), '\n')
+		}
+		items = append(items, item)
+	}
+	if len(items) == 0 {
+		return nil, errors.New("bulk request contains no actions")
+	}
+	return items, nil
+}
+
+// splitBulkItems groups the items with the given indexes into chunks of at most maxBytes.
+// An item larger than maxBytes gets a chunk of its own. If maxBytes is not positive, there is a single chunk.
+func splitBulkItems(items []bulkItem, indexes []int, maxBytes int) [][]int {
+	if maxBytes <= 0 {
+		return [][]int{indexes}
+	}
+
+	var chunks [][]int
+	var chunk []int
+	chunkBytes := 0
+	for _, index := range indexes {
+		size := len(items[index].body)
+		if len(chunk) > 0 && chunkBytes+size > maxBytes {
+			chunks = append(chunks, chunk)
+			chunk, chunkBytes = nil, 0
+		}
+		chunk = append(chunk, index)
+		chunkBytes += size
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
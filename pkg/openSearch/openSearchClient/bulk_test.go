@@ -0,0 +1,293 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openSearchClient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bulkTestServer answers bulk requests with the status returned by itemStatus for each document,
+// the request number starts at 0. A request status other than 200 fails the whole request.
+type bulkTestServer struct {
+	mu            sync.Mutex
+	requestStatus func(request int) int
+	itemStatus    func(request int, id string) int
+	requests      [][]string // ids of the documents of each request
+}
+
+func (s *bulkTestServer) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	request := len(s.requests)
+	var ids []string
+	var actions []string
+	lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+	for i := 0; i < len(lines); i++ {
+		var action map[string]struct {
+			Id string `json:"_id"`
+		}
+		_ = json.Unmarshal([]byte(lines[i]), &action)
+		for name, meta := range action {
+			actions = append(actions, name)
+			ids = append(ids, meta.Id)
+			if name != "delete" {
+				i++
+			}
+		}
+	}
+	s.requests = append(s.requests, ids)
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if s.requestStatus != nil {
+		if status := s.requestStatus(request); status != http.StatusOK {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"error":{"type":"error","reason":"failed"},"status":%d}`, status)))
+			return
+		}
+	}
+
+	items := make([]string, len(ids))
+	hasErrors := false
+	for i, id := range ids {
+		status := s.itemStatus(request, id)
+		if status < http.StatusMultipleChoices {
+			items[i] = fmt.Sprintf(`{%q:{"_index":"index","_id":%q,"status":%d}}`, actions[i], id, status)
+			continue
+		}
+		hasErrors = true
+		items[i] = fmt.Sprintf(`{%q:{"_index":"index","_id":%q,"status":%d,"error":{"type":"error_%d","reason":"failed"}}}`,
+			actions[i], id, status, status)
+	}
+	_, _ = fmt.Fprintf(w, `{"took":1,"errors":%t,"items":[%s]}`, hasErrors, strings.Join(items, ","))
+}
+
+func (s *bulkTestServer) requestedIds() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func bulkTestBody(ids ...string) []byte {
+	var body strings.Builder
+	for _, id := range ids {
+		_, _ = fmt.Fprintf(&body, "{\"index\":{\"_id\":%q}}\n{\"name\":\"document %s\"}\n", id, id)
+	}
+	return []byte(body.String())
+}
+
+func TestBulkWithOptions(t *testing.T) {
+	tests := map[string]struct {
+		requestBody   []byte
+		options       BulkOptions
+		requestStatus func(request int) int
+		itemStatus    func(request int, id string) int
+		wantStatus    map[string]int
+		wantRequests  [][]string
+		wantErr       bool
+	}{
+		"result of each item": {
+			requestBody: bulkTestBody("1", "2", "3"),
+			itemStatus: func(_ int, id string) int {
+				if id == "2" {
+					return http.StatusBadRequest
+				}
+				return http.StatusCreated
+			},
+			wantStatus:   map[string]int{"1": http.StatusCreated, "2": http.StatusBadRequest, "3": http.StatusCreated},
+			wantRequests: [][]string{{"1", "2", "3"}},
+		},
+		"rejected items are retried": {
+			requestBody: bulkTestBody("1", "2", "3"),
+			options:     BulkOptions{MaxRetries: 3, RetryDelay: time.Millisecond},
+			itemStatus: func(request int, id string) int {
+				if id != "1" && request < 2 {
+					return http.StatusTooManyRequests
+				}
+				return http.StatusCreated
+			},
+			wantStatus:   map[string]int{"1": http.StatusCreated, "2": http.StatusCreated, "3": http.StatusCreated},
+			wantRequests: [][]string{{"1", "2", "3"}, {"2", "3"}, {"2", "3"}},
+		},
+		"retries are exhausted": {
+			requestBody: bulkTestBody("1", "2"),
+			options:     BulkOptions{MaxRetries: 1, RetryDelay: time.Millisecond},
+			itemStatus: func(_ int, id string) int {
+				if id == "2" {
+					return http.StatusTooManyRequests
+				}
+				return http.StatusCreated
+			},
+			wantStatus:   map[string]int{"1": http.StatusCreated, "2": http.StatusTooManyRequests},
+			wantRequests: [][]string{{"1", "2"}, {"2"}},
+		},
+		"version conflicts fail by default": {
+			requestBody: bulkTestBody("1"),
+			options:     BulkOptions{MaxRetries: 3, RetryDelay: time.Millisecond},
+			itemStatus: func(request int, _ string) int {
+				if request == 0 {
+					return http.StatusConflict
+				}
+				return http.StatusOK
+			},
+			wantStatus:   map[string]int{"1": http.StatusConflict},
+			wantRequests: [][]string{{"1"}},
+		},
+		"version conflicts are retried with policy": {
+			requestBody: bulkTestBody("1"),
+			options: BulkOptions{MaxRetries: 3, RetryDelay: time.Millisecond,
+				VersionConflictPolicy: VersionConflictRetry},
+			itemStatus: func(request int, _ string) int {
+				if request == 0 {
+					return http.StatusConflict
+				}
+				return http.StatusOK
+			},
+			wantStatus:   map[string]int{"1": http.StatusOK},
+			wantRequests: [][]string{{"1"}, {"1"}},
+		},
+		"failed request is retried": {
+			requestBody: bulkTestBody("1", "2"),
+			options:     BulkOptions{MaxRetries: 1, RetryDelay: time.Millisecond},
+			requestStatus: func(request int) int {
+				if request == 0 {
+					return http.StatusTooManyRequests
+				}
+				return http.StatusOK
+			},
+			itemStatus:   func(int, string) int { return http.StatusCreated },
+			wantStatus:   map[string]int{"1": http.StatusCreated, "2": http.StatusCreated},
+			wantRequests: [][]string{{"1", "2"}, {"1", "2"}},
+		},
+		"failed request without retries": {
+			requestBody:   bulkTestBody("1"),
+			requestStatus: func(int) int { return http.StatusTooManyRequests },
+			itemStatus:    func(int, string) int { return http.StatusCreated },
+			wantRequests:  [][]string{{"1"}},
+			wantErr:       true,
+		},
+		"request is split by size": {
+			requestBody:  bulkTestBody("1", "2", "3", "4", "5"),
+			options:      BulkOptions{MaxRequestBytes: 2 * len(bulkTestBody("1"))},
+			itemStatus:   func(int, string) int { return http.StatusCreated },
+			wantStatus:   map[string]int{"1": 201, "2": 201, "3": 201, "4": 201, "5": 201},
+			wantRequests: [][]string{{"1", "2"}, {"3", "4"}, {"5"}},
+		},
+		"item larger than the limit is sent alone": {
+			requestBody:  bulkTestBody("1", "2"),
+			options:      BulkOptions{MaxRequestBytes: 10},
+			itemStatus:   func(int, string) int { return http.StatusCreated },
+			wantStatus:   map[string]int{"1": 201, "2": 201},
+			wantRequests: [][]string{{"1"}, {"2"}},
+		},
+		"delete actions have no source": {
+			requestBody: []byte("{\"delete\":{\"_id\":\"1\"}}\n{\"index\":{\"_id\":\"2\"}}\n{}\n"),
+			itemStatus: func(_ int, id string) int {
+				if id == "1" {
+					return http.StatusNotFound
+				}
+				return http.StatusCreated
+			},
+			wantStatus:   map[string]int{"1": http.StatusNotFound, "2": http.StatusCreated},
+			wantRequests: [][]string{{"1", "2"}},
+		},
+		"missing source": {
+			requestBody: []byte("{\"index\":{\"_id\":\"1\"}}\n"),
+			wantErr:     true,
+		},
+		"invalid action": {
+			requestBody: []byte("{\"index\":{},\"delete\":{}}\n{}\n"),
+			wantErr:     true,
+		},
+		"empty request": {
+			requestBody: []byte("\n"),
+			wantErr:     true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := &bulkTestServer{requestStatus: tt.requestStatus, itemStatus: tt.itemStatus}
+			client := NewClient(newTestServerClient(t, server.handle), 1, time.Millisecond)
+			defer client.Close()
+
+			result, err := client.BulkWithOptions(context.Background(), "index", tt.requestBody, tt.options)
+			assert.Equal(t, tt.wantRequests, server.requestedIds())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			gotStatus := make(map[string]int, len(result.Items))
+			for _, item := range result.Items {
+				gotStatus[item.DocumentId] = item.StatusCode
+				assert.Equal(t, item.StatusCode >= http.StatusMultipleChoices, item.Failed())
+			}
+			assert.Equal(t, tt.wantStatus, gotStatus)
+		})
+	}
+}
+
+func TestBulkWithOptions_FailedChunk(t *testing.T) {
+	server := &bulkTestServer{
+		requestStatus: func(request int) int {
+			if request == 1 {
+				return http.StatusTooManyRequests
+			}
+			return http.StatusOK
+		},
+		itemStatus: func(int, string) int { return http.StatusCreated },
+	}
+	client := NewClient(newTestServerClient(t, server.handle), 1, time.Millisecond)
+	defer client.Close()
+
+	result, err := client.BulkWithOptions(context.Background(), "index", bulkTestBody("1", "2", "3", "4", "5"),
+		BulkOptions{MaxRequestBytes: 2 * len(bulkTestBody("1"))})
+	require.Error(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, [][]string{{"1", "2"}, {"3", "4"}}, server.requestedIds())
+
+	assert.True(t, result.HasFailures())
+	assert.False(t, result.Items[0].Failed())
+	assert.False(t, result.Items[1].Failed())
+	var failedIds []string
+	for _, item := range result.Failed() {
+		failedIds = append(failedIds, item.DocumentId)
+		assert.Equal(t, "index", item.Action)
+		assert.Equal(t, "index", item.IndexName)
+		require.NotNil(t, item.Error)
+		assert.Equal(t, BulkErrorTypeNotSent, item.Error.Type)
+	}
+	assert.Equal(t, []string{"3", "4", "5"}, failedIds)
+}
+
+func TestBulkResult(t *testing.T) {
+	failed := BulkItemResult{Action: "index", DocumentId: "2", StatusCode: http.StatusBadRequest,
+		Error: &DocumentErrorType{Type: "mapper_parsing_exception", Reason: "failed to parse"}}
+	result := BulkResult{Items: []BulkItemResult{
+		{Action: "index", DocumentId: "1", StatusCode: http.StatusCreated},
+		failed,
+	}}
+
+	assert.True(t, result.HasFailures())
+	assert.Equal(t, []BulkItemResult{failed}, result.Failed())
+
+	result.Items = result.Items[:1]
+	assert.False(t, result.HasFailures())
+	assert.Empty(t, result.Failed())
+}
@@ -51,12 +51,19 @@ func NewClient(
 // requestBody is the request body to send to OpenSearch.
 // It returns the response body as or an error in case something went wrong.
 func (c *Client) Search(indexName string, requestBody []byte) (responseBody []byte, err error) {
+	return c.SearchContext(context.Background(), indexName, requestBody)
+}
+
+// SearchContext is like [Client.Search], but the request is cancelled when the context is done.
+// The correlation ID of the context is sent in the [OpaqueIdHeader].
+func (c *Client) SearchContext(ctx context.Context, indexName string, requestBody []byte) (responseBody []byte, err error) {
 	log.Debug().Msgf("search requestBody: %s", string(requestBody))
 	searchResponse, err := c.openSearchProjectClient.Search(
-		context.Background(),
+		ctx,
 		&opensearchapi.SearchReq{
 			Indices: []string{indexName},
 			Body:    bytes.NewReader(requestBody),
+			Header:  requestHeader(ctx),
 		},
 	)
 	if err != nil {
@@ -65,6 +72,7 @@ func (c *Client) Search(indexName string, requestBody []byte) (responseBody []by
 
 	// Get the raw response body to return a byte array.
 	body := searchResponse.Inspect().Response.Body
+	defer body.Close()
 	result, err := io.ReadAll(body)
 	if err != nil {
 		return nil, err
@@ -80,13 +88,21 @@ func (c *Client) Search(indexName string, requestBody []byte) (responseBody []by
 	return result, nil
 }
 
+// Count counts the documents in the given index matching the query of the request body.
 func (c *Client) Count(indexName string, requestBody []byte) (count int64, err error) {
+	return c.CountContext(context.Background(), indexName, requestBody)
+}
+
+// CountContext is like [Client.Count], but the request is cancelled when the context is done.
+// The correlation ID of the context is sent in the [OpaqueIdHeader].
+func (c *Client) CountContext(ctx context.Context, indexName string, requestBody []byte) (count int64, err error) {
 	log.Debug().Msgf("count requestBody: %s", string(requestBody))
 	request := opensearchapi.IndicesCountReq{
 		Indices: []string{indexName},
 		Body:    bytes.NewReader(requestBody),
+		Header:  requestHeader(ctx),
 	}
-	response, err := c.openSearchProjectClient.Indices.Count(context.Background(), &request)
+	response, err := c.openSearchProjectClient.Indices.Count(ctx, &request)
 	if err != nil {
 		return 0, fmt.Errorf("count request failed: %w", err)
 	}
@@ -95,6 +111,9 @@ func (c *Client) Count(indexName string, requestBody []byte) (count int64, err e
 	return count, nil
 }
 
+// SearchStream searches for all documents in the given index using the scroll API and writes the raw response
+// of each page to the returned reader. For new code prefer [SearchPitStream], which returns typed documents and
+// doesn't keep a scroll context open if the reader isn't consumed until the end.
 func (c *Client) SearchStream(
 	indexName string,
 	requestBody []byte,
@@ -117,6 +136,7 @@ func (c *Client) SearchStream(
 				Params: opensearchapi.SearchParams{
 					Scroll: scrollTimeout,
 				},
+				Header: requestHeader(ctx),
 			},
 		)
 		if err != nil {
@@ -161,6 +181,7 @@ func (c *Client) SearchStream(
 				Params: opensearchapi.ScrollGetParams{
 					Scroll: scrollTimeout,
 				},
+				Header: requestHeader(ctx),
 			}
 
 			scrollResult, err := c.openSearchProjectClient.Scroll.Get(ctx, scrollReq)
@@ -200,6 +221,7 @@ func (c *Client) SearchStream(
 		// Delete Scroll Context manually
 		clearScrollReq := opensearchapi.ScrollDeleteReq{
 			ScrollIDs: []string{scrollID},
+			Header:    requestHeader(ctx),
 		}
 		detachedCtx := context.WithoutCancel(ctx)
 		_, err = c.openSearchProjectClient.Scroll.Delete(detachedCtx, clearScrollReq)
@@ -238,6 +260,7 @@ func (c *Client) CompositeAggStream(indexName string, requestBody []byte, ctx co
 				&opensearchapi.SearchReq{
 					Indices: []string{indexName},
 					Body:    bytes.NewReader(paginatedRequestBody),
+					Header:  requestHeader(ctx),
 				},
 			)
 			if err != nil {
@@ -374,11 +397,23 @@ func (c *Client) Update(indexName string, requestBody []byte) (responseBody []by
 	return c.updateQueue.Update(indexName, requestBody)
 }
 
+// UpdateContext is like [Client.Update], but stops waiting for the update when the context is done.
+// The context is also used for the update request and its retries once the request is processed by the queue.
+func (c *Client) UpdateContext(ctx context.Context, indexName string, requestBody []byte) (responseBody []byte, err error) {
+	return c.updateQueue.UpdateContext(ctx, indexName, requestBody)
+}
+
 // SyncUpdate updates documents in the given index synchronously.
 func (c *Client) SyncUpdate(indexName string, requestBody []byte) (responseBody []byte, err error) {
 	return c.syncUpdate.Update(indexName, requestBody)
 }
 
+// SyncUpdateContext is like [Client.SyncUpdate], but the request and its retries are cancelled when the
+// context is done.
+func (c *Client) SyncUpdateContext(ctx context.Context, indexName string, requestBody []byte) (responseBody []byte, err error) {
+	return c.syncUpdate.UpdateContext(ctx, indexName, requestBody)
+}
+
 // AsyncDeleteByQuery updates documents in the given index asynchronously.
 // It does not wait for the update to finish before returning.
 // It returns an error in case something went wrong.
@@ -386,7 +421,13 @@ func (c *Client) SyncUpdate(indexName string, requestBody []byte) (responseBody
 // indexName is the name of the index to delete from.
 // requestBody is the request body to send to OpenSearch to identify the documents to be deleted.
 func (c *Client) AsyncDeleteByQuery(indexName string, requestBody []byte) error {
-	return c.deleteByQuery(indexName, requestBody, true)
+	return c.deleteByQuery(context.Background(), indexName, requestBody, true)
+}
+
+// AsyncDeleteByQueryContext is like [Client.AsyncDeleteByQuery], but the request is cancelled when the
+// context is done. Once OpenSearch accepted the request, the deletion is not affected by the context anymore.
+func (c *Client) AsyncDeleteByQueryContext(ctx context.Context, indexName string, requestBody []byte) error {
+	return c.deleteByQuery(ctx, indexName, requestBody, true)
 }
 
 // DeleteByQuery updates documents in the given index.
@@ -396,10 +437,16 @@ func (c *Client) AsyncDeleteByQuery(indexName string, requestBody []byte) error
 // indexName is the name of the index to delete from.
 // requestBody is the request body to send to OpenSearch to identify the documents to be deleted.
 func (c *Client) DeleteByQuery(indexName string, requestBody []byte) error {
-	return c.deleteByQuery(indexName, requestBody, false)
+	return c.deleteByQuery(context.Background(), indexName, requestBody, false)
+}
+
+// DeleteByQueryContext is like [Client.DeleteByQuery], but the request is cancelled when the context is done.
+// The correlation ID of the context is sent in the [OpaqueIdHeader].
+func (c *Client) DeleteByQueryContext(ctx context.Context, indexName string, requestBody []byte) error {
+	return c.deleteByQuery(ctx, indexName, requestBody, false)
 }
 
-func (c *Client) deleteByQuery(indexName string, requestBody []byte, isAsync bool) error {
+func (c *Client) deleteByQuery(ctx context.Context, indexName string, requestBody []byte, isAsync bool) error {
 	waitForCompletion := !isAsync
 
 	params := opensearchapi.DocumentDeleteByQueryParams{
@@ -412,11 +459,12 @@ func (c *Client) deleteByQuery(indexName string, requestBody []byte, isAsync boo
 	}
 
 	resp, err := c.openSearchProjectClient.Document.DeleteByQuery(
-		context.Background(),
+		ctx,
 		opensearchapi.DocumentDeleteByQueryReq{
 			Indices: []string{indexName},
 			Body:    bytes.NewReader(requestBody),
 			Params:  params,
+			Header:  requestHeader(ctx),
 		},
 	)
 	if err != nil {
@@ -458,19 +506,26 @@ func SerializeDocumentsForBulkUpdate[T any](indexName string, documents []T) ([]
 }
 
 // BulkUpdate performs a bulk update in the given index.
-// It returns an error in case something went wrong.
+// It returns an error in case something went wrong. Use [Client.Bulk] to get the result of each document.
 //
 // indexName is the name of the index to update.
 // requestBody is the request body to send to OpenSearch specifying the bulk update.
 func (c *Client) BulkUpdate(indexName string, requestBody []byte) error {
+	return c.BulkUpdateContext(context.Background(), indexName, requestBody)
+}
+
+// BulkUpdateContext is like [Client.BulkUpdate], but the request is cancelled when the context is done.
+// The correlation ID of the context is sent in the [OpaqueIdHeader].
+func (c *Client) BulkUpdateContext(ctx context.Context, indexName string, requestBody []byte) error {
 	resp, err := c.openSearchProjectClient.Bulk(
-		context.Background(),
+		ctx,
 		opensearchapi.BulkReq{
 			Index: indexName,
 			Body:  bytes.NewReader(requestBody),
 			Params: opensearchapi.BulkParams{
 				Refresh: "false",
 			},
+			Header: requestHeader(ctx),
 		},
 	)
 	if err != nil {
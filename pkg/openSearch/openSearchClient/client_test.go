@@ -8,6 +8,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/json"
+	"fmt"
 	"io"
 	"strings"
 	"testing"
@@ -160,6 +161,41 @@ func TestClient(t *testing.T) {
 			assert.Equal(t, uint(0), searchResponse.Hits.Total.Value)
 			assert.Equal(t, 0, len(searchResponse.GetResults()))
 		}},
+		"TestSearchContextCancelled": {func(t *testing.T, client *Client, _ *IndexFunction, indexName string) {
+			// given
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			// when
+			query := `{"query":{"match_all":{}}}`
+			_, err := client.SearchContext(ctx, indexName, []byte(query))
+
+			// then
+			require.ErrorIs(t, err, context.Canceled)
+		}},
+		"TestSearchPitStream": {func(t *testing.T, client *Client, _ *IndexFunction, indexName string) {
+			// given
+			vulnerabilities := make([]*Vulnerability, 5)
+			for i := range vulnerabilities {
+				vulnerabilities[i] = &Vulnerability{Oid: fmt.Sprintf("1.3.6.1.4.1.25623.1.0.%d", i), Name: aVulnerability.Name}
+			}
+			createDataInIndex(t, client, indexName, vulnerabilities, 5)
+
+			// when
+			query := `{"query":{"match_all":{}},"sort":[{"oid.keyword":{"order":"asc"}}]}`
+			var oids []string
+			for vulnerability, err := range SearchPitStream[Vulnerability](context.Background(), client, indexName,
+				[]byte(query), PitStreamSettings{PageSize: 2}) {
+				require.NoError(t, err)
+				oids = append(oids, vulnerability.Oid)
+			}
+
+			// then
+			assert.Equal(t, []string{
+				"1.3.6.1.4.1.25623.1.0.0", "1.3.6.1.4.1.25623.1.0.1", "1.3.6.1.4.1.25623.1.0.2",
+				"1.3.6.1.4.1.25623.1.0.3", "1.3.6.1.4.1.25623.1.0.4",
+			}, oids)
+		}},
 		"TestSearchStream": {func(t *testing.T, client *Client, _ *IndexFunction, indexName string) {
 			var searchResponse SearchResponse[*Vulnerability]
 
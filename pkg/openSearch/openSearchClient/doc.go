@@ -29,5 +29,11 @@
 //		return err
 //	}
 //
+// All requests have a variant taking a context, e.g. [Client.SearchContext], which cancels the request when the
+// context is done. A correlation ID added with logs.WithCorrelationID is sent to OpenSearch in the X-Opaque-Id header.
+//
+// [Client.BulkWithOptions] returns the result of each item of a bulk request. With [BulkOptions] it retries items
+// rejected by an overloaded cluster and splits large request bodies into several requests.
+//
 // For further usage examples see ./client_test.go.
 package openSearchClient
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openSearchClient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// Refresh values control when changes of a write request are visible to searches.
+const (
+	// RefreshFalse doesn't wait for the changes to be visible, this is the default of OpenSearch.
+	RefreshFalse = "false"
+	// RefreshTrue refreshes the affected shards immediately.
+	RefreshTrue = "true"
+	// RefreshWaitFor waits until the changes are visible by the next scheduled refresh.
+	RefreshWaitFor = "wait_for"
+)
+
+// GetDocument returns the source of the document with the given id.
+// If there is no such document, an [OpenSearchResourceNotFound] error is returned.
+//
+// indexName is the name of the index or of an alias pointing to a single index.
+func (c *Client) GetDocument(ctx context.Context, indexName string, id string) (source []byte, err error) {
+	response, err := c.openSearchProjectClient.Document.Get(ctx, opensearchapi.DocumentGetReq{
+		Index:      indexName,
+		DocumentID: id,
+		Header:     requestHeader(ctx),
+	})
+	if err != nil {
+		return nil, documentError(response.Inspect().Response, err, "get", indexName, id)
+	}
+	if !response.Found {
+		return nil, NewOpenSearchResourceNotFound(fmt.Sprintf("document %s not found in index %s", id, indexName))
+	}
+	return response.Source, nil
+}
+
+// IndexDocument stores the document with the given id, an existing document with the same id is replaced.
+// If the id is empty, an id is generated by OpenSearch. It returns the id of the stored document.
+//
+// indexName is the name of the index or of an alias with a write index.
+// refresh controls the visibility of the change, e.g. [RefreshWaitFor]. If empty, the default of OpenSearch is used.
+func (c *Client) IndexDocument(ctx context.Context, indexName string, id string, document []byte, refresh string,
+) (string, error) {
+	return c.indexDocument(ctx, indexName, id, document, refresh, "")
+}
+
+// CreateDocument is like [Client.IndexDocument], but fails with an [OpenSearchResourceAlreadyExists] error
+// if a document with the id exists already.
+func (c *Client) CreateDocument(ctx context.Context, indexName string, id string, document []byte, refresh string,
+) (string, error) {
+	return c.indexDocument(ctx, indexName, id, document, refresh, "create")
+}
+
+func (c *Client) indexDocument(ctx context.Context, indexName string, id string, document []byte, refresh string,
+	opType string,
+) (string, error) {
+	response, err := c.openSearchProjectClient.Index(ctx, opensearchapi.IndexReq{
+		Index:      indexName,
+		DocumentID: id,
+		Body:       bytes.NewReader(document),
+		Params: opensearchapi.IndexParams{
+			OpType:  opType,
+			Refresh: refresh,
+		},
+		Header: requestHeader(ctx),
+	})
+	if err != nil {
+		action := "index"
+		if opType != "" {
+			action = opType
+		}
+		return "", documentError(response.Inspect().Response, err, action, indexName, id)
+	}
+	return response.ID, nil
+}
+
+// DeleteDocument deletes the document with the given id.
+// If there is no such document, an [OpenSearchResourceNotFound] error is returned.
+//
+// refresh controls the visibility of the change, e.g. [RefreshWaitFor]. If empty, the default of OpenSearch is used.
+func (c *Client) DeleteDocument(ctx context.Context, indexName string, id string, refresh string) error {
+	response, err := c.openSearchProjectClient.Document.Delete(ctx, opensearchapi.DocumentDeleteReq{
+		Index:      indexName,
+		DocumentID: id,
+		Params: opensearchapi.DocumentDeleteParams{
+			Refresh: refresh,
+		},
+		Header: requestHeader(ctx),
+	})
+	if err != nil {
+		return documentError(response.Inspect().Response, err, "delete", indexName, id)
+	}
+	return nil
+}
+
+// Bulk performs a bulk request in the given index and returns the parsed response, which contains the result
+// of each action. Other than [Client.BulkUpdate] the request doesn't fail if single actions failed, they have
+// to be checked in the response. See [Client.BulkWithOptions] for retries and the splitting of large requests.
+//
+// refresh controls the visibility of the changes, e.g. [RefreshWaitFor]. If empty, the default of OpenSearch is used.
+func (c *Client) Bulk(ctx context.Context, indexName string, requestBody []byte, refresh string) (*BulkResponse, error) {
+	body, _, err := c.bulkResponseBody(ctx, indexName, requestBody, refresh)
+	if err != nil {
+		return nil, err
+	}
+	var bulkResponse BulkResponse
+	if err := jsoniter.Unmarshal(body, &bulkResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse bulk response: %w", err)
+	}
+	return &bulkResponse, nil
+}
+
+// documentError translates the error of a request for a single document into the typed errors of this package.
+// A conflict only means that the document exists already for the create action, otherwise it is a version conflict.
+func documentError(response *opensearch.Response, err error, action string, indexName string, id string) error {
+	if response != nil {
+		switch {
+		case response.StatusCode == http.StatusNotFound:
+			// also returned if the index doesn't exist
+			return NewOpenSearchResourceNotFound(fmt.Sprintf("failed to %s document %s: %v", action, id, err))
+		case response.StatusCode == http.StatusConflict && action == "create":
+			return NewOpenSearchResourceAlreadyExists(
+				fmt.Sprintf("document %s already exists in index %s", id, indexName))
+		}
+	}
+	return requestError(response, err, fmt.Sprintf("error while trying to %s document %s in index %s",
+		action, id, indexName))
+}
+
+// requestError returns an [OpenSearchError] if OpenSearch responded with an error. Otherwise, e.g. if the
+// connection failed or the context is done, the error is wrapped to keep it available for errors.Is.
+func requestError(response *opensearch.Response, err error, message string) error {
+	if response != nil && response.IsError() {
+		return NewOpenSearchError(fmt.Sprintf("%s: %v", message, err))
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openSearchClient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDocumentTestClient(t *testing.T, statusCode int, responseBody string) *Client {
+	client := NewClient(newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(responseBody))
+	}), 1, time.Millisecond)
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestDocument(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("get document", func(t *testing.T) {
+		client := newDocumentTestClient(t, http.StatusOK,
+			`{"_index":"index","_id":"1","found":true,"_source":{"oid":"1.2.3"}}`)
+
+		source, err := client.GetDocument(ctx, "index", "1")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"oid":"1.2.3"}`, string(source))
+	})
+
+	t.Run("get missing document", func(t *testing.T) {
+		client := newDocumentTestClient(t, http.StatusNotFound, `{"_index":"index","_id":"1","found":false}`)
+
+		_, err := client.GetDocument(ctx, "index", "1")
+		var notFound *OpenSearchResourceNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("create existing document", func(t *testing.T) {
+		client := newDocumentTestClient(t, http.StatusConflict,
+			`{"error":{"type":"version_conflict_engine_exception","reason":"document already exists"},"status":409}`)
+
+		_, err := client.CreateDocument(ctx, "index", "1", []byte(`{}`), RefreshFalse)
+		var alreadyExists *OpenSearchResourceAlreadyExists
+		assert.ErrorAs(t, err, &alreadyExists)
+	})
+
+	t.Run("version conflict of indexed document", func(t *testing.T) {
+		client := newDocumentTestClient(t, http.StatusConflict,
+			`{"error":{"type":"version_conflict_engine_exception","reason":"version conflict"},"status":409}`)
+
+		_, err := client.IndexDocument(ctx, "index", "1", []byte(`{}`), RefreshFalse)
+		var alreadyExists *OpenSearchResourceAlreadyExists
+		assert.False(t, errors.As(err, &alreadyExists))
+		var openSearchErr *OpenSearchError
+		assert.ErrorAs(t, err, &openSearchErr)
+	})
+
+	t.Run("version conflict of deleted document", func(t *testing.T) {
+		client := newDocumentTestClient(t, http.StatusConflict,
+			`{"error":{"type":"version_conflict_engine_exception","reason":"version conflict"},"status":409}`)
+
+		err := client.DeleteDocument(ctx, "index", "1", RefreshFalse)
+		var alreadyExists *OpenSearchResourceAlreadyExists
+		assert.False(t, errors.As(err, &alreadyExists))
+		var openSearchErr *OpenSearchError
+		assert.ErrorAs(t, err, &openSearchErr)
+	})
+
+	t.Run("delete missing document", func(t *testing.T) {
+		client := newDocumentTestClient(t, http.StatusNotFound, `{"_index":"index","_id":"1","result":"not_found"}`)
+
+		err := client.DeleteDocument(ctx, "index", "1", RefreshFalse)
+		var notFound *OpenSearchResourceNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("index document fails", func(t *testing.T) {
+		client := newDocumentTestClient(t, http.StatusBadRequest,
+			`{"error":{"type":"mapper_parsing_exception","reason":"failed to parse"},"status":400}`)
+
+		_, err := client.IndexDocument(ctx, "index", "1", []byte(`{}`), RefreshFalse)
+		var openSearchErr *OpenSearchError
+		assert.ErrorAs(t, err, &openSearchErr)
+	})
+	t.Run("bulk with failed items", func(t *testing.T) {
+		client := newDocumentTestClient(t, http.StatusOK, `{"took":3,"errors":true,"items":[
+			{"index":{"_index":"index","_id":"1","status":201}},
+			{"index":{"_index":"index","_id":"2","status":400,
+				"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}
+		]}`)
+
+		response, err := client.Bulk(ctx, "index", []byte("{\"index\":{}}\n{}\n{\"index\":{}}\n{}\n"), RefreshFalse)
+		require.NoError(t, err)
+		assert.True(t, response.HasError)
+		require.Len(t, response.Errors, 2)
+		assert.Equal(t, uint(400), response.Errors[1].Index.StatusCode)
+		assert.Equal(t, "mapper_parsing_exception", response.Errors[1].Index.Error.Type)
+	})
+}
@@ -28,11 +28,17 @@ func NewIndexFunction(openSearchProjectClient *opensearchapi.Client) *IndexFunct
 
 // CreateIndex creates an index
 func (i *IndexFunction) CreateIndex(indexName string, indexSchema []byte) error {
+	return i.CreateIndexContext(context.Background(), indexName, indexSchema)
+}
+
+// CreateIndexContext is like [IndexFunction.CreateIndex], but the request is cancelled when the context is done.
+func (i *IndexFunction) CreateIndexContext(ctx context.Context, indexName string, indexSchema []byte) error {
 	resp, err := i.openSearchProjectClient.Indices.Create(
-		context.Background(),
+		ctx,
 		opensearchapi.IndicesCreateReq{
-			Index: indexName,
-			Body:  bytes.NewReader(indexSchema),
+			Index:  indexName,
+			Body:   bytes.NewReader(indexSchema),
+			Header: requestHeader(ctx),
 		},
 	)
 	if err != nil {
@@ -51,13 +57,19 @@ func (i *IndexFunction) CreateIndex(indexName string, indexSchema []byte) error
 }
 
 func (i *IndexFunction) GetIndexes(pattern string) ([]string, error) {
+	return i.GetIndexesContext(context.Background(), pattern)
+}
+
+// GetIndexesContext is like [IndexFunction.GetIndexes], but the request is cancelled when the context is done.
+func (i *IndexFunction) GetIndexesContext(ctx context.Context, pattern string) ([]string, error) {
 	response, err := i.openSearchProjectClient.Indices.Get(
-		context.Background(),
+		ctx,
 		opensearchapi.IndicesGetReq{
 			Indices: []string{pattern},
 			Params: opensearchapi.IndicesGetParams{
 				ExpandWildcards: "open",
 			},
+			Header: requestHeader(ctx),
 		},
 	)
 	if err != nil {
@@ -92,15 +104,21 @@ func indexNameSliceOf(resultString []byte) ([]string, error) {
 }
 
 func (i *IndexFunction) IndexExists(indexName string) (bool, error) {
+	return i.IndexExistsContext(context.Background(), indexName)
+}
+
+// IndexExistsContext is like [IndexFunction.IndexExists], but the request is cancelled when the context is done.
+func (i *IndexFunction) IndexExistsContext(ctx context.Context, indexName string) (bool, error) {
 	includeAlias := true
 
 	response, err := i.openSearchProjectClient.Indices.Exists(
-		context.Background(),
+		ctx,
 		opensearchapi.IndicesExistsReq{
 			Indices: []string{indexName},
 			Params: opensearchapi.IndicesExistsParams{
 				AllowNoIndices: &includeAlias,
 			},
+			Header: requestHeader(ctx),
 		},
 	)
 	if err != nil {
@@ -116,10 +134,16 @@ func (i *IndexFunction) IndexExists(indexName string) (bool, error) {
 }
 
 func (i *IndexFunction) DeleteIndex(indexName string) error {
+	return i.DeleteIndexContext(context.Background(), indexName)
+}
+
+// DeleteIndexContext is like [IndexFunction.DeleteIndex], but the request is cancelled when the context is done.
+func (i *IndexFunction) DeleteIndexContext(ctx context.Context, indexName string) error {
 	resp, err := i.openSearchProjectClient.Indices.Delete(
-		context.Background(),
+		ctx,
 		opensearchapi.IndicesDeleteReq{
 			Indices: []string{indexName},
+			Header:  requestHeader(ctx),
 		},
 	)
 	if err != nil {
@@ -140,6 +164,11 @@ func (i *IndexFunction) DeleteIndex(indexName string) error {
 }
 
 func (i *IndexFunction) CreateOrPutAlias(aliasName string, indexNames ...string) error {
+	return i.CreateOrPutAliasContext(context.Background(), aliasName, indexNames...)
+}
+
+// CreateOrPutAliasContext is like [IndexFunction.CreateOrPutAlias], but the request is cancelled when the context is done.
+func (i *IndexFunction) CreateOrPutAliasContext(ctx context.Context, aliasName string, indexNames ...string) error {
 	var actions []map[string]interface{}
 	for _, idx := range indexNames {
 		actions = append(actions, map[string]interface{}{
@@ -158,9 +187,10 @@ func (i *IndexFunction) CreateOrPutAlias(aliasName string, indexNames ...string)
 		return fmt.Errorf("failed to encode alias body: %w", err)
 	}
 
-	resp, err := i.openSearchProjectClient.Aliases(context.Background(),
+	resp, err := i.openSearchProjectClient.Aliases(ctx,
 		opensearchapi.AliasesReq{
-			Body: &buf,
+			Body:   &buf,
+			Header: requestHeader(ctx),
 		})
 
 	if err != nil {
@@ -176,11 +206,17 @@ func (i *IndexFunction) CreateOrPutAlias(aliasName string, indexNames ...string)
 }
 
 func (i *IndexFunction) DeleteAliasFromIndex(indexName string, aliasName string) error {
+	return i.DeleteAliasFromIndexContext(context.Background(), indexName, aliasName)
+}
+
+// DeleteAliasFromIndexContext is like [IndexFunction.DeleteAliasFromIndex], but the request is cancelled when the context is done.
+func (i *IndexFunction) DeleteAliasFromIndexContext(ctx context.Context, indexName string, aliasName string) error {
 	resp, err := i.openSearchProjectClient.Indices.Alias.Delete(
-		context.Background(),
+		ctx,
 		opensearchapi.AliasDeleteReq{
 			Indices: []string{indexName},
 			Alias:   []string{aliasName},
+			Header:  requestHeader(ctx),
 		},
 	)
 	if err != nil {
@@ -202,11 +238,17 @@ func (i *IndexFunction) DeleteAliasFromIndex(indexName string, aliasName string)
 }
 
 func (i *IndexFunction) IndexHasAlias(indexNames []string, aliasNames []string) (bool, error) {
+	return i.IndexHasAliasContext(context.Background(), indexNames, aliasNames)
+}
+
+// IndexHasAliasContext is like [IndexFunction.IndexHasAlias], but the request is cancelled when the context is done.
+func (i *IndexFunction) IndexHasAliasContext(ctx context.Context, indexNames []string, aliasNames []string) (bool, error) {
 	response, err := i.openSearchProjectClient.Indices.Alias.Exists(
-		context.Background(),
+		ctx,
 		opensearchapi.AliasExistsReq{
 			Indices: indexNames,
 			Alias:   aliasNames,
+			Header:  requestHeader(ctx),
 		},
 	)
 	if err != nil {
@@ -227,10 +269,16 @@ func (i *IndexFunction) IndexHasAlias(indexNames []string, aliasNames []string)
 }
 
 func (i *IndexFunction) AliasExists(aliasName string) (bool, error) {
+	return i.AliasExistsContext(context.Background(), aliasName)
+}
+
+// AliasExistsContext is like [IndexFunction.AliasExists], but the request is cancelled when the context is done.
+func (i *IndexFunction) AliasExistsContext(ctx context.Context, aliasName string) (bool, error) {
 	response, err := i.openSearchProjectClient.Cat.Aliases(
-		context.Background(),
+		ctx,
 		&opensearchapi.CatAliasesReq{
 			Aliases: []string{aliasName},
+			Header:  requestHeader(ctx),
 		},
 	)
 	if err != nil {
@@ -256,11 +304,17 @@ func (i *IndexFunction) AliasExists(aliasName string) (bool, error) {
 
 // previously AliasPointsToIndex
 func (i *IndexFunction) GetIndexesForAlias(aliasName string) ([]string, error) {
+	return i.GetIndexesForAliasContext(context.Background(), aliasName)
+}
+
+// GetIndexesForAliasContext is like [IndexFunction.GetIndexesForAlias], but the request is cancelled when the context is done.
+func (i *IndexFunction) GetIndexesForAliasContext(ctx context.Context, aliasName string) ([]string, error) {
 	data := make(map[string][]string)
 	response, err := i.openSearchProjectClient.Cat.Aliases(
-		context.Background(),
+		ctx,
 		&opensearchapi.CatAliasesReq{
 			Aliases: []string{aliasName},
+			Header:  requestHeader(ctx),
 		},
 	)
 	if err != nil {
@@ -275,6 +329,11 @@ func (i *IndexFunction) GetIndexesForAlias(aliasName string) ([]string, error) {
 }
 
 func (i *IndexFunction) RemoveIndexesFromAlias(indexesToRemove []string, aliasName string) error {
+	return i.RemoveIndexesFromAliasContext(context.Background(), indexesToRemove, aliasName)
+}
+
+// RemoveIndexesFromAliasContext is like [IndexFunction.RemoveIndexesFromAlias], but the request is cancelled when the context is done.
+func (i *IndexFunction) RemoveIndexesFromAliasContext(ctx context.Context, indexesToRemove []string, aliasName string) error {
 	if len(indexesToRemove) <= 0 {
 		return nil
 	}
@@ -287,9 +346,10 @@ func (i *IndexFunction) RemoveIndexesFromAlias(indexesToRemove []string, aliasNa
 	}
 
 	_, err = i.openSearchProjectClient.Aliases(
-		context.Background(),
+		ctx,
 		opensearchapi.AliasesReq{
-			Body: bytes.NewReader(actionsBytes),
+			Body:   bytes.NewReader(actionsBytes),
+			Header: requestHeader(ctx),
 		},
 	)
 	if err != nil {
@@ -319,12 +379,17 @@ func (i *IndexFunction) createIndexRemovalActions(indexesToRemove []string, alia
 }
 
 func (i *IndexFunction) RefreshIndex(index string) error {
+	return i.RefreshIndexContext(context.Background(), index)
+}
+
+// RefreshIndexContext is like [IndexFunction.RefreshIndex], but the request is cancelled when the context is done.
+func (i *IndexFunction) RefreshIndexContext(ctx context.Context, index string) error {
 	log.Debug().Msgf("Start refreshing index: %s", index)
-	ctx := context.Background()
 	refreshResp, err := i.openSearchProjectClient.Indices.Refresh(
 		ctx,
 		&opensearchapi.IndicesRefreshReq{
-			Index: []string{index},
+			Index:  []string{index},
+			Header: requestHeader(ctx),
 		},
 	)
 	if err != nil {
@@ -343,15 +408,21 @@ func (i *IndexFunction) RefreshIndex(index string) error {
 }
 
 func (i *IndexFunction) GetIndexSettings(index string) (map[string]interface{}, error) {
+	return i.GetIndexSettingsContext(context.Background(), index)
+}
+
+// GetIndexSettingsContext is like [IndexFunction.GetIndexSettings], but the request is cancelled when the context is done.
+func (i *IndexFunction) GetIndexSettingsContext(ctx context.Context, index string) (map[string]interface{}, error) {
 	req := opensearchapi.SettingsGetReq{
 		Indices: []string{index},
 		Params: opensearchapi.SettingsGetParams{
 			IncludeDefaults: new(true),
 		},
+		Header: requestHeader(ctx),
 	}
 
 	var settings map[string]interface{}
-	_, err := opensearch.Do(context.Background(), i.openSearchProjectClient.Client, http.MethodGet, req, &settings)
+	_, err := opensearch.Do(ctx, i.openSearchProjectClient.Client, http.MethodGet, req, &settings)
 	if err != nil {
 		return nil, err
 	}
@@ -362,7 +433,11 @@ func (i *IndexFunction) GetIndexSettings(index string) (map[string]interface{},
 }
 
 func (i *IndexFunction) SetIndexSettings(index string, settingsBody io.Reader) error {
-	ctx := context.Background()
+	return i.SetIndexSettingsContext(context.Background(), index, settingsBody)
+}
+
+// SetIndexSettingsContext is like [IndexFunction.SetIndexSettings], but the request is cancelled when the context is done.
+func (i *IndexFunction) SetIndexSettingsContext(ctx context.Context, index string, settingsBody io.Reader) error {
 
 	// Apply the settings to the index
 	settingsPutResp, err := i.openSearchProjectClient.Indices.Settings.Put(
@@ -370,6 +445,7 @@ func (i *IndexFunction) SetIndexSettings(index string, settingsBody io.Reader) e
 		opensearchapi.SettingsPutReq{
 			Indices: []string{index},
 			Body:    settingsBody,
+			Header:  requestHeader(ctx),
 		},
 	)
 	if err != nil {
@@ -387,7 +463,11 @@ func (i *IndexFunction) SetIndexSettings(index string, settingsBody io.Reader) e
 }
 
 func (i *IndexFunction) ForceMerge(index string, maximumNumberOfSegments int) error {
-	ctx := context.Background()
+	return i.ForceMergeContext(context.Background(), index, maximumNumberOfSegments)
+}
+
+// ForceMergeContext is like [IndexFunction.ForceMerge], but the request is cancelled when the context is done.
+func (i *IndexFunction) ForceMergeContext(ctx context.Context, index string, maximumNumberOfSegments int) error {
 	forceMergeResponse, err := i.openSearchProjectClient.Indices.Forcemerge(
 		ctx,
 		&opensearchapi.IndicesForcemergeReq{
@@ -395,6 +475,7 @@ func (i *IndexFunction) ForceMerge(index string, maximumNumberOfSegments int) er
 			Params: opensearchapi.IndicesForcemergeParams{
 				MaxNumSegments: &maximumNumberOfSegments,
 			},
+			Header: requestHeader(ctx),
 		},
 	)
 	if err != nil {
@@ -0,0 +1,298 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openSearchClient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultPitKeepAlive  = time.Minute
+	defaultPitPageSize   = 1000
+	defaultPitMaxRetries = 3
+	defaultPitRetryDelay = time.Second
+	// pitCloseTimeout limits the time for closing the point in time after the stream ended.
+	pitCloseTimeout = 10 * time.Second
+)
+
+// PitStreamSettings configures the stream of [SearchPitStream]. Zero values are replaced by defaults.
+type PitStreamSettings struct {
+	// KeepAlive is the time the point in time is kept between two pages, defaults to one minute.
+	KeepAlive time.Duration
+	// PageSize is the number of documents fetched per request, defaults to 1000.
+	PageSize int
+	// MaxRetries is the number of retries of a page after a transient error, defaults to 3.
+	// A negative value disables retries.
+	MaxRetries int
+	// RetryDelay is the delay before the first retry, it is doubled for each further retry. Defaults to one second.
+	RetryDelay time.Duration
+}
+
+func (s PitStreamSettings) withDefaults() PitStreamSettings {
+	if s.KeepAlive <= 0 {
+		s.KeepAlive = defaultPitKeepAlive
+	}
+	if s.PageSize <= 0 {
+		s.PageSize = defaultPitPageSize
+	}
+	if s.MaxRetries < 0 {
+		s.MaxRetries = 0
+	} else if s.MaxRetries == 0 {
+		s.MaxRetries = defaultPitMaxRetries
+	}
+	if s.RetryDelay <= 0 {
+		s.RetryDelay = defaultPitRetryDelay
+	}
+	return s
+}
+
+// pitPage is the part of a search response needed for streaming with a point in time.
+type pitPage[T any] struct {
+	PitId string `json:"pit_id"`
+	Hits  struct {
+		Hits []struct {
+			Content T                 `json:"_source"`
+			Sort    []json.RawMessage `json:"sort"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// SearchPitStream searches for all documents in the given index matching the request body and returns them one
+// by one. Other than [Client.SearchStream] it uses a point in time (PIT) and `search_after` instead of the
+// legacy scroll API, so the documents are read from a consistent view of the index page by page.
+//
+// The request body must contain a `sort` with a unique tie breaker field, e.g. created by
+// openSearchQuery.AddSortingAndPaging, as the sort values of the last document of a page are the start of
+// the next page. `from` and `size` of the request body are ignored, use [PitStreamSettings.PageSize] instead.
+// A `search_after` in the request body is used as the start of the first page.
+//
+// Transient errors, like an unavailable cluster, are retried with the last sort values, so the stream continues
+// where it stopped. Other errors and exhausted retries end the stream with the error.
+// The point in time is closed when all documents are read, the context is done or the caller stops the iteration.
+func SearchPitStream[T any](ctx context.Context, c *Client, indexName string, requestBody []byte,
+	settings PitStreamSettings,
+) iter.Seq2[T, error] {
+	settings = settings.withDefaults()
+
+	return func(yield func(T, error) bool) {
+		var zero T
+		body, err := pitRequestBody(requestBody)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		pitId, err := c.createPit(ctx, indexName, settings.KeepAlive)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer func() {
+			// close the point in time also if the context is cancelled
+			closeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), pitCloseTimeout)
+			defer cancel()
+			c.deletePit(closeCtx, pitId)
+		}()
+
+		searchAfter := body["search_after"]
+		for page := 0; ; page++ {
+			log.Debug().Msgf("reading page %d of point in time stream", page)
+			response, err := searchPitPageWithRetries[T](ctx, c, body, pitId, searchAfter, settings)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if response.PitId != "" {
+				// the id of the point in time can change between requests
+				pitId = response.PitId
+			}
+
+			hits := response.Hits.Hits
+			for _, hit := range hits {
+				if !yield(hit.Content, nil) {
+					return
+				}
+			}
+			if len(hits) < settings.PageSize {
+				return
+			}
+
+			lastSort := hits[len(hits)-1].Sort
+			if len(lastSort) == 0 {
+				yield(zero, errors.New("search response contained no sort values to continue with"))
+				return
+			}
+			searchAfter, err = json.Marshal(lastSort)
+			if err != nil {
+				yield(zero, fmt.Errorf("failed to marshal sort values: %w", err))
+				return
+			}
+		}
+	}
+}
+
+// pitRequestBody parses the request body of a point in time stream.
+func pitRequestBody(requestBody []byte) (map[string]json.RawMessage, error) {
+	body := map[string]json.RawMessage{}
+	if len(bytes.TrimSpace(requestBody)) > 0 {
+		if err := json.Unmarshal(requestBody, &body); err != nil {
+			return nil, fmt.Errorf("failed to parse request body: %w", err)
+		}
+	}
+	if _, ok := body["sort"]; !ok {
+		return nil, errors.New("request body must contain a sort with a unique tie breaker for paging with search_after")
+	}
+	delete(body, "from")
+	return body, nil
+}
+
+// searchPitPageWithRetries requests the page after the given sort values, retrying transient errors.
+func searchPitPageWithRetries[T any](ctx context.Context, c *Client, body map[string]json.RawMessage, pitId string,
+	searchAfter json.RawMessage, settings PitStreamSettings,
+) (*pitPage[T], error) {
+	delay := settings.RetryDelay
+	for attempt := 0; ; attempt++ {
+		response, err := searchPitPage[T](ctx, c, body, pitId, searchAfter, settings)
+		if err == nil {
+			return response, nil
+		}
+		if attempt >= settings.MaxRetries || !isTransientError(ctx, err) {
+			return nil, err
+		}
+		log.Warn().Err(err).
+			Int("attempt_number", attempt+1).
+			Msgf("attempt %d: transient error in point in time search, retrying", attempt+1)
+		if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+			return nil, sleepErr
+		}
+		delay *= 2
+	}
+}
+
+// searchPitPage requests a single page of a point in time stream.
+func searchPitPage[T any](ctx context.Context, c *Client, body map[string]json.RawMessage, pitId string,
+	searchAfter json.RawMessage, settings PitStreamSettings,
+) (*pitPage[T], error) {
+	pageBody := make(map[string]any, len(body)+3)
+	for key, value := range body {
+		pageBody[key] = value
+	}
+	pageBody["size"] = settings.PageSize
+	pageBody["pit"] = map[string]string{
+		"id":         pitId,
+		"keep_alive": fmt.Sprintf("%dms", settings.KeepAlive.Milliseconds()),
+	}
+	if searchAfter != nil {
+		pageBody["search_after"] = searchAfter
+	} else {
+		delete(pageBody, "search_after")
+	}
+	requestBody, err := json.Marshal(pageBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	// the index is part of the point in time and must not be set
+	searchResponse, err := c.openSearchProjectClient.Search(ctx, &opensearchapi.SearchReq{
+		Body:   bytes.NewReader(requestBody),
+		Header: requestHeader(ctx),
+	})
+	if err != nil {
+		return nil, newSearchError(searchResponse, err)
+	}
+	responseBody := searchResponse.Inspect().Response.Body
+	defer responseBody.Close()
+
+	var page pitPage[T]
+	if err := jsoniter.NewDecoder(responseBody).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to parse search response: %w", err)
+	}
+	return &page, nil
+}
+
+// createPit creates a point in time for the given index and returns its id.
+func (c *Client) createPit(ctx context.Context, indexName string, keepAlive time.Duration) (string, error) {
+	response, err := c.openSearchProjectClient.PointInTime.Create(ctx, opensearchapi.PointInTimeCreateReq{
+		Indices: []string{indexName},
+		Params:  opensearchapi.PointInTimeCreateParams{KeepAlive: keepAlive},
+		Header:  requestHeader(ctx),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create point in time for index %s: %w", indexName, err)
+	}
+	return response.PitID, nil
+}
+
+// deletePit closes the point in time. Errors are only logged, as the point in time expires anyway.
+func (c *Client) deletePit(ctx context.Context, pitId string) {
+	_, err := c.openSearchProjectClient.PointInTime.Delete(ctx, opensearchapi.PointInTimeDeleteReq{
+		PitID:  []string{pitId},
+		Header: requestHeader(ctx),
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to close point in time")
+	}
+}
+
+// searchError is the error of a search request, keeping the status code of the response if there is one.
+type searchError struct {
+	statusCode int
+	err        error
+}
+
+func newSearchError(response *opensearchapi.SearchResp, err error) error {
+	statusCode := 0
+	if response != nil && response.Inspect().Response != nil {
+		statusCode = response.Inspect().Response.StatusCode
+	}
+	return &searchError{statusCode: statusCode, err: err}
+}
+
+func (e *searchError) Error() string {
+	return fmt.Sprintf("search request failed: %v", e.err)
+}
+
+func (e *searchError) Unwrap() error {
+	return e.err
+}
+
+// isTransientError returns true if a request failed with an error that is likely to go away when retrying,
+// e.g. a connection error or an overloaded cluster. Errors of the context are never transient.
+func isTransientError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var searchErr *searchError
+	if !errors.As(err, &searchErr) {
+		return false
+	}
+	return isTransientStatus(searchErr.statusCode)
+}
+
+// isTransientStatus returns true if the status code of a response indicates that the request may succeed
+// when retrying. The status code 0 stands for a missing response.
+func isTransientStatus(statusCode int) bool {
+	switch statusCode {
+	case 0, // no response, e.g. connection refused
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
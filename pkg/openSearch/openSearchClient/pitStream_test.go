@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openSearchClient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pitTestServer simulates the point in time API of OpenSearch with a fixed list of documents sorted by their id.
+type pitTestServer struct {
+	mu             sync.Mutex
+	documents      []int
+	failures       map[int]int // number of failures per request number
+	requests       int
+	searchAfters   []json.RawMessage
+	deletedPitIds  []string
+	createdIndices []string
+}
+
+func (s *pitTestServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/vulnerabilities/_search/point_in_time":
+		s.createdIndices = append(s.createdIndices, "vulnerabilities")
+		_, _ = w.Write([]byte(`{"pit_id":"pit-1","_shards":{"total":1,"successful":1},"creation_time":1}`))
+	case r.Method == http.MethodDelete && r.URL.Path == "/_search/point_in_time":
+		var body struct {
+			PitId []string `json:"pit_id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		s.deletedPitIds = append(s.deletedPitIds, body.PitId...)
+		_, _ = w.Write([]byte(`{"pits":[{"pit_id":"pit-1","successful":true}]}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/_search":
+		s.requests++
+		if s.failures[s.requests] > 0 {
+			s.failures[s.requests]--
+			s.requests--
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"type":"rejected_execution_exception"},"status":429}`))
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var request struct {
+			Size        int             `json:"size"`
+			SearchAfter json.RawMessage `json:"search_after"`
+			Pit         struct {
+				Id string `json:"id"`
+			} `json:"pit"`
+		}
+		_ = json.Unmarshal(body, &request)
+		s.searchAfters = append(s.searchAfters, request.SearchAfter)
+
+		start := 0
+		if request.SearchAfter != nil {
+			var after []int
+			_ = json.Unmarshal(request.SearchAfter, &after)
+			for start < len(s.documents) && s.documents[start] <= after[0] {
+				start++
+			}
+		}
+		end := min(start+request.Size, len(s.documents))
+
+		type hit struct {
+			Source Vulnerability `json:"_source"`
+			Sort   []int         `json:"sort"`
+		}
+		hits := []hit{}
+		for _, id := range s.documents[start:end] {
+			hits = append(hits, hit{Source: Vulnerability{Oid: string(rune('a' + id))}, Sort: []int{id}})
+		}
+		response := map[string]any{"pit_id": request.Pit.Id, "hits": map[string]any{"hits": hits}}
+		_ = json.NewEncoder(w).Encode(response)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestSearchPitStream(t *testing.T) {
+	query := []byte(`{"query":{"match_all":{}},"sort":[{"id":"asc"}],"from":10}`)
+
+	tests := map[string]struct {
+		failures         map[int]int
+		settings         PitStreamSettings
+		stopAfter        int
+		wantOids         []string
+		wantSearchAfters []string
+		wantErr          bool
+	}{
+		"all pages": {
+			settings:         PitStreamSettings{PageSize: 2},
+			wantOids:         []string{"a", "b", "c", "d", "e"},
+			wantSearchAfters: []string{"", "[1]", "[3]"},
+		},
+		"resume after transient error": {
+			failures:         map[int]int{2: 2},
+			settings:         PitStreamSettings{PageSize: 2, RetryDelay: time.Millisecond},
+			wantOids:         []string{"a", "b", "c", "d", "e"},
+			wantSearchAfters: []string{"", "[1]", "[3]"},
+		},
+		"retries exhausted": {
+			failures:         map[int]int{2: 2},
+			settings:         PitStreamSettings{PageSize: 2, MaxRetries: 1, RetryDelay: time.Millisecond},
+			wantOids:         []string{"a", "b"},
+			wantSearchAfters: []string{""},
+			wantErr:          true,
+		},
+		"stopped by caller": {
+			settings:         PitStreamSettings{PageSize: 2},
+			stopAfter:        3,
+			wantOids:         []string{"a", "b", "c"},
+			wantSearchAfters: []string{"", "[1]"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := &pitTestServer{documents: []int{0, 1, 2, 3, 4}, failures: tt.failures}
+			client := NewClient(newTestServerClient(t, server.handle), 1, time.Millisecond)
+			defer client.Close()
+
+			var oids []string
+			var streamErr error
+			for vulnerability, err := range SearchPitStream[Vulnerability](context.Background(), client,
+				"vulnerabilities", query, tt.settings) {
+				if err != nil {
+					streamErr = err
+					break
+				}
+				oids = append(oids, vulnerability.Oid)
+				if len(oids) == tt.stopAfter {
+					break
+				}
+			}
+
+			if tt.wantErr {
+				assert.Error(t, streamErr)
+			} else {
+				require.NoError(t, streamErr)
+			}
+			assert.Equal(t, tt.wantOids, oids)
+
+			searchAfters := make([]string, len(server.searchAfters))
+			for i, searchAfter := range server.searchAfters {
+				searchAfters[i] = string(searchAfter)
+			}
+			assert.Equal(t, tt.wantSearchAfters, searchAfters)
+			assert.Equal(t, []string{"pit-1"}, server.deletedPitIds, "point in time must be closed")
+		})
+	}
+
+	t.Run("missing sort", func(t *testing.T) {
+		server := &pitTestServer{}
+		client := NewClient(newTestServerClient(t, server.handle), 1, time.Millisecond)
+		defer client.Close()
+
+		for _, err := range SearchPitStream[Vulnerability](context.Background(), client, "vulnerabilities",
+			[]byte(`{"query":{"match_all":{}}}`), PitStreamSettings{}) {
+			assert.ErrorContains(t, err, "must contain a sort")
+		}
+		assert.Empty(t, server.createdIndices, "no point in time must be created")
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		server := &pitTestServer{documents: []int{0, 1, 2, 3, 4}}
+		client := NewClient(newTestServerClient(t, server.handle), 1, time.Millisecond)
+		defer client.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var streamErr error
+		for _, err := range SearchPitStream[Vulnerability](ctx, client, "vulnerabilities", query,
+			PitStreamSettings{PageSize: 2}) {
+			if err != nil {
+				streamErr = err
+				break
+			}
+			cancel()
+		}
+		assert.ErrorIs(t, streamErr, context.Canceled)
+		assert.Equal(t, []string{"pit-1"}, server.deletedPitIds, "point in time must be closed")
+	})
+}
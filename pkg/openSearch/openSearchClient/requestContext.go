@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openSearchClient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/logs"
+)
+
+// OpaqueIdHeader is the header used to identify the origin of a request in OpenSearch, e.g. in the tasks API
+// and the slow logs. It is set to the correlation ID of the context, see [logs.WithCorrelationID].
+const OpaqueIdHeader = "X-Opaque-Id"
+
+// requestHeader returns the additional header for a request with the given context. If the context carries
+// a correlation ID, it is passed on to OpenSearch in the [OpaqueIdHeader].
+func requestHeader(ctx context.Context) http.Header {
+	correlationID := logs.CorrelationID(ctx)
+	if correlationID == "" {
+		return nil
+	}
+	header := http.Header{}
+	header.Set(OpaqueIdHeader, correlationID)
+	return header
+}
+
+// sleepContext waits for the given duration like time.Sleep, but returns the error of the context early
+// if it is cancelled or its deadline is exceeded.
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
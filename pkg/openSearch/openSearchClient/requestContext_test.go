@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openSearchClient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/logs"
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServerClient(t *testing.T, handler http.HandlerFunc) *opensearchapi.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := opensearchapi.NewClient(opensearchapi.Config{
+		Client: opensearch.Config{Addresses: []string{server.URL}},
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestRequestContext(t *testing.T) {
+	t.Run("correlation ID is sent as opaque ID", func(t *testing.T) {
+		opaqueIds := make(chan string, 1)
+		client := NewClient(newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			opaqueIds <- r.Header.Get(OpaqueIdHeader)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"count":3}`))
+		}), 1, time.Millisecond)
+		defer client.Close()
+
+		ctx := logs.WithCorrelationID(context.Background(), "correlation-id")
+		count, err := client.CountContext(ctx, "index", []byte(`{}`))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.Equal(t, "correlation-id", <-opaqueIds)
+	})
+
+	t.Run("no opaque ID without correlation ID", func(t *testing.T) {
+		opaqueIds := make(chan string, 1)
+		iFunc := NewIndexFunction(newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			opaqueIds <- r.Header.Get(OpaqueIdHeader)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		}))
+
+		err := iFunc.DeleteIndexContext(context.Background(), "index")
+		require.NoError(t, err)
+		assert.Empty(t, <-opaqueIds)
+	})
+
+	t.Run("update retries stop at deadline", func(t *testing.T) {
+		client := NewClient(newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}), 100, time.Second)
+		defer client.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := client.SyncUpdateContext(ctx, "index", []byte(`{}`))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("queued update stops waiting at deadline", func(t *testing.T) {
+		release := make(chan struct{})
+		client := NewClient(newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}), 1, time.Millisecond)
+		defer client.Close()
+		defer close(release)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := client.UpdateContext(ctx, "index", []byte(`{}`))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
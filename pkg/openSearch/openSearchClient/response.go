@@ -95,6 +95,8 @@ type SearchResponseAggregation struct {
 	SumOtherDocCount        uint     `json:"sum_other_doc_count"`
 	Buckets                 []Bucket `json:"buckets"`
 	Value                   uint64   `json:"value"`
+	// AfterKey is the key of the last bucket of a composite aggregation, to request the following buckets.
+	AfterKey map[string]any `json:"after_key,omitempty"`
 }
 
 type SearchResponseAggregations map[string]SearchResponseAggregation
@@ -31,6 +31,12 @@ func NewSyncUpdateClient(osClient *opensearchapi.Client, maxRetries int, retryDe
 }
 
 func (s *SyncUpdateClient) Update(indexName string, requestBody []byte) ([]byte, error) {
+	return s.UpdateContext(context.Background(), indexName, requestBody)
+}
+
+// UpdateContext is like [SyncUpdateClient.Update], but the request and its retries are cancelled when the
+// context is done.
+func (s *SyncUpdateClient) UpdateContext(ctx context.Context, indexName string, requestBody []byte) ([]byte, error) {
 	log.Debug().Msgf("sync update requestBody: %s", string(requestBody))
 
 	var updateResponse *opensearchapi.UpdateByQueryResp
@@ -39,7 +45,7 @@ func (s *SyncUpdateClient) Update(indexName string, requestBody []byte) ([]byte,
 
 	for i := 0; i < s.updateMaxRetries; i++ {
 		updateResponse, err = s.client.UpdateByQuery(
-			context.Background(),
+			ctx,
 			opensearchapi.UpdateByQueryReq{
 				Indices: []string{indexName},
 				Body:    bytes.NewReader(requestBody),
@@ -47,11 +53,14 @@ func (s *SyncUpdateClient) Update(indexName string, requestBody []byte) ([]byte,
 					Pretty:  true,
 					Refresh: new(true),
 				},
+				Header: requestHeader(ctx),
 			},
 		)
 		if err != nil {
 			log.Warn().Err(err).Msgf("attempt %d: error in UpdateByQuery", i+1)
-			time.Sleep(s.updateRetryDelay)
+			if sleepErr := sleepContext(ctx, s.updateRetryDelay); sleepErr != nil {
+				return nil, sleepErr
+			}
 			continue
 		}
 
@@ -62,7 +71,9 @@ func (s *SyncUpdateClient) Update(indexName string, requestBody []byte) ([]byte,
 		}
 		if err != nil {
 			log.Warn().Err(err).Msgf("attempt %d: error in io.ReadAll", i+1)
-			time.Sleep(s.updateRetryDelay)
+			if sleepErr := sleepContext(ctx, s.updateRetryDelay); sleepErr != nil {
+				return nil, sleepErr
+			}
 			continue
 		}
 
@@ -76,7 +87,9 @@ func (s *SyncUpdateClient) Update(indexName string, requestBody []byte) ([]byte,
 			if len(failures.([]interface{})) > 0 {
 				err = fmt.Errorf("sync update returned failures: %v", failures)
 				log.Warn().Msgf("attempt %d: %v", i+1, err)
-				time.Sleep(s.updateRetryDelay)
+				if sleepErr := sleepContext(ctx, s.updateRetryDelay); sleepErr != nil {
+					return nil, sleepErr
+				}
 				continue
 			}
 		}
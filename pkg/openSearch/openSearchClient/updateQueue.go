@@ -26,6 +26,7 @@ type Request struct {
 	IndexName   string
 	RequestBody []byte
 	Response    chan Response // Use the new Response type
+	ctx         context.Context
 }
 
 // UpdateQueue is a queue for OpenSearch update requests.
@@ -76,16 +77,32 @@ func (q *UpdateQueue) Stop() {
 //
 // Returns: The response body or an error
 func (q *UpdateQueue) Update(indexName string, requestBody []byte) ([]byte, error) {
+	return q.UpdateContext(context.Background(), indexName, requestBody)
+}
+
+// UpdateContext is like [UpdateQueue.Update], but returns the error of the context if it is done before
+// the update finished. The context is used for the update request and its retries as well.
+func (q *UpdateQueue) UpdateContext(ctx context.Context, indexName string, requestBody []byte) ([]byte, error) {
 	request := &Request{
 		IndexName:   indexName,
 		RequestBody: requestBody,
-		Response:    make(chan Response),
+		// buffered, so that the queue doesn't block if the caller stopped waiting for the response
+		Response: make(chan Response, 1),
+		ctx:      ctx,
 	}
 
-	q.queue <- request
+	select {
+	case q.queue <- request:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 
-	response := <-request.Response
-	close(request.Response)
+	var response Response
+	select {
+	case response = <-request.Response:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 
 	if response.Err != nil {
 		return nil, response.Err
@@ -111,7 +128,11 @@ func (q *UpdateQueue) run() {
 	for {
 		select {
 		case request := <-q.queue:
-			responseBody, err := q.update(request.IndexName, request.RequestBody)
+			ctx := request.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			responseBody, err := q.update(ctx, request.IndexName, request.RequestBody)
 			if err != nil {
 				log.Error().Err(err).Msgf("update request failed %v", responseBody)
 				request.Response <- Response{Err: err}
@@ -125,7 +146,7 @@ func (q *UpdateQueue) run() {
 	}
 }
 
-func (q *UpdateQueue) update(indexName string, requestBody []byte) ([]byte, error) {
+func (q *UpdateQueue) update(ctx context.Context, indexName string, requestBody []byte) ([]byte, error) {
 	log.Debug().Msgf("update requestBody: %s", string(requestBody))
 
 	var updateResponse *opensearchapi.UpdateByQueryResp
@@ -134,20 +155,23 @@ func (q *UpdateQueue) update(indexName string, requestBody []byte) ([]byte, erro
 
 	for i := 0; i < q.updateMaxRetries; i++ {
 		updateResponse, err = q.client.UpdateByQuery(
-			context.Background(),
+			ctx,
 			opensearchapi.UpdateByQueryReq{
 				Indices: []string{indexName},
 				Body:    bytes.NewReader(requestBody),
 				Params: opensearchapi.UpdateByQueryParams{
 					Pretty: true,
 				},
+				Header: requestHeader(ctx),
 			},
 		)
 		if err != nil {
 			log.Warn().Err(err).
 				Int("attempt_number", i+1).
 				Msgf("attempt %d: error in UpdateByQuery", i+1)
-			time.Sleep(q.updateRetryDelay)
+			if sleepErr := sleepContext(ctx, q.updateRetryDelay); sleepErr != nil {
+				return nil, sleepErr
+			}
 			continue
 		}
 
@@ -157,7 +181,9 @@ func (q *UpdateQueue) update(indexName string, requestBody []byte) ([]byte, erro
 			log.Warn().Err(err).
 				Int("attempt_number", i+1).
 				Msgf("attempt %d: error in io.ReadAll", i+1)
-			time.Sleep(q.updateRetryDelay)
+			if sleepErr := sleepContext(ctx, q.updateRetryDelay); sleepErr != nil {
+				return nil, sleepErr
+			}
 			continue
 		}
 
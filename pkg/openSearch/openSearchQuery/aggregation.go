@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openSearchQuery
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/esquery"
+	esextensions "github.com/greenbone/opensight-golang-libraries/pkg/openSearch/esextension"
+	"github.com/greenbone/opensight-golang-libraries/pkg/openSearch/openSearchClient"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/aggregation"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
+)
+
+// AggregationName is the name of the composite aggregation added by [AddAggregation].
+const AggregationName = "aggregation"
+
+// AddAggregation adds a composite aggregation named [AggregationName] for the aggregation request to the search.
+// Each group-by field becomes a terms source (or a date histogram source), each metric a sub aggregation named
+// like its key. `fieldMapping` maps the fields of the request to the document fields.
+// The page size of the paging request limits the number of buckets. Paging by page index is not supported
+// for composite aggregations, use the cursor of the previous response instead, see [AggregationResponseOf].
+// As only the buckets are of interest, no documents are returned by the search.
+func AddAggregation(search *esquery.SearchRequest, request *aggregation.Request, fieldMapping map[string]string,
+	pagingRequest *paging.Request,
+) (*esquery.SearchRequest, error) {
+	if err := aggregation.ValidateAggregationRequest(request); err != nil {
+		return nil, err
+	}
+
+	composite := esextensions.Composite(AggregationName)
+	groupFields := make([]string, 0, len(request.GroupBy))
+	for _, groupBy := range request.GroupBy {
+		field, err := documentFieldOf(groupBy.Field, fieldMapping)
+		if err != nil {
+			return nil, err
+		}
+		if groupBy.DateHistogram != nil {
+			composite.Sources(esextensions.DateHistogram(groupBy.Field, field, string(groupBy.DateHistogram.Interval)).
+				MissingBucket())
+		} else {
+			composite.Sources(esextensions.Terms(groupBy.Field, field).MissingBucket())
+		}
+		groupFields = append(groupFields, groupBy.Field)
+	}
+
+	for _, metric := range request.Metrics {
+		field, err := documentFieldOf(metric.Field, fieldMapping)
+		if err != nil {
+			return nil, err
+		}
+		var metricAggregation esquery.Aggregation
+		switch metric.Metric {
+		case filter.AggregateMetricSum:
+			metricAggregation = esquery.Sum(metric.Key(), field)
+		case filter.AggregateMetricMin:
+			metricAggregation = esquery.Min(metric.Key(), field)
+		case filter.AggregateMetricMax:
+			metricAggregation = esquery.Max(metric.Key(), field)
+		case filter.AggregateMetricAvg:
+			metricAggregation = esquery.Avg(metric.Key(), field)
+		case filter.AggregateMetricValueCount:
+			metricAggregation = esquery.ValueCount(metric.Key(), field)
+		}
+		composite.Aggregations(metricAggregation)
+	}
+
+	if pagingRequest != nil {
+		if pagingRequest.PageIndex != 0 {
+			return nil, fmt.Errorf("paging by page index is not supported for aggregations, got page index: %d",
+				pagingRequest.PageIndex)
+		}
+		if pagingRequest.PageSize > 0 {
+			composite.Size(uint64(pagingRequest.PageSize))
+		}
+		if pagingRequest.Cursor != "" {
+			cursor, err := paging.DecodeCursorOfKind(pagingRequest.Cursor, paging.CursorKindAggregation)
+			if err != nil {
+				return nil, err
+			}
+			if !cursor.HasSortColumns(groupFields) || len(cursor.SortValues) != len(groupFields) {
+				return nil, fmt.Errorf("cursor was created for group-by fields %v, but group-by fields are %v",
+					cursor.SortColumns, groupFields)
+			}
+			after := make(map[string]any, len(groupFields))
+			for i, field := range groupFields {
+				after[field] = cursor.SortValues[i]
+			}
+			composite.AfterKey(after)
+		}
+	}
+
+	return search.Aggs(composite).Size(0), nil
+}
+
+// AggregationResponseOf converts the result of the aggregation added by [AddAggregation] into the response
+// of the aggregation request. Keys of date histograms are returned as time.Time.
+// If there are more buckets, `nextCursor` is set, which can be used in the paging request to get the following buckets.
+func AggregationResponseOf(result openSearchClient.SearchResponseAggregation, request *aggregation.Request,
+) (response *aggregation.Response, nextCursor string, err error) {
+	response = &aggregation.Response{Buckets: make([]aggregation.Bucket, 0, len(result.Buckets))}
+	for _, resultBucket := range result.Buckets {
+		resultKeys, ok := resultBucket.Key.(map[string]any)
+		if !ok {
+			return nil, "", fmt.Errorf("unexpected bucket key of type %T", resultBucket.Key)
+		}
+
+		bucket := aggregation.Bucket{Keys: make(map[string]any, len(request.GroupBy)), Count: uint64(resultBucket.DocCount)}
+		for _, groupBy := range request.GroupBy {
+			key := resultKeys[groupBy.Field]
+			if groupBy.DateHistogram != nil && key != nil {
+				key, err = timeOfEpochMillis(key)
+				if err != nil {
+					return nil, "", err
+				}
+			}
+			bucket.Keys[groupBy.Field] = key
+		}
+
+		if len(request.Metrics) > 0 {
+			bucket.Metrics = make(map[string]*float64, len(request.Metrics))
+			for _, metric := range request.Metrics {
+				var value *float64
+				if number, ok := resultBucket.Aggs[metric.Key()].Value.(float64); ok {
+					value = &number
+				}
+				bucket.Metrics[metric.Key()] = value
+			}
+		}
+		response.Buckets = append(response.Buckets, bucket)
+	}
+
+	if len(result.AfterKey) > 0 && len(result.Buckets) > 0 {
+		cursor := paging.Cursor{Kind: paging.CursorKindAggregation}
+		for _, groupBy := range request.GroupBy {
+			cursor.SortColumns = append(cursor.SortColumns, groupBy.Field)
+			cursor.SortValues = append(cursor.SortValues, result.AfterKey[groupBy.Field])
+		}
+		nextCursor, err = paging.EncodeCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	return response, nextCursor, nil
+}
+
+func documentFieldOf(field string, fieldMapping map[string]string) (string, error) {
+	documentField, ok := fieldMapping[field]
+	if !ok {
+		return "", filter.NewInvalidFilterFieldError("missing field mapping for '%s'", field)
+	}
+	return documentField, nil
+}
+
+func timeOfEpochMillis(value any) (time.Time, error) {
+	millis, ok := value.(float64)
+	if !ok {
+		return time.Time{}, fmt.Errorf("unexpected date histogram key of type %T", value)
+	}
+	return time.UnixMilli(int64(millis)).UTC(), nil
+}
@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openSearchQuery
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/esquery"
+	"github.com/greenbone/opensight-golang-libraries/pkg/openSearch/openSearchClient"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/aggregation"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var aggregationFieldMapping = map[string]string{
+	"severity": "vulnerabilityTest.severity",
+	"hostname": "asset.hostname.keyword",
+	"created":  "created",
+}
+
+func TestAddAggregation(t *testing.T) {
+	request := &aggregation.Request{
+		GroupBy: []aggregation.GroupBy{
+			{Field: "hostname"},
+			{Field: "created", DateHistogram: &aggregation.DateHistogram{Interval: aggregation.IntervalDay}},
+		},
+		Metrics: []aggregation.Metric{
+			{Field: "severity", Metric: filter.AggregateMetricMax},
+			{Field: "severity", Metric: filter.AggregateMetricValueCount},
+		},
+	}
+	cursor, err := paging.EncodeCursor(paging.Cursor{
+		Kind:        paging.CursorKindAggregation,
+		SortColumns: []string{"hostname", "created"},
+		SortValues:  []any{"host-a", 1704067200000},
+	})
+	require.NoError(t, err)
+	documentCursor, err := paging.EncodeCursor(paging.Cursor{
+		SortColumns:     []string{"hostname", "created"},
+		SortValues:      []any{"host-a", 1704067200000},
+		TieBreakerValue: "doc-1",
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		request              *aggregation.Request
+		pagingRequest        *paging.Request
+		expectedQueryJson    string
+		expectedErrorMessage string
+	}{
+		"group by and metrics": {
+			request:           request,
+			expectedQueryJson: `{"aggs":{"aggregation":{"composite":{"sources":[{"hostname":{"terms":{"field":"asset.hostname.keyword","missing_bucket":true,"order":"asc"}}},{"created":{"date_histogram":{"calendar_interval":"day","field":"created","missing_bucket":true,"order":"asc"}}}]},"aggregations":{"max(severity)":{"max":{"field":"vulnerabilityTest.severity"}},"valueCount(severity)":{"value_count":{"field":"vulnerabilityTest.severity"}}}}},"size":0}`,
+		},
+		"page size and cursor": {
+			request:           &aggregation.Request{GroupBy: request.GroupBy},
+			pagingRequest:     &paging.Request{PageSize: 10, Cursor: cursor},
+			expectedQueryJson: `{"aggs":{"aggregation":{"composite":{"after":{"created":1704067200000,"hostname":"host-a"},"size":10,"sources":[{"hostname":{"terms":{"field":"asset.hostname.keyword","missing_bucket":true,"order":"asc"}}},{"created":{"date_histogram":{"calendar_interval":"day","field":"created","missing_bucket":true,"order":"asc"}}}]}}},"size":0}`,
+		},
+		"cursor of other group by fields": {
+			request:              &aggregation.Request{GroupBy: []aggregation.GroupBy{{Field: "hostname"}}},
+			pagingRequest:        &paging.Request{Cursor: cursor},
+			expectedErrorMessage: "cursor was created for group-by fields",
+		},
+		"cursor of document search": {
+			request:              request,
+			pagingRequest:        &paging.Request{Cursor: documentCursor},
+			expectedErrorMessage: "expected aggregation cursor, got document cursor",
+		},
+		"page index": {
+			request:              request,
+			pagingRequest:        &paging.Request{PageIndex: 1, PageSize: 10},
+			expectedErrorMessage: "paging by page index is not supported",
+		},
+		"missing field mapping": {
+			request:              &aggregation.Request{GroupBy: []aggregation.GroupBy{{Field: "unknown"}}},
+			expectedErrorMessage: "missing field mapping for 'unknown'",
+		},
+		"invalid request": {
+			request:              &aggregation.Request{},
+			expectedErrorMessage: "needs at least one group-by field",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			search, err := AddAggregation(esquery.Search(), tc.request, aggregationFieldMapping, tc.pagingRequest)
+			if tc.expectedErrorMessage != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+				return
+			}
+			require.NoError(t, err)
+
+			queryJson, err := json.Marshal(search.Map())
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedQueryJson, string(queryJson))
+		})
+	}
+}
+
+func TestAggregationResponseOf(t *testing.T) {
+	request := &aggregation.Request{
+		GroupBy: []aggregation.GroupBy{
+			{Field: "hostname"},
+			{Field: "created", DateHistogram: &aggregation.DateHistogram{Interval: aggregation.IntervalDay}},
+		},
+		Metrics: []aggregation.Metric{{Field: "severity", Metric: filter.AggregateMetricMax}},
+	}
+	responseJson := `{
+		"took": 1,
+		"aggregations": {
+			"aggregation": {
+				"after_key": {"hostname": "host-b", "created": 1704153600000},
+				"buckets": [
+					{"key": {"hostname": "host-a", "created": 1704067200000}, "doc_count": 3, "max(severity)": {"value": 7.5}},
+					{"key": {"hostname": "host-b", "created": null}, "doc_count": 1, "max(severity)": {"value": null}}
+				]
+			}
+		}
+	}`
+	result, err := openSearchClient.UnmarshalSearchResponse[any]([]byte(responseJson))
+	require.NoError(t, err)
+
+	response, nextCursor, err := AggregationResponseOf(result.Aggregations[AggregationName], request)
+	require.NoError(t, err)
+
+	maxSeverity := 7.5
+	assert.Equal(t, &aggregation.Response{Buckets: []aggregation.Bucket{
+		{
+			Keys:    map[string]any{"hostname": "host-a", "created": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			Count:   3,
+			Metrics: map[string]*float64{"max(severity)": &maxSeverity},
+		},
+		{
+			Keys:    map[string]any{"hostname": "host-b", "created": nil},
+			Count:   1,
+			Metrics: map[string]*float64{"max(severity)": nil},
+		},
+	}}, response)
+
+	cursor, err := paging.DecodeCursorOfKind(nextCursor, paging.CursorKindAggregation)
+	require.NoError(t, err)
+	assert.Nil(t, cursor.TieBreakerValue)
+	assert.Equal(t, []string{"hostname", "created"}, cursor.SortColumns)
+	assert.Equal(t, []any{"host-b", json.Number("1704153600000")}, cursor.SortValues)
+}
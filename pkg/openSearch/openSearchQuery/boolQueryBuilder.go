@@ -34,10 +34,7 @@ type (
 )
 
 // RatingRange represent a closed interval of float32 values.
-type RatingRange struct {
-	Min float32 // Lower bound of the rating range (inclusive)
-	Max float32 // Upper bound of the rating range (inclusive)
-}
+type RatingRange = filter.RatingRange
 
 // QuerySettings is used to configure the query builder.
 type QuerySettings struct {
@@ -54,6 +51,9 @@ type QuerySettings struct {
 	FilterFieldMapping map[string]string
 	// StringFieldRating is a map for field names with a rating. The rating is used to determine the compare order of the field in the query.
 	StringFieldRating map[string]map[string]RatingRange
+	// SearchFields are the fields matched by the free text search of the result selector, see
+	// [BoolQueryBuilder.AddSearch]. A field can be boosted like `name^2`.
+	SearchFields []string
 }
 
 // NestedQueryFieldDefinition is a definition of a nested query field.
@@ -158,9 +158,10 @@ func (q *BoolQueryBuilder) createOperatorMapping() map[filter.CompareOperator]qu
 }
 
 // AddFilterRequest adds a filter request to this query.
-// The filter request is translated into a bool query.
+// The filter request is translated into a bool query. Nested groups of the request
+// are translated into nested bool queries.
 func (q *BoolQueryBuilder) AddFilterRequest(request *filter.Request) error {
-	if request == nil || len(request.Fields) == 0 {
+	if request.IsEmpty() {
 		return nil
 	}
 
@@ -172,12 +173,22 @@ func (q *BoolQueryBuilder) AddFilterRequest(request *filter.Request) error {
 	operatorMapping := q.createOperatorMapping()
 
 	for _, field := range effectiveRequest.Fields {
+		if field.Match == filter.ValueMatchAll {
+			return fmt.Errorf("field '%s' with unsupported match '%s'", field.Name, field.Match)
+		}
 		if handler, ok := operatorMapping[field.Operator]; ok {
 			handler(field.Name, field.Keys, field.Value)
 		} else {
 			return fmt.Errorf("field '%s' with unknown operator '%s'", field.Name, field.Operator)
 		}
 	}
+	for index, group := range request.Groups {
+		groupQuery, err := q.groupQuery(group)
+		if err != nil {
+			return fmt.Errorf("failed to transform filter group %d: %w", index, err)
+		}
+		q.Must = append(q.Must, groupQuery)
+	}
 	switch effectiveRequest.Operator {
 	case filter.LogicOperatorAnd:
 		q.query = q.query.
@@ -205,6 +216,28 @@ func (q *BoolQueryBuilder) AddFilterRequest(request *filter.Request) error {
 	}
 }
 
+// groupQuery translates a nested filter group into a separate bool query,
+// using the same settings and compare operators as this builder.
+func (q *BoolQueryBuilder) groupQuery(group filter.Request) (*esquery.BoolQuery, error) {
+	// empty groups are invalid, see [filter.Request.IsEmpty]
+	if group.IsEmpty() {
+		return nil, fmt.Errorf("filter group is empty")
+	}
+	if group.Operator == "" && len(group.Fields)+len(group.Groups) == 1 { // for a single entry `Operator` is not relevant
+		group.Operator = filter.LogicOperatorAnd
+	}
+	groupBuilder := &BoolQueryBuilder{
+		querySettings:    q.querySettings,
+		compareOperators: q.compareOperators,
+		query:            esquery.Bool(),
+	}
+	err := groupBuilder.AddFilterRequest(&group)
+	if err != nil {
+		return nil, err
+	}
+	return groupBuilder.Build(), nil
+}
+
 func effectiveFilterFields(filterRequest filter.Request, fieldMapping map[string]string) (filter.Request, error) {
 	var filterFields []filter.RequestField
 	for _, field := range filterRequest.Fields {
@@ -232,6 +265,7 @@ func createMappedField(dtoField filter.RequestField, fieldMapping map[string]str
 		Keys:     dtoField.Keys,
 		Name:     entityName,
 		Value:    dtoField.Value,
+		Match:    dtoField.Match,
 	}, nil
 }
 
@@ -48,6 +48,113 @@ func TestBoolQueryBuilder_BasicFunctionality(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		"should translate nested groups into nested bool queries": {
+			filterRequest: &filter.Request{
+				Fields: []filter.RequestField{
+					{
+						Name:     "testName",
+						Operator: filter.CompareOperatorContains,
+						Value:    "a",
+					},
+				},
+				Groups: []filter.Request{
+					{
+						Fields: []filter.RequestField{
+							{
+								Name:     "testName",
+								Operator: filter.CompareOperatorIsEqualTo,
+								Value:    "b",
+							},
+							{
+								Name:     "testName",
+								Operator: filter.CompareOperatorIsNotEqualTo,
+								Value:    "c",
+							},
+						},
+						Operator: filter.LogicOperatorOr,
+					},
+				},
+				Operator: filter.LogicOperatorAnd,
+			},
+			wantJSON: `{"query":{"bool":{"must":[
+				{"wildcard":{"testName.keyword":{"value":"*a*"}}},
+				{"bool":{"minimum_should_match":1,"should":[
+					{"term":{"testName":{"value":"b"}}},
+					{"bool":{"must_not":[{"term":{"testName":{"value":"c"}}}]}}
+				]}}
+			]}}}`,
+			wantErr: false,
+		},
+		"should fail with empty nested group": {
+			filterRequest: &filter.Request{
+				Fields: []filter.RequestField{
+					{
+						Name:     "testName",
+						Operator: filter.CompareOperatorBeginsWith,
+						Value:    "start",
+					},
+				},
+				Groups:   []filter.Request{{Operator: filter.LogicOperatorAnd}},
+				Operator: filter.LogicOperatorAnd,
+			},
+			wantErr: true,
+		},
+		"should default to AND for nested group with single field": {
+			filterRequest: &filter.Request{
+				Groups: []filter.Request{
+					{
+						Fields: []filter.RequestField{
+							{
+								Name:     "testName",
+								Operator: filter.CompareOperatorBeginsWith,
+								Value:    "start",
+							},
+						},
+					},
+				},
+				Operator: filter.LogicOperatorAnd,
+			},
+			wantJSON: `{"query":{"bool":{"must":[
+				{"bool":{"must":[{"prefix":{"testName.keyword":{"value":"start"}}}]}}
+			]}}}`,
+			wantErr: false,
+		},
+		"should fail with invalid nested group (missing logic operator)": {
+			filterRequest: &filter.Request{
+				Groups: []filter.Request{
+					{
+						Fields: []filter.RequestField{
+							{
+								Name:     "testName",
+								Operator: filter.CompareOperatorBeginsWith,
+								Value:    "start",
+							},
+							{
+								Name:     "testName",
+								Operator: filter.CompareOperatorContains,
+								Value:    "a",
+							},
+						},
+					},
+				},
+				Operator: filter.LogicOperatorAnd,
+			},
+			wantErr: true,
+		},
+		"should fail with unsupported match of all values": {
+			filterRequest: &filter.Request{
+				Fields: []filter.RequestField{
+					{
+						Name:     "testName",
+						Operator: filter.CompareOperatorBeginsWith,
+						Match:    filter.ValueMatchAll,
+						Value:    []any{"a", "b"},
+					},
+				},
+				Operator: filter.LogicOperatorAnd,
+			},
+			wantErr: true,
+		},
 		"should fail with invalid filter request (empty field name)": {
 			filterRequest: &filter.Request{
 				Fields: []filter.RequestField{
@@ -125,6 +232,59 @@ func TestBoolQueryBuilder_TermFilter(t *testing.T) {
 	})
 }
 
+func TestBoolQueryBuilder_NormalizedFilter(t *testing.T) {
+	requestOptions := []filter.RequestOption{
+		{Name: filter.NewReadableValue("", "ip"), Control: filter.RequestOptionType{Type: filter.ControlTypeString}},
+		{Name: filter.NewReadableValue("", "created"), Control: filter.RequestOptionType{Type: filter.ControlTypeDateTime}},
+	}
+	settings := QuerySettings{
+		FilterFieldMapping: map[string]string{"ip": "ip", "created": "created"},
+	}
+
+	tests := map[string]struct {
+		field    filter.RequestField
+		wantJSON string
+	}{
+		"ip address": {
+			field: filter.RequestField{Name: "ip", Operator: filter.CompareOperatorIsIpEqualTo, Value: "10.0.0.1"},
+			wantJSON: `{"query":{"bool":{"must":[
+				{"term":{"ip.keyword":{"value":"10.0.0.1"}}}
+			]}}}`,
+		},
+		"ip addresses": {
+			field: filter.RequestField{Name: "ip", Operator: filter.CompareOperatorIsIpNotEqualTo,
+				Value: []any{"10.0.0.1", "10.0.0.2/32"}},
+			wantJSON: `{"query":{"bool":{"must_not":[
+				{"terms":{"ip.keyword":["10.0.0.1","10.0.0.2"]}}
+			]}}}`,
+		},
+		"between dates": {
+			field: filter.RequestField{Name: "created", Operator: filter.CompareOperatorBetweenDates,
+				Value: []any{"2024-05-01", "2024-05-31T23:59:59Z"}},
+			wantJSON: `{"query":{"bool":{"must":[
+				{"range":{"created":{"gte":"2024-05-01T00:00:00Z","lte":"2024-05-31T23:59:59Z"}}}
+			]}}}`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			normalized, err := filter.NormalizeFilter(&filter.Request{
+				Operator: filter.LogicOperatorAnd,
+				Fields:   []filter.RequestField{tc.field},
+			}, requestOptions)
+			require.NoError(t, err)
+
+			query := testBoolQueryBuilderWrapper{}
+			query.BoolQueryBuilder = NewBoolQueryBuilder(&settings)
+			require.NoError(t, query.AddFilterRequest(normalized))
+
+			json, err := query.toJson()
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.wantJSON, json)
+		})
+	}
+}
+
 func TestFilterQueryOperatorAnd(t *testing.T) {
 	mixedTests := map[string]struct {
 		file     string
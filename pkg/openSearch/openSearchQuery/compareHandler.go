@@ -316,7 +316,8 @@ func getStringRange(fieldName string, rating string, querySettings *QuerySetting
 // HandleCompareOperatorBetweenDates constructs an OpenSearch range query for a given date field.
 // It accepts a field name and a field value, which must be either:
 // - A slice of two time.Time values ([]time.Time), representing the start and end of the range, or
-// - A slice of two RFC3339Nano-formatted strings ([]string), which are parsed into time.Time,  representing the start and end of the range.
+// - A slice of two RFC3339Nano-formatted strings ([]string), which are parsed into time.Time,  representing the start and end of the range, or
+// - A slice of two time.Time values or RFC3339Nano-formatted strings ([]any), as produced by normalizing the filter request.
 //
 // The generated range query is inclusive of both the lower and upper bounds.
 // If a document’s timestamp is exactly equal to the start or end date, it will still match the query.
@@ -347,8 +348,34 @@ func HandleCompareOperatorBetweenDates(fieldName string, _ []string, fieldValue
 		return esquery.Range(fieldName).
 			Gte(start).
 			Lte(end)
+	case []any:
+		if len(dateValue) != 2 {
+			log.Error().Msgf("invalid fieldValue length for []any: %T", fieldValue)
+			return esquery.MatchNone()
+		}
+		start, err1 := toDate(dateValue[0])
+		end, err2 := toDate(dateValue[1])
+		if err1 != nil || err2 != nil {
+			log.Error().Msgf("invalid date in []any: %v, %v", err1, err2)
+			return esquery.MatchNone()
+		}
+		return esquery.Range(fieldName).
+			Gte(start).
+			Lte(end)
 	default:
-		log.Error().Msgf("unsupported fieldValue type: %T, want: []string, []time.Time", fieldValue)
+		log.Error().Msgf("unsupported fieldValue type: %T, want: []string, []time.Time, []any", fieldValue)
 		return esquery.MatchNone()
 	}
 }
+
+// toDate returns the value as time.Time, it has to be either a time.Time or a RFC3339Nano-formatted string.
+func toDate(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		return time.Parse(time.RFC3339Nano, v)
+	default:
+		return time.Time{}, fmt.Errorf("unsupported date type %T", value)
+	}
+}
@@ -111,11 +111,11 @@ func TestHandleRatingComparison(t *testing.T) {
 	querySettings := QuerySettings{
 		StringFieldRating: map[string]map[string]RatingRange{
 			"severityClass": {
-				"Log":      {0, 0},
-				"Low":      {0.1, 3.9},
-				"Medium":   {4, 6.9},
-				"High":     {7, 8.9},
-				"Critical": {9, 10},
+				"Log":      {Min: 0, Max: 0},
+				"Low":      {Min: 0.1, Max: 3.9},
+				"Medium":   {Min: 4, Max: 6.9},
+				"High":     {Min: 7, Max: 8.9},
+				"Critical": {Min: 9, Max: 10},
 			},
 		},
 	}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openSearchQuery
+
+import (
+	"errors"
+
+	"github.com/aquasecurity/esquery"
+	esextensions "github.com/greenbone/opensight-golang-libraries/pkg/openSearch/esextension"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
+)
+
+// ScoreField is the field containing the relevance of a document for the query, used for sorting by rank.
+const ScoreField = "_score"
+
+// AddSearch adds the free text search of the result selector to this query. The search text in web search syntax
+// is matched against the configured search fields with a `simple_query_string` query, see [esextensions.WebSearch].
+// Without search text the query is not changed.
+func (q *BoolQueryBuilder) AddSearch(search *query.Search) error {
+	if search.IsEmpty() {
+		return nil
+	}
+	if len(q.querySettings.SearchFields) == 0 {
+		return errors.New("free text search is not supported, no search fields are configured")
+	}
+
+	q.query = q.query.Must(esextensions.WebSearch(search.Text, q.querySettings.SearchFields...))
+	return nil
+}
+
+// AddRankSorting sorts the documents by their relevance for the search text, if requested by the search.
+// It has to be called before [AddSortingAndPaging], so that the requested sort keys only order documents
+// of equal relevance. As the relevance is not part of the cursor, paging by cursor is not supported.
+func AddRankSorting(searchRequest *esquery.SearchRequest, search *query.Search, pagingRequest *paging.Request,
+) (*esquery.SearchRequest, error) {
+	if search.IsEmpty() || !search.SortByRank {
+		return searchRequest, nil
+	}
+	if pagingRequest != nil && pagingRequest.Cursor != "" {
+		return nil, errors.New("paging by cursor is not supported when sorting by rank")
+	}
+	return searchRequest.Sort(ScoreField, esquery.OrderDesc), nil
+}
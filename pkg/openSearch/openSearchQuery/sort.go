@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 
+	esextensions "github.com/greenbone/opensight-golang-libraries/pkg/openSearch/esextension"
 	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
 	"github.com/greenbone/opensight-golang-libraries/pkg/query/sorting"
 
@@ -20,41 +21,62 @@ type EffectiveSortField struct {
 	AggregationValue string
 }
 
+// AddOrder orders the buckets of the terms aggregation by the requested sort column.
+// For sorting by multiple keys use [AddCompoundOrder], as esquery.TermsAggregation only supports a single order.
 func AddOrder(aggregation *esquery.TermsAggregation, sortingRequest *sorting.Request,
 	sortFieldMapping map[string]EffectiveSortField,
 ) (*esquery.TermsAggregation, error) {
 	if sortingRequest != nil {
-		field, err := effectiveSortFieldOf(*sortingRequest, sortFieldMapping)
+		keys := sortingRequest.SortKeys()
+		if len(keys) > 1 {
+			return nil, fmt.Errorf("ordering by %d sort keys is not supported, use AddCompoundOrder instead", len(keys))
+		}
+		field, err := effectiveSortFieldOf(keys[0].Column, sortFieldMapping)
 		if err != nil {
 			return nil, err
 		}
-		return aggregation.Order(map[string]string{field.AggregationValue: sortingRequest.SortDirection.String()}), nil
+		return aggregation.Order(map[string]string{field.AggregationValue: keys[0].Direction.String()}), nil
 	}
 	return aggregation, nil
 }
 
+// AddCompoundOrder orders the buckets of the terms aggregation by all sort keys of the sorting request.
+// The position of null values can't be controlled for aggregations, so it is ignored.
+func AddCompoundOrder(aggregation *esquery.TermsAggregation, sortingRequest *sorting.Request,
+	sortFieldMapping map[string]EffectiveSortField,
+) (*esextensions.OrderedTermsAggregation, error) {
+	orderedAggregation := esextensions.OrderedTerms(aggregation)
+	for _, key := range sortingRequest.SortKeys() {
+		field, err := effectiveSortFieldOf(key.Column, sortFieldMapping)
+		if err != nil {
+			return nil, err
+		}
+		orderedAggregation.Order(field.AggregationValue, key.Direction.String())
+	}
+	return orderedAggregation, nil
+}
+
 func AddMaxAggForSorting(aggs []esquery.Aggregation, sortingRequest *sorting.Request,
 	sortFieldMapping map[string]EffectiveSortField,
 ) ([]esquery.Aggregation, error) {
-	if sortingRequest == nil {
-		return aggs, nil
-	}
+	for _, key := range sortingRequest.SortKeys() {
+		field, err := effectiveSortFieldOf(key.Column, sortFieldMapping)
+		if err != nil {
+			return nil, err
+		}
 
-	field, err := effectiveSortFieldOf(*sortingRequest, sortFieldMapping)
-	if err != nil {
-		return nil, err
-	}
+		// refers to an existing aggregation that does not need to be created
+		if field.PlainField == nil || field.AggregationName == nil {
+			continue
+		}
 
-	// refers to an existing aggregation that does not need to be created
-	if field.PlainField == nil || field.AggregationName == nil {
-		return aggs, nil
+		aggs = append(aggs, esquery.Max(*field.AggregationName, *field.PlainField))
 	}
-
-	agg := esquery.Max(*field.AggregationName, *field.PlainField)
-	return append(aggs, agg), nil
+	return aggs, nil
 }
 
 // BucketSortAgg is capable to sort all existing buckets, but is currently only used for paging
+// The position of null values can't be controlled for aggregations, so it is ignored.
 func BucketSortAgg(sortingRequest *sorting.Request, sortFieldMapping map[string]EffectiveSortField,
 	pagingRequest *paging.Request,
 ) (*esquery.CustomAggMap, error) {
@@ -65,22 +87,27 @@ func BucketSortAgg(sortingRequest *sorting.Request, sortFieldMapping map[string]
 	sorting := map[string]interface{}{}
 
 	if sortingRequest != nil {
-		field, err := effectiveSortFieldOf(*sortingRequest, sortFieldMapping)
-		if err != nil {
-			return nil, err
-		}
-
-		order, err := getOrder(sortingRequest)
-		if err != nil {
-			return nil, err
+		var sortFields []map[string]interface{}
+		for _, key := range sortingRequest.SortKeys() {
+			field, err := effectiveSortFieldOf(key.Column, sortFieldMapping)
+			if err != nil {
+				return nil, err
+			}
+
+			order, err := getOrder(key.Direction)
+			if err != nil {
+				return nil, err
+			}
+
+			sortFields = append(sortFields, map[string]interface{}{
+				field.AggregationValue: map[string]interface{}{
+					"order": order,
+				},
+			})
 		}
 
 		sorting = map[string]interface{}{
-			"sort": []map[string]interface{}{
-				{field.AggregationValue: map[string]interface{}{
-					"order": order,
-				}},
-			},
+			"sort": sortFields,
 		}
 	}
 
@@ -111,23 +138,81 @@ func AddBucketSortAgg(aggs []esquery.Aggregation, sortingRequest *sorting.Reques
 	return append(aggs, agg), nil
 }
 
-func getOrder(sortingRequest *sorting.Request) (esquery.Order, error) {
-	switch strings.ToLower(sortingRequest.SortDirection.String()) {
+// AddSortingAndPaging configures sorting and paging of a document search. Documents are sorted by the plain fields
+// of the requested sort keys and additionally by `tieBreakerField`, which must be unique per document, to get
+// a stable order. If the paging request contains a cursor, the search continues after the position encoded in the
+// cursor using `search_after`, otherwise the offset is derived from the page index.
+// The sort values of the last hit of a page can be used to create the cursor for the next page, see [paging.EncodeCursor].
+// OpenSearch sorts documents with missing values last, so `nulls first` is not supported.
+func AddSortingAndPaging(search *esquery.SearchRequest, sortingRequest *sorting.Request,
+	sortFieldMapping map[string]EffectiveSortField, tieBreakerField string, pagingRequest *paging.Request,
+) (*esquery.SearchRequest, error) {
+	keys := sortingRequest.SortKeys()
+	sortColumns := make([]string, 0, len(keys))
+	for _, key := range keys {
+		field, err := effectiveSortFieldOf(key.Column, sortFieldMapping)
+		if err != nil {
+			return nil, err
+		}
+		if field.PlainField == nil {
+			return nil, fmt.Errorf("%s can not be used for sorting documents", key.Column)
+		}
+		if key.Nulls == sorting.NullsFirst {
+			return nil, fmt.Errorf("sorting missing values of %s first is not supported", key.Column)
+		}
+
+		order, err := getOrder(key.Direction)
+		if err != nil {
+			return nil, err
+		}
+		search = search.Sort(*field.PlainField, order)
+		sortColumns = append(sortColumns, key.Column)
+	}
+	search = search.Sort(tieBreakerField, esquery.OrderAsc)
+
+	if pagingRequest == nil {
+		return search, nil
+	}
+	if pagingRequest.Cursor == "" {
+		return search.
+			From(uint64(pagingRequest.PageIndex * pagingRequest.PageSize)).
+			Size(uint64(pagingRequest.PageSize)), nil
+	}
+
+	if pagingRequest.PageIndex != 0 {
+		return nil, fmt.Errorf("page index must be 0 when paging by cursor, got page index: %d",
+			pagingRequest.PageIndex)
+	}
+	cursor, err := paging.DecodeCursorOfKind(pagingRequest.Cursor, paging.CursorKindDocument)
+	if err != nil {
+		return nil, err
+	}
+	if !cursor.HasSortColumns(sortColumns) {
+		return nil, fmt.Errorf("cursor was created for sort columns %v, but sort columns are %v",
+			cursor.SortColumns, sortColumns)
+	}
+
+	searchAfter := append(append([]any{}, cursor.SortValues...), cursor.TieBreakerValue)
+	return search.SearchAfter(searchAfter...).Size(uint64(pagingRequest.PageSize)), nil
+}
+
+func getOrder(direction sorting.SortDirection) (esquery.Order, error) {
+	switch strings.ToLower(direction.String()) {
 	case string(esquery.OrderAsc):
 		return esquery.OrderAsc, nil
 	case string(esquery.OrderDesc):
 		return esquery.OrderDesc, nil
 	default:
-		return "", fmt.Errorf("%s is no valid sort direction", sortingRequest.SortDirection.String())
+		return "", fmt.Errorf("%s is no valid sort direction", direction.String())
 	}
 }
 
-func effectiveSortFieldOf(sortingRequest sorting.Request, sortFieldMapping map[string]EffectiveSortField) (EffectiveSortField, error) {
-	field, ok := sortFieldMapping[sortingRequest.SortColumn]
+func effectiveSortFieldOf(sortColumn string, sortFieldMapping map[string]EffectiveSortField) (EffectiveSortField, error) {
+	field, ok := sortFieldMapping[sortColumn]
 
 	if !ok {
 		return EffectiveSortField{}, fmt.Errorf("%s is no valid sort column, possible values: %s",
-			sortingRequest.SortColumn, strings.Join(validSortColumns(sortFieldMapping), ","))
+			sortColumn, strings.Join(validSortColumns(sortFieldMapping), ","))
 	}
 	return field, nil
 }
@@ -8,8 +8,10 @@ import (
 	"testing"
 
 	"github.com/aquasecurity/esquery"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
 	"github.com/greenbone/opensight-golang-libraries/pkg/query/sorting"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type SortingTestCase struct {
@@ -79,6 +81,137 @@ func TestSorting(t *testing.T) {
 	}
 }
 
+func TestCompoundSorting(t *testing.T) {
+	sortingRequest := &sorting.Request{Keys: []sorting.Key{
+		{Column: "severity", Direction: "desc"},
+		{Column: "qod", Direction: "asc"},
+	}}
+
+	t.Run("terms aggregation order", func(t *testing.T) {
+		subAggs, err := AddMaxAggForSorting(nil, sortingRequest, sortFieldMapping)
+		require.NoError(t, err)
+		termsAggregation, err := AddCompoundOrder(
+			esquery.TermsAgg("vulnerabilityWithAssetCountAgg", "vulnerabilityTest.oid.keyword").Aggs(subAggs...),
+			sortingRequest, sortFieldMapping)
+		require.NoError(t, err)
+
+		resultingJson, err := esquery.Search().Aggs(termsAggregation).Size(0).MarshalJSON()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"aggs":{"vulnerabilityWithAssetCountAgg":{"aggs":{"maxSeverity":{"max":{"field":"vulnerabilityTest.severityCvss.override"}},"maxQod":{"max":{"field":"qod"}}},"terms":{"field":"vulnerabilityTest.oid.keyword","order":[{"maxSeverity.value":"DESC"},{"maxQod.value":"ASC"}]}}},"size":0}`,
+			string(resultingJson))
+	})
+
+	t.Run("single order fails for multiple keys", func(t *testing.T) {
+		_, err := AddOrder(esquery.TermsAgg("vulnerabilityWithAssetCountAgg", "vulnerabilityTest.oid.keyword"),
+			sortingRequest, sortFieldMapping)
+		assert.ErrorContains(t, err, "use AddCompoundOrder instead")
+	})
+
+	t.Run("bucket sort", func(t *testing.T) {
+		agg, err := BucketSortAgg(sortingRequest, sortFieldMapping, &paging.Request{PageIndex: 1, PageSize: 5})
+		require.NoError(t, err)
+
+		resultingJson, err := esquery.Search().Aggs(agg).Size(0).MarshalJSON()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"aggs":{"sorting":{"bucket_sort":{"from":5,"size":5,"sort":[{"maxSeverity.value":{"order":"desc"}},{"maxQod.value":{"order":"asc"}}]}}},"size":0}`,
+			string(resultingJson))
+	})
+}
+
+func TestAddSortingAndPaging(t *testing.T) {
+	cursorOf := func(sortColumns []string, sortValues []any, tieBreakerValue any) string {
+		cursor, err := paging.EncodeCursor(paging.Cursor{
+			SortColumns:     sortColumns,
+			SortValues:      sortValues,
+			TieBreakerValue: tieBreakerValue,
+		})
+		require.NoError(t, err)
+		return cursor
+	}
+	aggregationCursor, err := paging.EncodeCursor(paging.Cursor{
+		Kind:        paging.CursorKindAggregation,
+		SortColumns: []string{"qod"},
+		SortValues:  []any{70},
+	})
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		SortingRequest       *sorting.Request
+		PagingRequest        *paging.Request
+		ExpectedQueryJson    string
+		ExpectedErrorMessage string
+	}{
+		"no sorting and no paging": {
+			ExpectedQueryJson: `{"query":{"bool":{}},"sort":[{"id":{"order":"asc"}}]}`,
+		},
+		"sorting and offset paging": {
+			SortingRequest:    &sorting.Request{SortColumn: "qod", SortDirection: "desc"},
+			PagingRequest:     &paging.Request{PageIndex: 2, PageSize: 10},
+			ExpectedQueryJson: `{"query":{"bool":{}},"sort":[{"qod":{"order":"desc"}},{"id":{"order":"asc"}}],"from":20,"size":10}`,
+		},
+		"sorting and cursor paging": {
+			SortingRequest:    &sorting.Request{SortColumn: "qod", SortDirection: "asc"},
+			PagingRequest:     &paging.Request{PageSize: 10, Cursor: cursorOf([]string{"qod"}, []any{70}, "doc-1")},
+			ExpectedQueryJson: `{"query":{"bool":{}},"sort":[{"qod":{"order":"asc"}},{"id":{"order":"asc"}}],"search_after":[70,"doc-1"],"size":10}`,
+		},
+		"cursor paging without sorting": {
+			PagingRequest:     &paging.Request{PageSize: 10, Cursor: cursorOf(nil, nil, 12345678901234567)},
+			ExpectedQueryJson: `{"query":{"bool":{}},"sort":[{"id":{"order":"asc"}}],"search_after":[12345678901234567],"size":10}`,
+		},
+		"multiple sort keys and cursor paging": {
+			SortingRequest: &sorting.Request{Keys: []sorting.Key{
+				{Column: "severity", Direction: "desc", Nulls: sorting.NullsLast},
+				{Column: "qod", Direction: "asc"},
+			}},
+			PagingRequest:     &paging.Request{PageSize: 10, Cursor: cursorOf([]string{"severity", "qod"}, []any{5.5, 70}, "doc-1")},
+			ExpectedQueryJson: `{"query":{"bool":{}},"sort":[{"vulnerabilityTest.severityCvss.override":{"order":"desc"}},{"qod":{"order":"asc"}},{"id":{"order":"asc"}}],"search_after":[5.5,70,"doc-1"],"size":10}`,
+		},
+		"nulls first": {
+			SortingRequest:       &sorting.Request{Keys: []sorting.Key{{Column: "qod", Direction: "asc", Nulls: sorting.NullsFirst}}},
+			ExpectedErrorMessage: "sorting missing values of qod first is not supported",
+		},
+		"cursor for different sort column": {
+			SortingRequest:       &sorting.Request{SortColumn: "qod", SortDirection: "asc"},
+			PagingRequest:        &paging.Request{PageSize: 10, Cursor: cursorOf([]string{"severity"}, []any{5.0}, "doc-1")},
+			ExpectedErrorMessage: "cursor was created for sort columns [severity], but sort columns are [qod]",
+		},
+		"cursor with page index": {
+			PagingRequest:        &paging.Request{PageIndex: 1, PageSize: 10, Cursor: cursorOf(nil, nil, "doc-1")},
+			ExpectedErrorMessage: "page index must be 0 when paging by cursor",
+		},
+		"cursor of aggregation": {
+			SortingRequest:       &sorting.Request{SortColumn: "qod", SortDirection: "asc"},
+			PagingRequest:        &paging.Request{PageSize: 10, Cursor: aggregationCursor},
+			ExpectedErrorMessage: "expected document cursor, got aggregation cursor",
+		},
+		"invalid cursor": {
+			PagingRequest:        &paging.Request{PageSize: 10, Cursor: "invalid"},
+			ExpectedErrorMessage: "invalid cursor",
+		},
+		"sorting by unknown field": {
+			SortingRequest:       &sorting.Request{SortColumn: "unknown", SortDirection: "asc"},
+			ExpectedErrorMessage: "unknown is no valid sort column, possible values:",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			q := NewBoolQueryBuilder(&QuerySettings{})
+			search, err := AddSortingAndPaging(esquery.Search().Query(q.Build()),
+				testCase.SortingRequest, sortFieldMapping, "id", testCase.PagingRequest)
+			if testCase.ExpectedErrorMessage != "" {
+				assert.ErrorContains(t, err, testCase.ExpectedErrorMessage)
+				return
+			}
+			require.NoError(t, err)
+
+			resultingJson, err := search.MarshalJSON()
+			require.NoError(t, err)
+			assert.JSONEq(t, testCase.ExpectedQueryJson, string(resultingJson))
+		})
+	}
+}
+
 var sortFieldMapping = map[string]EffectiveSortField{
 	"severity": {
 		PlainField:       strPtr("vulnerabilityTest.severityCvss.override"),
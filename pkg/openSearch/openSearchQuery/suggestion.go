@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openSearchQuery
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/aquasecurity/esquery"
+	esextensions "github.com/greenbone/opensight-golang-libraries/pkg/openSearch/esextension"
+	"github.com/greenbone/opensight-golang-libraries/pkg/openSearch/openSearchClient"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/suggestion"
+)
+
+// SuggestionAggregationName is the name of the terms aggregation added by [AddSuggestion].
+const SuggestionAggregationName = "suggestion"
+
+// luceneRegexpReplacer escapes the reserved characters of the regular expressions used by OpenSearch.
+var luceneRegexpReplacer = strings.NewReplacer(
+	`\`, `\\`, `.`, `\.`, `?`, `\?`, `+`, `\+`, `*`, `\*`, `|`, `\|`, `{`, `\{`, `}`, `\}`, `[`, `\[`, `]`, `\]`,
+	`(`, `\(`, `)`, `\)`, `"`, `\"`, `#`, `\#`, `@`, `\@`, `&`, `\&`, `<`, `\<`, `>`, `\>`, `~`, `\~`,
+)
+
+// AddSuggestion sets the query and a terms aggregation named [SuggestionAggregationName] to the search to retrieve
+// value suggestions for the field of the suggestion request. The field is resolved via the filter field mapping
+// of the query settings, like for the `beginsWith` operator its keyword field is used.
+//
+// Only documents matching the filter of the request and the prefix are considered, the prefix is matched
+// case-insensitively. The aggregation returns the distinct values starting with the prefix in ascending order.
+// As only the buckets are of interest, no documents are returned by the search.
+// The request is expected to be validated with [suggestion.ValidateSuggestionRequest].
+func AddSuggestion(search *esquery.SearchRequest, request *suggestion.Request, querySettings *QuerySettings,
+) (*esquery.SearchRequest, error) {
+	mappedField, ok := querySettings.FilterFieldMapping[request.Field]
+	if !ok {
+		return nil, filter.NewInvalidFilterFieldError(
+			"mapping for filter field '%s' is currently not implemented", request.Field)
+	}
+	field := mappedField + ".keyword"
+	if querySettings.WildcardArrays != nil && querySettings.WildcardArrays[mappedField] {
+		field = mappedField
+	}
+
+	boolQueryBuilder := NewBoolQueryBuilder(querySettings)
+	if err := boolQueryBuilder.AddFilterRequest(request.Filter); err != nil {
+		return nil, err
+	}
+	query := boolQueryBuilder.Build()
+
+	terms := esquery.TermsAgg(SuggestionAggregationName, field).
+		Size(uint64(request.EffectiveSize())).
+		Order(map[string]string{"_key": "asc"})
+	if request.Prefix != "" {
+		query.Must(esextensions.Prefix(field, request.Prefix).CaseInsensitiveMatch())
+		// a document can contain further values of an array field, which must not be suggested
+		terms.Include(caseInsensitivePrefixRegexp(request.Prefix))
+	}
+
+	return search.Query(query).Aggs(terms).Size(0), nil
+}
+
+// SuggestionResponseOf converts the result of the aggregation added by [AddSuggestion] into the response
+// of the suggestion request.
+func SuggestionResponseOf(result openSearchClient.SearchResponseAggregation) *suggestion.Response {
+	response := &suggestion.Response{Values: make([]string, 0, len(result.Buckets))}
+	for _, bucket := range result.Buckets {
+		value := bucket.KeyAsString
+		if value == "" {
+			value = ValueToString(bucket.Key)
+		}
+		response.Values = append(response.Values, value)
+	}
+	return response
+}
+
+// caseInsensitivePrefixRegexp returns a regular expression matching all values starting with the prefix,
+// ignoring the case, e.g. `[aA][bB]\.c.*` for `aB.c`.
+func caseInsensitivePrefixRegexp(prefix string) string {
+	var builder strings.Builder
+	for _, r := range prefix {
+		lower, upper := unicode.ToLower(r), unicode.ToUpper(r)
+		if lower != upper {
+			builder.WriteRune('[')
+			builder.WriteRune(lower)
+			builder.WriteRune(upper)
+			builder.WriteRune(']')
+		} else {
+			builder.WriteString(luceneRegexpReplacer.Replace(string(r)))
+		}
+	}
+	builder.WriteString(".*")
+	return builder.String()
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openSearchQuery
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aquasecurity/esquery"
+	"github.com/greenbone/opensight-golang-libraries/pkg/openSearch/openSearchClient"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/suggestion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddSuggestion(t *testing.T) {
+	querySettings := &QuerySettings{
+		FilterFieldMapping: map[string]string{
+			"hostname": "asset.hostname",
+			"tags":     "asset.tags",
+			"severity": "vulnerabilityTest.severity",
+		},
+		WildcardArrays: map[string]bool{"asset.tags": true},
+	}
+
+	tests := map[string]struct {
+		request              *suggestion.Request
+		expectedQueryJson    string
+		expectedErrorMessage string
+	}{
+		"prefix": {
+			request:           &suggestion.Request{Field: "hostname", Prefix: "Ho.1"},
+			expectedQueryJson: `{"aggs":{"suggestion":{"terms":{"field":"asset.hostname.keyword","include":"[hH][oO]\\.1.*","order":{"_key":"asc"},"size":10}}},"query":{"bool":{"must":[{"prefix":{"asset.hostname.keyword":{"case_insensitive":true,"value":"Ho.1"}}}]}},"size":0}`,
+		},
+		"empty prefix and size": {
+			request:           &suggestion.Request{Field: "hostname", Size: 3},
+			expectedQueryJson: `{"aggs":{"suggestion":{"terms":{"field":"asset.hostname.keyword","order":{"_key":"asc"},"size":3}}},"query":{"bool":{}},"size":0}`,
+		},
+		"wildcard array field": {
+			request:           &suggestion.Request{Field: "tags", Prefix: "x"},
+			expectedQueryJson: `{"aggs":{"suggestion":{"terms":{"field":"asset.tags","include":"[xX].*","order":{"_key":"asc"},"size":10}}},"query":{"bool":{"must":[{"prefix":{"asset.tags":{"case_insensitive":true,"value":"x"}}}]}},"size":0}`,
+		},
+		"with filter": {
+			request: &suggestion.Request{
+				Field:  "hostname",
+				Prefix: "h",
+				Filter: &filter.Request{
+					Operator: filter.LogicOperatorAnd,
+					Fields:   []filter.RequestField{{Name: "severity", Operator: filter.CompareOperatorIsGreaterThan, Value: 5}},
+				},
+			},
+			expectedQueryJson: `{"aggs":{"suggestion":{"terms":{"field":"asset.hostname.keyword","include":"[hH].*","order":{"_key":"asc"},"size":10}}},"query":{"bool":{"must":[{"range":{"vulnerabilityTest.severity":{"gt":5}}},{"prefix":{"asset.hostname.keyword":{"case_insensitive":true,"value":"h"}}}]}},"size":0}`,
+		},
+		"missing field mapping": {
+			request:              &suggestion.Request{Field: "unknown"},
+			expectedErrorMessage: "mapping for filter field 'unknown' is currently not implemented",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			search, err := AddSuggestion(esquery.Search(), tc.request, querySettings)
+			if tc.expectedErrorMessage != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+				return
+			}
+			require.NoError(t, err)
+
+			queryJson, err := json.Marshal(search.Map())
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedQueryJson, string(queryJson))
+		})
+	}
+}
+
+func TestSuggestionResponseOf(t *testing.T) {
+	responseJson := `{
+		"aggregations": {
+			"suggestion": {
+				"buckets": [
+					{"key": "host-a", "doc_count": 3},
+					{"key": 42, "doc_count": 1}
+				]
+			}
+		}
+	}`
+	result, err := openSearchClient.UnmarshalSearchResponse[any]([]byte(responseJson))
+	require.NoError(t, err)
+
+	response := SuggestionResponseOf(result.Aggregations[SuggestionAggregationName])
+	assert.Equal(t, &suggestion.Response{Values: []string{"host-a", "42"}}, response)
+}
@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package openSearchRepository provides a typed repository for documents stored in OpenSearch.
+// It maps a [query.ResultSelector] to a search request and parses the response into a
+// [query.ResponseListWithMetadata], so services don't need to build requests and parse responses by hand.
+//
+// Example Usage:
+//
+//	repository := NewRepository[Vulnerability](client, "vulnerabilities", Settings{
+//		FilterFieldMapping: map[string]string{"name": "name.keyword"},
+//		SortFieldMapping:   map[string]openSearchQuery.EffectiveSortField{"name": {PlainField: &nameField}},
+//		TieBreakerField:    "oid",
+//		SearchFields:       []string{"name"},
+//	})
+//
+//	result, err := repository.Search(ctx, resultSelector)
+//	if err != nil {
+//		return err
+//	}
+package openSearchRepository
@@ -0,0 +1,316 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openSearchRepository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/esquery"
+	"github.com/greenbone/opensight-golang-libraries/pkg/openSearch/openSearchClient"
+	"github.com/greenbone/opensight-golang-libraries/pkg/openSearch/openSearchQuery"
+	"github.com/greenbone/opensight-golang-libraries/pkg/openSearch/osquery"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// maxReportedBulkFailures limits the number of failed documents listed in the error of [Repository.BulkIndex].
+const maxReportedBulkFailures = 10
+
+// Document can be implemented by the document type of a [Repository] to carry the id of the document.
+// The id is not part of the document source, so the id field should be excluded from JSON, e.g. with `json:"-"`.
+// The methods have to be implemented with pointer receivers, on a value receiver SetId can't set the id.
+type Document interface {
+	GetId() string
+	SetId(id string)
+}
+
+// Settings configures the mapping of a [query.ResultSelector] to the documents of a [Repository].
+type Settings struct {
+	// FilterFieldMapping maps the filter fields of the request to the document fields.
+	FilterFieldMapping map[string]string
+	// SortFieldMapping maps the sort columns of the request to the document fields.
+	SortFieldMapping map[string]openSearchQuery.EffectiveSortField
+	// TieBreakerField is a field which is unique per document, it is used to get a stable order for paging.
+	TieBreakerField string
+	// SearchFields are the fields the free text search is matched against. If empty, searching is not supported.
+	SearchFields []string
+	// Refresh controls the visibility of changes, e.g. [openSearchClient.RefreshWaitFor].
+	// If empty, the default of OpenSearch is used.
+	Refresh string
+	// Bulk configures the retries and the request size of [Repository.BulkIndex] and [Repository.BulkIndexWithResult],
+	// its Refresh is replaced by Refresh.
+	Bulk openSearchClient.BulkOptions
+}
+
+// Repository provides typed access to the documents of type T stored in an index.
+type Repository[T any] struct {
+	client    *openSearchClient.Client
+	indexName string
+	settings  Settings
+}
+
+// NewRepository creates a new repository for documents of type T.
+//
+// indexName is the name of the index or of an alias. For writing documents the alias needs a write index.
+func NewRepository[T any](client *openSearchClient.Client, indexName string, settings Settings) *Repository[T] {
+	return &Repository[T]{
+		client:    client,
+		indexName: indexName,
+		settings:  settings,
+	}
+}
+
+// Get returns the document with the given id. If there is no such document, an
+// [openSearchClient.OpenSearchResourceNotFound] error is returned.
+func (r *Repository[T]) Get(ctx context.Context, id string) (T, error) {
+	var document T
+	source, err := r.client.GetDocument(ctx, r.indexName, id)
+	if err != nil {
+		return document, err
+	}
+	if err := jsoniter.Unmarshal(source, &document); err != nil {
+		return document, fmt.Errorf("failed to parse document %s: %w", id, err)
+	}
+	setId(&document, id)
+	return document, nil
+}
+
+// Index stores the document, an existing document with the same id is replaced. The id is taken from the
+// document if it implements [Document], otherwise or if it is empty an id is generated by OpenSearch.
+// It returns the id of the stored document.
+func (r *Repository[T]) Index(ctx context.Context, document T) (string, error) {
+	source, err := jsoniter.Marshal(document)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize document: %w", err)
+	}
+	return r.client.IndexDocument(ctx, r.indexName, getId(&document), source, r.settings.Refresh)
+}
+
+// BulkIndex stores all documents with a bulk request, ids are handled like in [Repository.Index].
+// If single documents couldn't be stored, an [openSearchClient.OpenSearchError] listing them is returned,
+// the other documents are stored nevertheless.
+func (r *Repository[T]) BulkIndex(ctx context.Context, documents []T) error {
+	_, err := r.BulkIndexWithResult(ctx, documents)
+	return err
+}
+
+// BulkIndexWithResult is like [Repository.BulkIndex], but also returns the result of each document
+// in the order of the documents.
+func (r *Repository[T]) BulkIndexWithResult(ctx context.Context, documents []T) (*openSearchClient.BulkResult, error) {
+	if len(documents) == 0 {
+		return &openSearchClient.BulkResult{}, nil
+	}
+
+	requestBody, err := serializeForBulkIndex(documents)
+	if err != nil {
+		return nil, err
+	}
+	options := r.settings.Bulk
+	options.Refresh = r.settings.Refresh
+	result, err := r.client.BulkWithOptions(ctx, r.indexName, requestBody, options)
+	if err != nil {
+		return result, err
+	}
+
+	failed := result.Failed()
+	if len(failed) == 0 {
+		return result, nil
+	}
+	failures := make([]string, 0, min(len(failed), maxReportedBulkFailures)+1)
+	for _, item := range failed {
+		if len(failures) == maxReportedBulkFailures {
+			failures = append(failures, "...")
+			break
+		}
+		failures = append(failures, fmt.Sprintf("%s: %s", item.DocumentId, describeFailure(item)))
+	}
+	return result, openSearchClient.NewOpenSearchError(fmt.Sprintf("failed to index %d of %d documents in %s: %s",
+		len(failed), len(documents), r.indexName, strings.Join(failures, ", ")))
+}
+
+// describeFailure returns the reason of the failed item of a bulk request.
+func describeFailure(item openSearchClient.BulkItemResult) string {
+	if item.Error == nil {
+		return fmt.Sprintf("status %d", item.StatusCode)
+	}
+	return fmt.Sprintf("%s: %s", item.Error.Type, item.Error.Reason)
+}
+
+// Delete deletes the document with the given id. If there is no such document, an
+// [openSearchClient.OpenSearchResourceNotFound] error is returned.
+func (r *Repository[T]) Delete(ctx context.Context, id string) error {
+	return r.client.DeleteDocument(ctx, r.indexName, id, r.settings.Refresh)
+}
+
+// Search returns the documents selected by the filter, search, sorting and paging of the result selector,
+// together with the matching metadata. Aggregations are not supported.
+//
+// If the page is full, the metadata contains a cursor for the next page, unless the documents are sorted by rank.
+// Without a paging request, OpenSearch returns its default number of documents.
+func (r *Repository[T]) Search(ctx context.Context, resultSelector query.ResultSelector,
+) (query.ResponseListWithMetadata[T], error) {
+	var result query.ResponseListWithMetadata[T]
+
+	requestBody, err := r.searchRequestBody(resultSelector)
+	if err != nil {
+		return result, err
+	}
+	responseBody, err := r.client.SearchContext(ctx, r.indexName, requestBody)
+	if err != nil {
+		return result, fmt.Errorf("search in %s failed: %w", r.indexName, err)
+	}
+
+	var response searchResponse
+	if err := jsoniter.Unmarshal(responseBody, &response); err != nil {
+		return result, fmt.Errorf("failed to parse search response: %w", err)
+	}
+	result.Data = make([]T, 0, len(response.Hits.Hits))
+	for _, hit := range response.Hits.Hits {
+		var document T
+		if err := jsoniter.Unmarshal(hit.Source, &document); err != nil {
+			return result, fmt.Errorf("failed to parse document %s: %w", hit.Id, err)
+		}
+		setId(&document, hit.Id)
+		result.Data = append(result.Data, document)
+	}
+
+	result.Metadata = query.NewMetadata(resultSelector, uint64(response.Hits.Total.Value))
+	if result.Metadata.Paging != nil {
+		result.Metadata.Paging.NextCursor, err = nextCursor(resultSelector, response.Hits.Hits)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// searchRequestBody creates the body of the search request for the result selector.
+func (r *Repository[T]) searchRequestBody(resultSelector query.ResultSelector) ([]byte, error) {
+	if resultSelector.Aggregation != nil {
+		return nil, errors.New("aggregations are not supported by the repository")
+	}
+
+	queryBuilder := osquery.NewBoolQueryBuilder(&osquery.QuerySettings{
+		FilterFieldMapping: r.settings.FilterFieldMapping,
+		SearchFields:       r.settings.SearchFields,
+	})
+	if err := queryBuilder.AddFilterRequest(resultSelector.Filter); err != nil {
+		return nil, err
+	}
+	if err := queryBuilder.AddSearch(resultSelector.Search); err != nil {
+		return nil, err
+	}
+
+	search, err := openSearchQuery.AddRankSorting(esquery.Search().Query(queryBuilder.Build()),
+		resultSelector.Search, resultSelector.Paging)
+	if err != nil {
+		return nil, err
+	}
+	search, err = openSearchQuery.AddSortingAndPaging(search, resultSelector.Sorting, r.settings.SortFieldMapping,
+		r.settings.TieBreakerField, resultSelector.Paging)
+	if err != nil {
+		return nil, err
+	}
+
+	body := search.Map()
+	// the number of hits is only counted up to 10000 by default
+	body["track_total_hits"] = true
+	return jsoniter.Marshal(body)
+}
+
+// searchResponse is the part of a search response needed by the repository.
+type searchResponse struct {
+	Hits struct {
+		Total openSearchClient.SearchResponseHitsTotal `json:"total"`
+		Hits  []searchHit                              `json:"hits"`
+	} `json:"hits"`
+}
+
+type searchHit struct {
+	Id     string          `json:"_id"`
+	Source json.RawMessage `json:"_source"`
+	Sort   json.RawMessage `json:"sort"`
+}
+
+// nextCursor returns the cursor pointing to the last hit, if the page is full and the hits are not sorted by rank.
+func nextCursor(resultSelector query.ResultSelector, hits []searchHit) (string, error) {
+	pagingRequest := resultSelector.Paging
+	if pagingRequest.PageSize <= 0 || len(hits) < pagingRequest.PageSize {
+		return "", nil
+	}
+	if !resultSelector.Search.IsEmpty() && resultSelector.Search.SortByRank {
+		return "", nil
+	}
+
+	// the sort values are decoded as json.Number to keep the precision of large numbers
+	var sortValues []any
+	decoder := json.NewDecoder(bytes.NewReader(hits[len(hits)-1].Sort))
+	decoder.UseNumber()
+	if err := decoder.Decode(&sortValues); err != nil {
+		return "", fmt.Errorf("failed to parse sort values of the last document: %w", err)
+	}
+
+	keys := resultSelector.Sorting.SortKeys()
+	if len(sortValues) != len(keys)+1 {
+		return "", fmt.Errorf("expected %d sort values of the last document, got %d", len(keys)+1, len(sortValues))
+	}
+	cursor := paging.Cursor{
+		SortValues:      sortValues[:len(keys)],
+		TieBreakerValue: sortValues[len(keys)],
+	}
+	for _, key := range keys {
+		cursor.SortColumns = append(cursor.SortColumns, key.Column)
+	}
+	return paging.EncodeCursor(cursor)
+}
+
+// serializeForBulkIndex creates the body of a bulk request indexing the documents.
+func serializeForBulkIndex[T any](documents []T) ([]byte, error) {
+	var body bytes.Buffer
+	for i := range documents {
+		action := map[string]map[string]string{"index": {}}
+		if id := getId(&documents[i]); id != "" {
+			action["index"]["_id"] = id
+		}
+		actionJson, err := jsoniter.Marshal(action)
+		if err != nil {
+			return nil, err
+		}
+		documentJson, err := jsoniter.Marshal(documents[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize document: %w", err)
+		}
+		body.Write(actionJson)
+		body.WriteByte('\n')
+		body.Write(documentJson)
+		body.WriteByte('\n')
+	}
+	return body.Bytes(), nil
+}
+
+// documentOf returns the document as [Document], if *T implements it.
+func documentOf[T any](document *T) (Document, bool) {
+	d, ok := any(document).(Document)
+	return d, ok
+}
+
+func getId[T any](document *T) string {
+	if d, ok := documentOf(document); ok {
+		return d.GetId()
+	}
+	return ""
+}
+
+func setId[T any](document *T, id string) {
+	if d, ok := documentOf(document); ok {
+		d.SetId(id)
+	}
+}
@@ -0,0 +1,370 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openSearchRepository
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/openSearch/openSearchClient"
+	"github.com/greenbone/opensight-golang-libraries/pkg/openSearch/openSearchQuery"
+	"github.com/greenbone/opensight-golang-libraries/pkg/openSearch/ostesting"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/aggregation"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/sorting"
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDocument carries its id, which is not part of the source
+type testDocument struct {
+	Id string `json:"-"`
+	ostesting.TestType
+}
+
+func (d *testDocument) GetId() string {
+	return d.Id
+}
+
+func (d *testDocument) SetId(id string) {
+	d.Id = id
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+var testSettings = Settings{
+	FilterFieldMapping: map[string]string{
+		"keyword": "keyword",
+		"integer": "integer",
+	},
+	SortFieldMapping: map[string]openSearchQuery.EffectiveSortField{
+		"integer": {PlainField: ptr("integer")},
+	},
+	TieBreakerField: "id",
+	SearchFields:    []string{"text"},
+	Refresh:         openSearchClient.RefreshTrue,
+}
+
+func newTestRepository(t *testing.T) *Repository[testDocument] {
+	tester := ostesting.NewTester(t)
+	_, alias := tester.NewTestTypeIndexAlias(t, "repository")
+	client := openSearchClient.NewClient(tester.OSClient(), 1, time.Millisecond)
+	return NewRepository[testDocument](client, alias, testSettings)
+}
+
+func newTestDocument(id string, keyword string, integer int, text string) testDocument {
+	return testDocument{
+		Id: id,
+		TestType: ostesting.TestType{
+			ID:          id,
+			Keyword:     keyword,
+			Integer:     integer,
+			Text:        text,
+			DateTimeStr: "2024-01-23T10:00:00.000Z",
+			DateTime:    time.Date(2024, 1, 23, 10, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestRepository_Document(t *testing.T) {
+	repository := newTestRepository(t)
+	ctx := context.Background()
+	document := newTestDocument("1", "keyword1", 1, "first document")
+
+	id, err := repository.Index(ctx, document)
+	require.NoError(t, err)
+	assert.Equal(t, "1", id)
+
+	got, err := repository.Get(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, document, got)
+
+	document.Integer = 2
+	_, err = repository.Index(ctx, document)
+	require.NoError(t, err)
+	got, err = repository.Get(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Integer)
+
+	require.NoError(t, repository.Delete(ctx, "1"))
+
+	var notFound *openSearchClient.OpenSearchResourceNotFound
+	_, err = repository.Get(ctx, "1")
+	assert.ErrorAs(t, err, &notFound)
+	err = repository.Delete(ctx, "1")
+	assert.ErrorAs(t, err, &notFound)
+
+	// the id is generated if the document has none
+	document.Id = ""
+	id, err = repository.Index(ctx, document)
+	require.NoError(t, err)
+	assert.NotEmpty(t, id)
+	got, err = repository.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, id, got.Id)
+}
+
+func TestRepository_Search(t *testing.T) {
+	repository := newTestRepository(t)
+	ctx := context.Background()
+
+	documents := []testDocument{
+		newTestDocument("1", "a", 3, "red apple"),
+		newTestDocument("2", "a", 1, "green apple"),
+		newTestDocument("3", "a", 2, "red cherry"),
+		newTestDocument("4", "b", 4, "red apple"),
+		newTestDocument("5", "a", 2, "yellow banana"),
+	}
+	require.NoError(t, repository.BulkIndex(ctx, documents))
+
+	filterA := &filter.Request{
+		Operator: filter.LogicOperatorAnd,
+		Fields:   []filter.RequestField{{Name: "keyword", Operator: filter.CompareOperatorIsEqualTo, Value: "a"}},
+	}
+	sortByInteger := &sorting.Request{SortColumn: "integer", SortDirection: sorting.DirectionDescending}
+
+	t.Run("filter, sorting and paging", func(t *testing.T) {
+		result, err := repository.Search(ctx, query.ResultSelector{
+			Filter:  filterA,
+			Sorting: sortByInteger,
+			Paging:  &paging.Request{PageIndex: 0, PageSize: 2},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []testDocument{documents[0], documents[2]}, result.Data)
+		require.NotNil(t, result.Metadata.Paging)
+		assert.Equal(t, uint64(4), result.Metadata.Paging.TotalDisplayableResults)
+		require.NotEmpty(t, result.Metadata.Paging.NextCursor)
+
+		// the documents with equal sort values are ordered by the tie breaker
+		result, err = repository.Search(ctx, query.ResultSelector{
+			Filter:  filterA,
+			Sorting: sortByInteger,
+			Paging:  &paging.Request{PageSize: 2, Cursor: result.Metadata.Paging.NextCursor},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []testDocument{documents[4], documents[1]}, result.Data)
+		require.NotEmpty(t, result.Metadata.Paging.NextCursor)
+
+		result, err = repository.Search(ctx, query.ResultSelector{
+			Filter:  filterA,
+			Sorting: sortByInteger,
+			Paging:  &paging.Request{PageSize: 2, Cursor: result.Metadata.Paging.NextCursor},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, result.Data)
+		assert.Empty(t, result.Metadata.Paging.NextCursor)
+	})
+
+	t.Run("search", func(t *testing.T) {
+		result, err := repository.Search(ctx, query.ResultSelector{
+			Filter:  filterA,
+			Sorting: sortByInteger,
+			Search:  &query.Search{Text: "red apple"},
+			Paging:  &paging.Request{PageSize: 10},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []testDocument{documents[0]}, result.Data)
+		assert.Equal(t, uint64(1), result.Metadata.Paging.TotalDisplayableResults)
+		assert.Empty(t, result.Metadata.Paging.NextCursor)
+	})
+}
+
+func TestRepository_BulkIndexFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":3,"errors":true,"items":[
+			{"index":{"_index":"index","_id":"1","status":201}},
+			{"index":{"_index":"index","_id":"2","status":400,
+				"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}
+		]}`))
+	}))
+	defer server.Close()
+	osClient, err := opensearchapi.NewClient(opensearchapi.Config{
+		Client: opensearch.Config{Addresses: []string{server.URL}},
+	})
+	require.NoError(t, err)
+	client := openSearchClient.NewClient(osClient, 1, time.Millisecond)
+	defer client.Close()
+	repository := NewRepository[testDocument](client, "index", testSettings)
+
+	documents := []testDocument{
+		newTestDocument("1", "a", 1, "one"),
+		newTestDocument("2", "b", 2, "two"),
+	}
+	result, err := repository.BulkIndexWithResult(context.Background(), documents)
+	var openSearchErr *openSearchClient.OpenSearchError
+	require.ErrorAs(t, err, &openSearchErr)
+	assert.Contains(t, err.Error(), "2: mapper_parsing_exception: failed to parse")
+	assert.NotContains(t, err.Error(), "1:")
+	require.Len(t, result.Items, 2)
+	assert.False(t, result.Items[0].Failed())
+	assert.True(t, result.Items[1].Failed())
+
+	err = repository.BulkIndex(context.Background(), documents)
+	require.ErrorAs(t, err, &openSearchErr)
+}
+
+func TestRepository_SearchRequestBody(t *testing.T) {
+	repository := NewRepository[testDocument](nil, "index", testSettings)
+
+	tests := map[string]struct {
+		resultSelector query.ResultSelector
+		settings       *Settings
+		wantBody       string
+		wantErr        bool
+	}{
+		"sorting and paging": {
+			resultSelector: query.ResultSelector{
+				Sorting: &sorting.Request{SortColumn: "integer", SortDirection: sorting.DirectionAscending},
+				Paging:  &paging.Request{PageIndex: 1, PageSize: 10},
+			},
+			wantBody: `{
+				"query": {"bool": {}},
+				"sort": [{"integer": {"order": "asc"}}, {"id": {"order": "asc"}}],
+				"from": 10,
+				"size": 10,
+				"track_total_hits": true
+			}`,
+		},
+		"search by rank": {
+			resultSelector: query.ResultSelector{
+				Sorting: &sorting.Request{SortColumn: "integer", SortDirection: sorting.DirectionAscending},
+				Search:  &query.Search{Text: "red", SortByRank: true},
+			},
+			wantBody: `{
+				"query": {"bool": {"must": [{"simple_query_string": {
+					"query": "red", "fields": ["text"], "default_operator": "and"
+				}}]}},
+				"sort": [{"_score": {"order": "desc"}}, {"integer": {"order": "asc"}}, {"id": {"order": "asc"}}],
+				"track_total_hits": true
+			}`,
+		},
+		"search with filter": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{Operator: filter.LogicOperatorAnd, Fields: []filter.RequestField{
+					{Name: "keyword", Operator: filter.CompareOperatorIsEqualTo, Value: "a"},
+				}},
+				Search: &query.Search{Text: `red -blue`},
+			},
+			wantBody: `{
+				"query": {"bool": {"must": [
+					{"term": {"keyword": {"value": "a"}}},
+					{"simple_query_string": {"query": "red -blue", "fields": ["text"], "default_operator": "and"}}
+				]}},
+				"sort": [{"id": {"order": "asc"}}],
+				"track_total_hits": true
+			}`,
+		},
+		"search without search fields": {
+			resultSelector: query.ResultSelector{Search: &query.Search{Text: "red"}},
+			settings:       &Settings{TieBreakerField: "id"},
+			wantErr:        true,
+		},
+		"aggregation": {
+			resultSelector: query.ResultSelector{Aggregation: &aggregation.Request{}},
+			wantErr:        true,
+		},
+		"invalid sort column": {
+			resultSelector: query.ResultSelector{
+				Sorting: &sorting.Request{SortColumn: "unknown", SortDirection: sorting.DirectionAscending},
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := repository
+			if tt.settings != nil {
+				r = NewRepository[testDocument](nil, "index", *tt.settings)
+			}
+
+			body, err := r.searchRequestBody(tt.resultSelector)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.wantBody, string(body))
+		})
+	}
+}
+
+func TestSerializeForBulkIndex(t *testing.T) {
+	documents := []testDocument{
+		newTestDocument("1", "a", 1, "one"),
+		newTestDocument("", "b", 2, "two"),
+	}
+
+	body, err := serializeForBulkIndex(documents)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+	require.Len(t, lines, 4)
+	assert.JSONEq(t, `{"index": {"_id": "1"}}`, lines[0])
+	assert.JSONEq(t, `{"index": {}}`, lines[2])
+
+	var source map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &source))
+	assert.Equal(t, "a", source["keyword"])
+	assert.NotContains(t, source, "Id")
+}
+
+func TestNextCursor(t *testing.T) {
+	sortByInteger := &sorting.Request{SortColumn: "integer", SortDirection: sorting.DirectionDescending}
+	hits := []searchHit{
+		{Id: "1", Sort: json.RawMessage(`[3, "1"]`)},
+		{Id: "2", Sort: json.RawMessage(`[9007199254740993, "2"]`)},
+	}
+
+	tests := map[string]struct {
+		resultSelector query.ResultSelector
+		wantCursor     *paging.Cursor
+	}{
+		"full page": {
+			resultSelector: query.ResultSelector{Sorting: sortByInteger, Paging: &paging.Request{PageSize: 2}},
+			wantCursor: &paging.Cursor{
+				SortColumns:     []string{"integer"},
+				SortValues:      []any{json.Number("9007199254740993")},
+				TieBreakerValue: "2",
+			},
+		},
+		"last page": {
+			resultSelector: query.ResultSelector{Sorting: sortByInteger, Paging: &paging.Request{PageSize: 3}},
+		},
+		"sorted by rank": {
+			resultSelector: query.ResultSelector{
+				Sorting: sortByInteger,
+				Paging:  &paging.Request{PageSize: 2},
+				Search:  &query.Search{Text: "red", SortByRank: true},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cursor, err := nextCursor(tt.resultSelector, hits)
+			require.NoError(t, err)
+			if tt.wantCursor == nil {
+				assert.Empty(t, cursor)
+				return
+			}
+			decoded, err := paging.DecodeCursor(cursor)
+			require.NoError(t, err)
+			assert.Equal(t, *tt.wantCursor, decoded)
+		})
+	}
+}
@@ -6,7 +6,6 @@ package osquery
 
 import (
 	"fmt"
-	"reflect"
 
 	"github.com/aquasecurity/esquery"
 	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
@@ -31,6 +30,9 @@ type (
 // QuerySettings is used to configure the query builder.
 type QuerySettings struct {
 	FilterFieldMapping map[string]string
+	// SearchFields are the fields matched by the free text search of the result selector, see
+	// [BoolQueryBuilder.AddSearch]. A field can be boosted like `name^2`.
+	SearchFields []string
 }
 
 // CompareOperator defines a mapping between a filter.CompareOperator and a function to generate an appropriate
@@ -131,12 +133,13 @@ func (q *BoolQueryBuilder) createOperatorMapping() map[filter.CompareOperator]qu
 }
 
 // AddFilterRequest adds a filter request to this query.
-// The filter request is translated into a bool query.
+// The filter request is translated into a bool query. Nested groups of the request
+// are translated into nested bool queries.
 func (q *BoolQueryBuilder) AddFilterRequest(request *filter.Request) error {
-	if request == nil || len(request.Fields) == 0 {
+	if request.IsEmpty() {
 		return nil
 	}
-	if request.Operator == "" && len(request.Fields) == 1 { // for single filter `Operator` is not relevant
+	if request.Operator == "" && len(request.Fields)+len(request.Groups) == 1 { // for single filter `Operator` is not relevant
 		request.Operator = filter.LogicOperatorAnd
 	}
 
@@ -148,6 +151,9 @@ func (q *BoolQueryBuilder) AddFilterRequest(request *filter.Request) error {
 	operatorMapping := q.createOperatorMapping()
 
 	for _, field := range effectiveRequest.Fields {
+		if field.Match == filter.ValueMatchAll {
+			return fmt.Errorf("field '%s' with unsupported match '%s'", field.Name, field.Match)
+		}
 		if handler, ok := operatorMapping[field.Operator]; ok {
 			value := field.Value
 
@@ -159,22 +165,10 @@ func (q *BoolQueryBuilder) AddFilterRequest(request *filter.Request) error {
 				return fmt.Errorf("field '%s' has no value set", field.Name)
 			}
 
-			if t := reflect.TypeOf(value); t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
-				slice := reflect.ValueOf(value)
-
-				if slice.Len() == 0 { // disallow empty list values, as the there is no clear way to interpret this kind of filter
+			if values, ok := filter.ListValues(value); ok {
+				if len(values) == 0 { // disallow empty list values, as the there is no clear way to interpret this kind of filter
 					return fmt.Errorf("field '%s' has empty list of values", field.Name)
 				}
-				// convert to []any, so that handlers don't need to deal with different slice types
-				var values []any
-				if v, ok := value.([]any); ok {
-					values = v
-				} else {
-					values = make([]any, slice.Len())
-					for i := 0; i < slice.Len(); i++ {
-						values[i] = slice.Index(i).Interface()
-					}
-				}
 				value = values
 			}
 
@@ -186,6 +180,13 @@ func (q *BoolQueryBuilder) AddFilterRequest(request *filter.Request) error {
 			return fmt.Errorf("field '%s' with unknown operator '%s'", field.Name, field.Operator)
 		}
 	}
+	for index, group := range request.Groups {
+		groupQuery, err := q.groupQuery(group)
+		if err != nil {
+			return fmt.Errorf("failed to transform filter group %d to database query: %w", index, err)
+		}
+		q.Must = append(q.Must, groupQuery)
+	}
 	switch effectiveRequest.Operator {
 	case filter.LogicOperatorAnd:
 		q.query = q.query.
@@ -211,6 +212,25 @@ func (q *BoolQueryBuilder) AddFilterRequest(request *filter.Request) error {
 	}
 }
 
+// groupQuery translates a nested filter group into a separate bool query,
+// using the same settings and compare operators as this builder.
+func (q *BoolQueryBuilder) groupQuery(group filter.Request) (*esquery.BoolQuery, error) {
+	// empty groups are invalid, see [filter.Request.IsEmpty]
+	if group.IsEmpty() {
+		return nil, fmt.Errorf("filter group is empty")
+	}
+	groupBuilder := &BoolQueryBuilder{
+		querySettings:    q.querySettings,
+		compareOperators: q.compareOperators,
+		query:            esquery.Bool(),
+	}
+	err := groupBuilder.AddFilterRequest(&group)
+	if err != nil {
+		return nil, err
+	}
+	return groupBuilder.Build(), nil
+}
+
 func effectiveFilterFields(filterRequest filter.Request, fieldMapping map[string]string) (filter.Request, error) {
 	var filterFields []filter.RequestField
 	for _, field := range filterRequest.Fields {
@@ -238,6 +258,7 @@ func createMappedField(dtoField filter.RequestField, fieldMapping map[string]str
 		Keys:     dtoField.Keys,
 		Name:     entityName,
 		Value:    dtoField.Value,
+		Match:    dtoField.Match,
 	}, nil
 }
 
@@ -191,6 +191,50 @@ func TestBoolQueryBuilder_AddFilterRequest(t *testing.T) {
 			},
 			wantDocuments: []ostesting.TestType{doc1},
 		},
+		"combine filters with nested groups": {
+			filterRequest: &filter.Request{
+				Fields: []filter.RequestField{
+					{
+						Name:     "keywordField",
+						Operator: filter.CompareOperatorIsEqualTo,
+						Value:    doc0.Keyword,
+					},
+				},
+				Groups: []filter.Request{
+					{
+						Fields: []filter.RequestField{
+							{
+								Name:     "integerField",
+								Operator: filter.CompareOperatorIsGreaterThan,
+								Value:    doc0.Integer,
+							},
+							{
+								Name:     "booleanField",
+								Operator: filter.CompareOperatorIsEqualTo,
+								Value:    false,
+							},
+						},
+						Operator: filter.LogicOperatorAnd,
+					},
+				},
+				Operator: filter.LogicOperatorOr,
+			},
+			wantDocuments: []ostesting.TestType{doc0, doc2}, // keyword = '' OR (integer > 0 AND boolean = false)
+		},
+		"fail on empty nested group": {
+			filterRequest: &filter.Request{
+				Fields: []filter.RequestField{
+					{
+						Name:     "keywordField",
+						Operator: filter.CompareOperatorIsEqualTo,
+						Value:    doc1.Keyword,
+					},
+				},
+				Groups:   []filter.Request{{Operator: filter.LogicOperatorAnd}},
+				Operator: filter.LogicOperatorAnd,
+			},
+			wantErr: true,
+		},
 		"fail with multiple filters and missing logic operator": {
 			filterRequest: &filter.Request{
 				Fields: []filter.RequestField{
@@ -949,3 +993,54 @@ func TestBoolQueryBuilder_AddTermFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestBoolQueryBuilder_NormalizedFilter(t *testing.T) {
+	requestOptions := []filter.RequestOption{
+		{Name: filter.NewReadableValue("", "id"), Control: filter.RequestOptionType{Type: filter.ControlTypeUuid}},
+	}
+	querySettings := &QuerySettings{FilterFieldMapping: map[string]string{"id": "id"}}
+
+	tests := map[string]struct {
+		value    any
+		wantJson string
+	}{
+		"single uuid": {
+			value:    "9bd5c4ea-5b0c-4a4f-a2a0-5a0ff0a3e1d2",
+			wantJson: `{"query":{"bool":{"must":[{"term":{"id":{"value":"9bd5c4ea-5b0c-4a4f-a2a0-5a0ff0a3e1d2"}}}]}}}`,
+		},
+		"list of uuids": {
+			value: []any{"9bd5c4ea-5b0c-4a4f-a2a0-5a0ff0a3e1d2", "2d1b0c4e-8c4f-4a59-9a1e-6f3f0f1b2c3d"},
+			wantJson: `{"query":{"bool":{"must":[{"terms":{"id":[
+				"9bd5c4ea-5b0c-4a4f-a2a0-5a0ff0a3e1d2","2d1b0c4e-8c4f-4a59-9a1e-6f3f0f1b2c3d"
+			]}}]}}}`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			normalized, err := filter.NormalizeFilter(singleFilter(filter.RequestField{
+				Name:     "id",
+				Operator: filter.CompareOperatorIsEqualTo,
+				Value:    tt.value,
+			}), requestOptions)
+			require.NoError(t, err)
+
+			q := NewBoolQueryBuilder(querySettings)
+			require.NoError(t, q.AddFilterRequest(normalized))
+
+			gotJson, err := esquery.Search().Query(q.Build()).MarshalJSON()
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.wantJson, string(gotJson))
+		})
+	}
+}
+
+func TestBoolQueryBuilder_UnsupportedMatch(t *testing.T) {
+	q := NewBoolQueryBuilder(&QuerySettings{FilterFieldMapping: map[string]string{"tag": "tag"}})
+	err := q.AddFilterRequest(singleFilter(filter.RequestField{
+		Name:     "tag",
+		Operator: filter.CompareOperatorIsEqualTo,
+		Match:    filter.ValueMatchAll,
+		Value:    []any{"a", "b"},
+	}))
+	assert.ErrorContains(t, err, "unsupported match 'all'")
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package osquery
+
+import (
+	"errors"
+
+	esextensions "github.com/greenbone/opensight-golang-libraries/pkg/openSearch/esextension"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+)
+
+// AddSearch adds the free text search of the result selector to this query. The search text in web search syntax
+// is matched against the configured search fields with a `simple_query_string` query, see [esextensions.WebSearch].
+// Without search text the query is not changed.
+func (q *BoolQueryBuilder) AddSearch(search *query.Search) error {
+	if search.IsEmpty() {
+		return nil
+	}
+	if len(q.querySettings.SearchFields) == 0 {
+		return errors.New("free text search is not supported, no search fields are configured")
+	}
+
+	q.query = q.query.Must(esextensions.WebSearch(search.Text, q.querySettings.SearchFields...))
+	return nil
+}
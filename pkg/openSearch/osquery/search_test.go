@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package osquery
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/esquery"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddSearch(t *testing.T) {
+	querySettings := &QuerySettings{
+		FilterFieldMapping: map[string]string{"hostname": "hostname"},
+		SearchFields:       []string{"hostname^2", "description"},
+	}
+
+	tests := map[string]struct {
+		querySettings *QuerySettings
+		filter        *filter.Request
+		search        *query.Search
+		wantQueryJson string
+		wantErr       bool
+	}{
+		"search": {
+			querySettings: querySettings,
+			search:        &query.Search{Text: `linux -"windows server" or macos`},
+			wantQueryJson: `{"query":{"bool":{"must":[{"simple_query_string":{"fields":["hostname^2","description"],"default_operator":"and","query":"linux -\"windows server\" | macos"}}]}}}`,
+		},
+		"search with filter": {
+			querySettings: querySettings,
+			filter: &filter.Request{Operator: filter.LogicOperatorAnd, Fields: []filter.RequestField{
+				{Name: "hostname", Operator: filter.CompareOperatorIsEqualTo, Value: "host"},
+			}},
+			search:        &query.Search{Text: "web"},
+			wantQueryJson: `{"query":{"bool":{"must":[{"term":{"hostname":{"value":"host"}}},{"simple_query_string":{"fields":["hostname^2","description"],"default_operator":"and","query":"web"}}]}}}`,
+		},
+		"empty search": {
+			querySettings: querySettings,
+			search:        &query.Search{Text: " "},
+			wantQueryJson: `{"query":{"bool":{}}}`,
+		},
+		"no search": {
+			querySettings: querySettings,
+			wantQueryJson: `{"query":{"bool":{}}}`,
+		},
+		"without search fields": {
+			querySettings: &QuerySettings{},
+			search:        &query.Search{Text: "web"},
+			wantErr:       true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			q := NewBoolQueryBuilder(tt.querySettings)
+			require.NoError(t, q.AddFilterRequest(tt.filter))
+			err := q.AddSearch(tt.search)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			resultingJson, err := esquery.Search().Query(q.Build()).MarshalJSON()
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.wantQueryJson, string(resultingJson))
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/aggregation"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/lib/pq"
+)
+
+// aggregateFunctions maps the aggregate metrics to the postgres aggregate functions.
+var aggregateFunctions = map[filter.AggregateMetric]string{
+	filter.AggregateMetricSum:        "sum",
+	filter.AggregateMetricMin:        "min",
+	filter.AggregateMetricMax:        "max",
+	filter.AggregateMetricAvg:        "avg",
+	filter.AggregateMetricValueCount: "count",
+}
+
+// BuildAggregation generates the postgres SQL query parts for the aggregation request of the result selector.
+//
+// `selectList` contains the expressions for the SELECT clause: a column for each group-by field named like the
+// field, the number of rows of the bucket named `count` and a column for each metric named like its key,
+// e.g. `avg(severity)`. For a date histogram the column contains the start of the interval.
+//
+// `query` contains the joins needed by the fields, the WHERE clause of the filter and search, the GROUP BY clause,
+// the ORDER BY clause ordering the buckets by their keys and the OFFSET and LIMIT clauses of the paging request.
+// The sorting request is not applied and paging by cursor is not supported.
+//
+// The full query is composed like `"SELECT " + selectList + " FROM table " + query`, the result can be read
+// with [ScanAggregationRows].
+func (qb *Builder) BuildAggregation(resultSelector query.ResultSelector) (selectList string, query string, args []any, err error) {
+	request := resultSelector.Aggregation
+	if err := aggregation.ValidateAggregationRequest(request); err != nil {
+		return "", "", nil, err
+	}
+	if resultSelector.Paging != nil && resultSelector.Paging.Cursor != "" {
+		return "", "", nil, errors.New("paging by cursor is not supported for aggregations")
+	}
+
+	var selectExpressions, groupPositions []string
+	for i, groupBy := range request.GroupBy {
+		column, err := qb.quotedColumnOf(groupBy.Field)
+		if err != nil {
+			return "", "", nil, err
+		}
+		if groupBy.DateHistogram != nil {
+			column = fmt.Sprintf("date_trunc('%s', %s)", groupBy.DateHistogram.Interval, column)
+		}
+		selectExpressions = append(selectExpressions, column+" AS "+pq.QuoteIdentifier(groupBy.Field))
+		groupPositions = append(groupPositions, fmt.Sprint(i+1))
+	}
+	selectExpressions = append(selectExpressions, `count(*) AS "count"`)
+	for _, metric := range request.Metrics {
+		column, err := qb.quotedColumnOf(metric.Field)
+		if err != nil {
+			return "", "", nil, err
+		}
+		selectExpressions = append(selectExpressions,
+			fmt.Sprintf("%s(%s) AS %s", aggregateFunctions[metric.Metric], column, pq.QuoteIdentifier(metric.Key())))
+	}
+
+	searchCondition, searchArgs, err := qb.composeSearchCondition(resultSelector.Search)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("error adding search query: %w", err)
+	}
+	var statement strings.Builder
+	args, err = qb.addFilters(&statement, resultSelector.Filter, searchCondition, searchArgs)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("error adding filter query: %w", err)
+	}
+
+	// order by keys, so that the buckets are consistent between pages
+	positions := strings.Join(groupPositions, ", ")
+	fmt.Fprintf(&statement, " GROUP BY %s ORDER BY %s", positions, positions)
+
+	if resultSelector.Paging != nil {
+		err = qb.addPaging(&statement, *resultSelector.Paging)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("error adding paging query: %w", err)
+		}
+	}
+
+	fields := filterFieldNames(resultSelector.Filter)
+	for _, groupBy := range request.GroupBy {
+		fields = append(fields, groupBy.Field)
+	}
+	for _, metric := range request.Metrics {
+		fields = append(fields, metric.Field)
+	}
+	query = qb.composeJoins(fields) + statement.String()
+	return strings.Join(selectExpressions, ", "), rebind(query), args, nil
+}
+
+func (qb *Builder) quotedColumnOf(field string) (string, error) {
+	quotedName, ok := qb.columns[field]
+	if !ok {
+		return "", filter.NewInvalidFilterFieldError("missing filter field mapping for '%s'", field)
+	}
+	if err := qb.checkNoExistsRelation(field); err != nil {
+		return "", err
+	}
+	return quotedName, nil
+}
+
+// ScanAggregationRows reads the result of a query generated with [Builder.BuildAggregation] for the given
+// aggregation request. Metrics must be numeric.
+func ScanAggregationRows(rows *sql.Rows, request *aggregation.Request) (*aggregation.Response, error) {
+	response := &aggregation.Response{Buckets: []aggregation.Bucket{}}
+	for rows.Next() {
+		keys := make([]any, len(request.GroupBy))
+		var count uint64
+		metrics := make([]sql.NullFloat64, len(request.Metrics))
+
+		destinations := make([]any, 0, len(keys)+1+len(metrics))
+		for i := range keys {
+			destinations = append(destinations, &keys[i])
+		}
+		destinations = append(destinations, &count)
+		for i := range metrics {
+			destinations = append(destinations, &metrics[i])
+		}
+		if err := rows.Scan(destinations...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		bucket := aggregation.Bucket{Keys: make(map[string]any, len(keys)), Count: count}
+		for i, groupBy := range request.GroupBy {
+			if b, ok := keys[i].([]byte); ok { // text values can be returned as raw bytes by the driver
+				keys[i] = string(b)
+			}
+			bucket.Keys[groupBy.Field] = keys[i]
+		}
+		if len(metrics) > 0 {
+			bucket.Metrics = make(map[string]*float64, len(metrics))
+			for i, metric := range request.Metrics {
+				if metrics[i].Valid {
+					bucket.Metrics[metric.Key()] = &metrics[i].Float64
+				} else {
+					bucket.Metrics[metric.Key()] = nil
+				}
+			}
+		}
+		response.Buckets = append(response.Buckets, bucket)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+	return response, nil
+}
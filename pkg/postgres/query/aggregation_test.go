@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greenbone/opensight-golang-libraries/internal/pgtesting"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/aggregation"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PostgresQueryBuilder_BuildAggregation(t *testing.T) {
+	docs := []TestDoc{
+		{ID: 1, String: "a", Integer: 1, Float: 1, Boolean: true, DateTime: time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC)},
+		{ID: 2, String: "b", Integer: 2, Float: 2, Boolean: true, DateTime: time.Date(2024, 1, 23, 10, 0, 0, 0, time.UTC)},
+		{ID: 3, String: "c", Integer: 3, Float: 4, Boolean: false, DateTime: time.Date(2024, 2, 23, 10, 0, 0, 0, time.UTC)},
+	}
+
+	type testCase struct {
+		resultSelector query.ResultSelector
+		wantBuckets    []aggregation.Bucket
+		wantErr        bool
+	}
+
+	tests := map[string]testCase{
+		"group by field with metrics": {
+			resultSelector: query.ResultSelector{
+				Aggregation: &aggregation.Request{
+					GroupBy: []aggregation.GroupBy{{Field: "booleanField"}},
+					Metrics: []aggregation.Metric{
+						{Field: "integerField", Metric: filter.AggregateMetricSum},
+						{Field: "floatField", Metric: filter.AggregateMetricAvg},
+						{Field: "floatField", Metric: filter.AggregateMetricMax},
+					},
+				},
+			},
+			wantBuckets: []aggregation.Bucket{
+				{
+					Keys: map[string]any{"booleanField": false}, Count: 1,
+					Metrics: map[string]*float64{"sum(integerField)": ptr(3.0), "avg(floatField)": ptr(4.0), "max(floatField)": ptr(4.0)},
+				},
+				{
+					Keys: map[string]any{"booleanField": true}, Count: 2,
+					Metrics: map[string]*float64{"sum(integerField)": ptr(3.0), "avg(floatField)": ptr(1.5), "max(floatField)": ptr(2.0)},
+				},
+			},
+		},
+		"date histogram with filter": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{Fields: []filter.RequestField{
+					{Name: "integerField", Operator: filter.CompareOperatorIsGreaterThan, Value: 1},
+				}},
+				Aggregation: &aggregation.Request{
+					GroupBy: []aggregation.GroupBy{
+						{Field: "dateTimeField", DateHistogram: &aggregation.DateHistogram{Interval: aggregation.IntervalMonth}},
+					},
+				},
+			},
+			wantBuckets: []aggregation.Bucket{
+				{Keys: map[string]any{"dateTimeField": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, Count: 1},
+				{Keys: map[string]any{"dateTimeField": time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}, Count: 1},
+			},
+		},
+		"multiple group-by fields with paging": {
+			resultSelector: query.ResultSelector{
+				Aggregation: &aggregation.Request{
+					GroupBy: []aggregation.GroupBy{{Field: "booleanField"}, {Field: "stringField"}},
+				},
+				Paging: &paging.Request{PageSize: 1, PageIndex: 1},
+			},
+			wantBuckets: []aggregation.Bucket{
+				{Keys: map[string]any{"booleanField": true, "stringField": "a"}, Count: 1},
+			},
+		},
+		"missing aggregation request": {
+			resultSelector: query.ResultSelector{},
+			wantErr:        true,
+		},
+		"unknown field": {
+			resultSelector: query.ResultSelector{
+				Aggregation: &aggregation.Request{GroupBy: []aggregation.GroupBy{{Field: "unknown"}}},
+			},
+			wantErr: true,
+		},
+		"paging by cursor": {
+			resultSelector: query.ResultSelector{
+				Aggregation: &aggregation.Request{GroupBy: []aggregation.GroupBy{{Field: "booleanField"}}},
+				Paging:      &paging.Request{Cursor: "abc"},
+			},
+			wantErr: true,
+		},
+	}
+
+	db := pgtesting.NewDB(t, migrationsFS, migrationDir)
+	repo := NewTestRepository(db)
+	for _, doc := range docs {
+		require.NoError(t, repo.CreateTestDoc(&doc), "failed to create test document")
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder, err := NewPostgresQueryBuilder(Settings{
+				FilterFieldMapping:      fieldMapping,
+				SortingTieBreakerColumn: sortingTieBreakerColumn,
+			})
+			require.NoError(t, err)
+
+			selectList, conditionalQuery, args, err := builder.BuildAggregation(tt.resultSelector)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			fullQuery := `SELECT ` + selectList + ` FROM test_table ` + conditionalQuery
+			t.Logf("sending query to database: query: %v, args: %v", fullQuery, args)
+			rows, err := db.Query(fullQuery, args...)
+			require.NoError(t, err)
+			defer rows.Close()
+
+			response, err := ScanAggregationRows(rows, tt.resultSelector.Aggregation)
+			require.NoError(t, err)
+			for _, bucket := range response.Buckets {
+				for key, value := range bucket.Keys {
+					if date, ok := value.(time.Time); ok {
+						bucket.Keys[key] = date.UTC()
+					}
+				}
+			}
+			assert.Equal(t, tt.wantBuckets, response.Buckets)
+		})
+	}
+}
@@ -7,6 +7,8 @@ package query
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -27,65 +29,205 @@ type Settings struct {
 	// It will be part of the `ORDER BY` clause, so depending on the query the entry needs to be prefixed
 	// with the table name or alias used in the query.
 	SortingTieBreakerColumn string
+	// StringFieldRating maps filter fields to their ratings. A rating is a named range of numeric values,
+	// e.g. `high` for a severity between 7.0 and 8.9. It is needed to translate the `*Rating` compare operators.
+	StringFieldRating map[string]map[string]filter.RatingRange
+	// FieldRelations declares for filter (or sorting) fields mapped to columns of related tables, how these
+	// tables are reached. The builder puts only the joins of the fields referenced by the request in front of
+	// the conditional query, see [Relation]. As the query then contains columns of several tables, the select
+	// list of the query needs to be qualified with the table name, e.g. `SELECT asset.* FROM asset `.
+	FieldRelations map[string]Relation
+	// JsonbFields are the filter fields mapped to `jsonb` columns containing an object with text values,
+	// e.g. tags like `{"env": "prod"}`. The keys of a filter field are the path to the compared value.
+	// Equality is checked with the containment operator `@>`, which can use a GIN index of the column.
+	JsonbFields map[string]bool
+	// ArrayFields are the filter fields mapped to array columns, e.g. `text[]`, with the way a list of values
+	// is matched against the elements of the array, if the filter field doesn't define it.
+	ArrayFields map[string]ArrayMatch
+	// SearchColumns are the `tsvector` columns searched by the free text search of the result selector, e.g.
+	// a generated column `to_tsvector('simple', name || ' ' || description)`. The search text is matched with
+	// `websearch_to_tsquery` using the `simple` configuration. Like SortingTieBreakerColumn the entries are
+	// used as is, so depending on the query they need to be prefixed with the table name or alias.
+	SearchColumns []string
 }
 
 // Builder represents a query builder used to construct PostgresSQL conditional query strings
 // with sorting and paging functionalities.
+// It is configured once and does not change afterwards, so a single instance can be shared across requests
+// and used concurrently. Each build method returns a fresh statement.
 type Builder struct {
-	querySettings Settings        // Settings used to configure the query builder
-	query         strings.Builder // strings.Builder to construct the query string
+	querySettings Settings          // Settings used to configure the query builder
+	columns       map[string]string // quoted database columns of the filter fields
 }
 
 // NewPostgresQueryBuilder creates a new instance of the query builder with the provided settings.
+// The settings are copied, so later changes of the passed maps don't affect the builder.
 func NewPostgresQueryBuilder(querySetting Settings) (*Builder, error) {
 	if querySetting.SortingTieBreakerColumn == "" {
 		return nil, fmt.Errorf("missing sorting tie breaker column in query settings")
 	}
+	if err := validateRelations(querySetting.FieldRelations); err != nil {
+		return nil, fmt.Errorf("invalid field relations in query settings: %w", err)
+	}
+	for field, match := range querySetting.ArrayFields {
+		if match != ArrayMatchAny && match != ArrayMatchAll {
+			return nil, fmt.Errorf("invalid array match '%s' of field '%s' in query settings", match, field)
+		}
+	}
+
+	columns := make(map[string]string, len(querySetting.FilterFieldMapping))
+	for field, dbColumnName := range querySetting.FilterFieldMapping {
+		quotedName, err := getQuotedName(dbColumnName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse quoted name of field %s: %w", field, err)
+		}
+		columns[field] = quotedName
+	}
+
+	querySetting.FilterFieldMapping = maps.Clone(querySetting.FilterFieldMapping)
+	querySetting.StringFieldRating = cloneStringFieldRating(querySetting.StringFieldRating)
+	querySetting.FieldRelations = maps.Clone(querySetting.FieldRelations)
+	querySetting.JsonbFields = maps.Clone(querySetting.JsonbFields)
+	querySetting.ArrayFields = maps.Clone(querySetting.ArrayFields)
+	querySetting.SearchColumns = slices.Clone(querySetting.SearchColumns)
+	return &Builder{querySettings: querySetting, columns: columns}, nil
+}
+
+// cloneStringFieldRating copies the ratings including the rating maps of the fields.
+func cloneStringFieldRating(stringFieldRating map[string]map[string]filter.RatingRange) map[string]map[string]filter.RatingRange {
+	if stringFieldRating == nil {
+		return nil
+	}
+	clone := make(map[string]map[string]filter.RatingRange, len(stringFieldRating))
+	for field, ratings := range stringFieldRating {
+		clone[field] = maps.Clone(ratings)
+	}
+	return clone
+}
 
-	return &Builder{querySettings: querySetting}, nil
+// addFilters builds and appends filter conditions to the query based on the provided filter request.
+// See [Builder.composeWhereClause] for the composition of the conditions.
+func (qb *Builder) addFilters(statement *strings.Builder, request *filter.Request, extraCondition string, extraArgs []any) (
+	args []any, err error,
+) {
+	whereClause, args, err := qb.composeWhereClause(request, extraCondition, extraArgs)
+	if err != nil {
+		return nil, err
+	}
+	statement.WriteString(whereClause)
+	return args, nil
 }
 
-// addFilters builds and appends filter conditions to the query builder based on the provided filter request.
+// composeWhereClause builds the `WHERE` clause for the provided filter request, see [Builder.composeFilterCondition].
+// Without any condition the clause is empty.
+func (qb *Builder) composeWhereClause(request *filter.Request, extraCondition string, extraArgs []any) (
+	whereClause string, args []any, err error,
+) {
+	condition, args, err := qb.composeFilterCondition(request, extraCondition, extraArgs)
+	if err != nil || condition == "" {
+		return "", nil, err
+	}
+	return "WHERE " + condition, args, nil
+}
+
+// composeFilterCondition builds the condition for the provided filter request.
 // It constructs conditional clauses using the logic operator specified in the request.
+// A non-empty `extraCondition`, e.g. a cursor condition (see [Builder.composeCursorCondition]), is added
+// to the filter conditions with AND.
 // It uses the `?` query placeholder, so you can pass your parameter separately
-// It returns all individual field values in a single list
-func (qb *Builder) addFilters(request *filter.Request) (args []any, err error) {
-	if request == nil || len(request.Fields) == 0 {
-		return nil, nil
+// It returns all individual field values in a single list. Without any condition the condition is empty.
+func (qb *Builder) composeFilterCondition(request *filter.Request, extraCondition string, extraArgs []any) (
+	condition string, args []any, err error,
+) {
+	var conditions []string
+	if !request.IsEmpty() {
+		filterConditions, filterArgs, err := qb.composeConditions(request)
+		if err != nil {
+			return "", nil, err
+		}
+		conditions = append(conditions, filterConditions)
+		args = append(args, filterArgs...)
 	}
-	if request.Operator == "" && len(request.Fields) == 1 { // for single filter `Operator` is not relevant
-		request.Operator = filter.LogicOperatorAnd
+	if extraCondition != "" {
+		conditions = append(conditions, extraCondition)
+		args = append(args, extraArgs...)
+	}
+
+	switch len(conditions) {
+	case 0:
+		return "", nil, nil
+	case 1:
+		return conditions[0], args, nil
+	default:
+		return "(" + strings.Join(conditions, ") AND (") + ")", args, nil
+	}
+}
+
+// composeConditions translates the fields and nested groups of the given request into a single condition,
+// chaining them with the logic operator of the request. Conditions of nested groups are enclosed in parentheses.
+// The returned args are in the same order as their placeholders in the condition.
+func (qb *Builder) composeConditions(request *filter.Request) (conditions string, args []any, err error) {
+	operator := request.Operator
+	if operator == "" && len(request.Fields)+len(request.Groups) == 1 { // for single filter `Operator` is not relevant
+		operator = filter.LogicOperatorAnd
 	}
 	var logicOperator string
-	switch request.Operator {
+	switch operator {
 	case filter.LogicOperatorAnd:
 		logicOperator = "AND"
 	case filter.LogicOperatorOr:
 		logicOperator = "OR"
 	default:
-		return nil, fmt.Errorf("invalid filter logic operator: %s", request.Operator)
+		return "", nil, fmt.Errorf("invalid filter logic operator: %s", request.Operator)
 	}
 
-	qb.query.WriteString("WHERE ")
+	var query strings.Builder
 	for index, field := range request.Fields {
+		var negateExists bool
+		field.Operator, negateExists = qb.existsOperator(field)
+		if (field.Operator == filter.CompareOperatorExists || field.Operator == filter.CompareOperatorDoesNotExist) &&
+			(field.Value == nil || !qb.querySettings.JsonbFields[field.Name]) {
+			// exists operator does not need a value, but for more consistent handling just pass a dummy value.
+			// Only for jsonb fields the value can invert the check, like for tag filters.
+			field.Value = ""
+		}
 		sanitizedValue, err := sanitizeFilterValue(field.Value)
 		if err != nil {
-			return nil, fmt.Errorf("error sanitizing filter field value '%s': %w", field.Name, err)
+			return "", nil, fmt.Errorf("error sanitizing filter field value '%s': %w", field.Name, err)
 		}
 		field.Value = sanitizedValue
-		args = append(args, extractFieldValues(field.Value, field.Operator)...)
 
-		conditionTemplate, err := composeQuery(qb.querySettings.FilterFieldMapping, field)
+		conditionTemplate, fieldArgs, err := qb.composeQuery(field)
 		if err != nil {
-			return nil, fmt.Errorf("error composing query from filter field %q:  %w", field.Name, err)
+			return "", nil, fmt.Errorf("error composing query from filter field %q:  %w", field.Name, err)
 		}
+		args = append(args, fieldArgs...)
 
 		if index > 0 {
-			fmt.Fprintf(&qb.query, " %s ", logicOperator)
+			fmt.Fprintf(&query, " %s ", logicOperator)
 		}
-		qb.query.WriteString(conditionTemplate)
+		query.WriteString(qb.wrapExistsCondition(field.Name, conditionTemplate, negateExists))
 	}
-	return args, nil
+
+	for index, group := range request.Groups {
+		// empty groups are invalid, see [filter.Request.IsEmpty]
+		if group.IsEmpty() {
+			return "", nil, fmt.Errorf("filter group %d is empty", index)
+		}
+		groupConditions, groupArgs, err := qb.composeConditions(&group)
+		if err != nil {
+			return "", nil, fmt.Errorf("error composing query from filter group %d: %w", index, err)
+		}
+		args = append(args, groupArgs...)
+
+		if index > 0 || len(request.Fields) > 0 {
+			fmt.Fprintf(&query, " %s ", logicOperator)
+		}
+		query.WriteRune('(')
+		query.WriteString(groupConditions)
+		query.WriteRune(')')
+	}
+	return query.String(), args, nil
 }
 
 // likeReplacer is used for escaping LIKE and ILIKE clauses wildcards and backslashes
@@ -108,13 +250,15 @@ func extractFieldValues(input any, compareOperator filter.CompareOperator) (resp
 	}
 
 	if values, isSlice := input.([]any); isSlice {
-		// validate values and escape special symbols
+		// escape special symbols, without modifying the values of the request
+		resp = make([]any, len(values))
 		for index, value := range values {
 			if strValue, isString := value.(string); isString {
-				values[index] = processString(strValue)
+				value = processString(strValue)
 			}
+			resp[index] = value
 		}
-		return values
+		return resp
 	}
 
 	if strValue, isString := input.(string); isString {
@@ -123,79 +267,199 @@ func extractFieldValues(input any, compareOperator filter.CompareOperator) (resp
 	return []any{input}
 }
 
-// addSorting appends sorting conditions to the query builder based on the provided sorting request.
-// It constructs the ORDER BY clause using the specified sort column and direction.
-func (qb *Builder) addSorting(sort *sorting.Request) error {
-	sortStatement := " ORDER BY"
+// sortColumn is a sort key of a sorting request resolved to its database column.
+type sortColumn struct {
+	name      string             // database column name
+	ascending bool               // sort direction
+	nulls     sorting.NullsOrder // explicitly requested position of NULL values
+}
+
+// nullsFirst returns true if NULL values are sorted before all other values.
+// Postgres sorts NULL values last in ascending and first in descending order by default.
+func (c sortColumn) nullsFirst() bool {
+	if c.nulls == sorting.NullsDefault {
+		return !c.ascending
+	}
+	return c.nulls == sorting.NullsFirst
+}
 
-	if sort != nil {
-		var sortDirection string
-		switch sort.SortDirection {
+// sortColumnsOf resolves the sort keys of the sorting request to the database columns.
+func (qb *Builder) sortColumnsOf(sort *sorting.Request) ([]sortColumn, error) {
+	keys := sort.SortKeys()
+	columns := make([]sortColumn, 0, len(keys))
+	for _, key := range keys {
+		var ascending bool
+		switch key.Direction {
 		case sorting.DirectionAscending:
-			sortDirection = "ASC"
+			ascending = true
 		case sorting.DirectionDescending:
-			sortDirection = "DESC"
+			ascending = false
 		default:
-			return fmt.Errorf("invalid sort direction: %s", sort.SortDirection)
+			return nil, fmt.Errorf("invalid sort direction: %s", key.Direction)
+		}
+		if !key.Nulls.IsValid() {
+			return nil, fmt.Errorf("invalid nulls order: %s", key.Nulls)
 		}
 
-		dbColumnName, ok := qb.querySettings.FilterFieldMapping[sort.SortColumn]
+		dbColumnName, ok := qb.querySettings.FilterFieldMapping[key.Column]
 		if !ok {
-			return filter.NewInvalidFilterFieldError(
-				"missing filter field mapping for '%s'", sort.SortColumn)
+			return nil, filter.NewInvalidFilterFieldError(
+				"missing filter field mapping for '%s'", key.Column)
+		}
+		if err := qb.checkNoExistsRelation(key.Column); err != nil {
+			return nil, err
 		}
-		sortStatement += fmt.Sprintf(" %s %s,", dbColumnName, sortDirection)
+		columns = append(columns, sortColumn{name: dbColumnName, ascending: ascending, nulls: key.Nulls})
+	}
+	return columns, nil
+}
+
+// addSorting appends sorting conditions to the query based on the provided sorting request and search.
+// See [Builder.composeOrderBy] for the composition of the ORDER BY clause.
+func (qb *Builder) addSorting(statement *strings.Builder, sort *sorting.Request, search *query.Search) (args []any, err error) {
+	orderBy, args, err := qb.composeOrderBy(sort, search)
+	if err != nil {
+		return nil, err
+	}
+	statement.WriteString(" ORDER BY ")
+	statement.WriteString(orderBy)
+	return args, nil
+}
+
+// composeOrderBy builds the list of sort expressions of the ORDER BY clause using the specified sort keys,
+// each with its direction and optional position of NULL values. The tie breaker column is always appended.
+// If the search requests sorting by rank, the results are ordered by their rank first. The args are the values
+// for the placeholders of the rank expression.
+func (qb *Builder) composeOrderBy(sort *sorting.Request, search *query.Search) (orderBy string, args []any, err error) {
+	columns, err := qb.sortColumnsOf(sort)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var sortStatement string
+	if rankOrder, rankArgs := qb.composeRankOrder(search); rankOrder != "" {
+		sortStatement += rankOrder + ", "
+		args = rankArgs
+	}
+	for _, column := range columns {
+		sortDirection := "DESC"
+		if column.ascending {
+			sortDirection = "ASC"
+		}
+		sortStatement += fmt.Sprintf("%s %s", column.name, sortDirection)
+		switch column.nulls {
+		case sorting.NullsFirst:
+			sortStatement += " NULLS FIRST"
+		case sorting.NullsLast:
+			sortStatement += " NULLS LAST"
+		}
+		sortStatement += ", "
 	}
 	// add tie breaker to ensure consistent sorting
-	sortStatement += fmt.Sprintf(" %s ASC", qb.querySettings.SortingTieBreakerColumn)
+	sortStatement += fmt.Sprintf("%s ASC", qb.querySettings.SortingTieBreakerColumn)
+	return sortStatement, args, nil
+}
 
-	qb.query.WriteString(sortStatement)
-	return nil
+// composeCursorCondition translates the encoded cursor of a paging request into a keyset condition, which
+// only matches rows following the row the cursor points to in the sort order given by `sort`.
+func (qb *Builder) composeCursorCondition(encodedCursor string, sort *sorting.Request) (condition string, args []any, err error) {
+	cursor, err := paging.DecodeCursorOfKind(encodedCursor, paging.CursorKindDocument)
+	if err != nil {
+		return "", nil, err
+	}
+	var sortFields []string
+	for _, key := range sort.SortKeys() {
+		sortFields = append(sortFields, key.Column)
+	}
+	if !cursor.HasSortColumns(sortFields) {
+		return "", nil, fmt.Errorf("cursor was created for sort columns %v, but sort columns are %v",
+			cursor.SortColumns, sortFields)
+	}
+
+	columns, err := qb.sortColumnsOf(sort)
+	if err != nil {
+		return "", nil, err
+	}
+	condition, args = composeKeysetCondition(columns, cursor.SortValues,
+		qb.querySettings.SortingTieBreakerColumn, cursor.TieBreakerValue)
+	return condition, args, nil
 }
 
-// addPaging appends paging conditions to the query builder based on the provided paging request.
+// addPaging appends paging conditions to the query based on the provided paging request.
 // It constructs the OFFSET and LIMIT clauses according to the specified page index and page size.
-func (qb *Builder) addPaging(paging paging.Request) error {
-	if paging.PageSize < 0 || paging.PageIndex < 0 {
-		return fmt.Errorf("paging parameters must be non-negative, got page size: %d, page index: %d",
-			paging.PageSize, paging.PageIndex)
+// If the request contains a cursor, no OFFSET is applied, as the position is already part of the filter conditions.
+func (qb *Builder) addPaging(statement *strings.Builder, paging paging.Request) error {
+	if err := checkPaging(paging); err != nil {
+		return err
 	}
 
 	if paging.PageIndex > 0 {
 		offset := paging.PageIndex * paging.PageSize
-		fmt.Fprintf(&qb.query, " OFFSET %d", offset)
+		fmt.Fprintf(statement, " OFFSET %d", offset)
 	}
 
 	if paging.PageSize > 0 {
-		fmt.Fprintf(&qb.query, " LIMIT %d", paging.PageSize)
+		fmt.Fprintf(statement, " LIMIT %d", paging.PageSize)
+	}
+	return nil
+}
+
+// checkPaging validates the paging request.
+func checkPaging(paging paging.Request) error {
+	if paging.PageSize < 0 || paging.PageIndex < 0 {
+		return fmt.Errorf("paging parameters must be non-negative, got page size: %d, page index: %d",
+			paging.PageSize, paging.PageIndex)
+	}
+	if paging.Cursor != "" && paging.PageIndex != 0 {
+		return fmt.Errorf("page index must be 0 when paging by cursor, got page index: %d", paging.PageIndex)
 	}
 	return nil
 }
 
 // Build generates the complete postgres SQL query based on the provided result selector.
-// It constructs the query by adding filter, sorting, and paging conditions.
+// It constructs the query by adding filter, sorting, and paging conditions. If the paging request contains
+// a cursor, keyset pagination is used instead of an offset. A free text search is added to the filter conditions,
+// see [Settings.SearchColumns]. Joins needed by the filter and sort fields
+// (see [Settings.FieldRelations]) are put in front of the conditions.
 // It returns the constructed query string, and all the individual filter fields values (args) in a single list
 func (qb *Builder) Build(resultSelector query.ResultSelector) (query string, args []any, err error) {
-	if resultSelector.Filter != nil {
-		args, err = qb.addFilters(resultSelector.Filter)
+	if err := checkSearchPaging(resultSelector); err != nil {
+		return "", nil, fmt.Errorf("error adding paging query: %w", err)
+	}
+	var cursorCondition string
+	var cursorArgs []any
+	if resultSelector.Paging != nil && resultSelector.Paging.Cursor != "" {
+		cursorCondition, cursorArgs, err = qb.composeCursorCondition(resultSelector.Paging.Cursor, resultSelector.Sorting)
 		if err != nil {
-			return "", nil, fmt.Errorf("error adding filter query: %w", err)
+			return "", nil, fmt.Errorf("error adding paging query: %w", err)
 		}
 	}
+	extraCondition, extraArgs, err := qb.composeExtraConditions(resultSelector.Search, cursorCondition, cursorArgs)
+	if err != nil {
+		return "", nil, fmt.Errorf("error adding search query: %w", err)
+	}
+
+	var statement strings.Builder
+	args, err = qb.addFilters(&statement, resultSelector.Filter, extraCondition, extraArgs)
+	if err != nil {
+		return "", nil, fmt.Errorf("error adding filter query: %w", err)
+	}
 
-	err = qb.addSorting(resultSelector.Sorting) // sorting is always applied
+	sortArgs, err := qb.addSorting(&statement, resultSelector.Sorting, resultSelector.Search) // sorting is always applied
 	if err != nil {
 		return "", nil, fmt.Errorf("error adding sort query: %w", err)
 	}
+	args = append(args, sortArgs...)
 
 	if resultSelector.Paging != nil {
-		err = qb.addPaging(*resultSelector.Paging)
+		err = qb.addPaging(&statement, *resultSelector.Paging)
 		if err != nil {
 			return "", nil, fmt.Errorf("error adding paging query: %w", err)
 		}
 	}
 
-	query = qb.query.String()
+	joins := qb.composeJoins(append(filterFieldNames(resultSelector.Filter), sortFieldNames(resultSelector.Sorting)...))
+	query = joins + statement.String()
 	query = rebind(query)
 	return query, args, nil
 }
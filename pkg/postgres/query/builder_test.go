@@ -8,11 +8,14 @@ import (
 	"database/sql"
 	"embed"
 	"fmt"
+	"net/netip"
 	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/greenbone/opensight-golang-libraries/internal/pgtesting"
 	"github.com/greenbone/opensight-golang-libraries/pkg/query"
 	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
@@ -38,6 +41,7 @@ type TestDoc struct {
 	Float    float32   `db:"float"`
 	Boolean  bool      `db:"boolean"`
 	DateTime time.Time `db:"date_time"`
+	IP       *string   `db:"ip"`
 }
 
 var fieldMapping = map[string]string{
@@ -47,10 +51,18 @@ var fieldMapping = map[string]string{
 	"floatField":    "float",
 	"booleanField":  "boolean",
 	"dateTimeField": "date_time",
+	"ipField":       "ip",
 }
 
 var sortingTieBreakerColumn = "id"
 
+var stringFieldRating = map[string]map[string]filter.RatingRange{
+	"floatField": {
+		"low":  {Min: 0, Max: 1.5},
+		"high": {Min: 1.5, Max: 10},
+	},
+}
+
 type TestRepository struct {
 	db *sql.DB
 }
@@ -61,14 +73,15 @@ func NewTestRepository(db *sql.DB) *TestRepository {
 
 func (r *TestRepository) CreateTestDoc(testType *TestDoc) error {
 	_, err := r.db.Exec(
-		`INSERT INTO test_table (id, string, integer, float, boolean, date_time)
-		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		`INSERT INTO test_table (id, string, integer, float, boolean, date_time, ip)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
 		testType.ID,
 		testType.String,
 		testType.Integer,
 		testType.Float,
 		testType.Boolean,
 		testType.DateTime,
+		testType.IP,
 	)
 	return err
 }
@@ -83,7 +96,7 @@ func (r *TestRepository) ListTestDocs(query string, args []any) ([]TestDoc, erro
 	var testTypes []TestDoc
 	for rows.Next() {
 		var tt TestDoc
-		if err := rows.Scan(&tt.ID, &tt.String, &tt.Integer, &tt.Float, &tt.Boolean, &tt.DateTime); err != nil {
+		if err := rows.Scan(&tt.ID, &tt.String, &tt.Integer, &tt.Float, &tt.Boolean, &tt.DateTime, &tt.IP); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 		testTypes = append(testTypes, tt)
@@ -95,6 +108,10 @@ func (r *TestRepository) ListTestDocs(query string, args []any) ([]TestDoc, erro
 	return testTypes, nil
 }
 
+func ptr[T any](v T) *T {
+	return &v
+}
+
 func singleFilter(f filter.RequestField) query.ResultSelector {
 	return query.ResultSelector{
 		Filter: &filter.Request{
@@ -117,6 +134,7 @@ func Test_PostgresQueryBuilder_Build(t *testing.T) {
 		Float:    1.1,
 		Boolean:  true,
 		DateTime: time.Date(2024, 1, 23, 10, 0, 0, 0, time.UTC),
+		IP:       ptr("192.168.0.1"),
 	}
 	doc2 := TestDoc{
 		ID:       2,
@@ -125,6 +143,7 @@ func Test_PostgresQueryBuilder_Build(t *testing.T) {
 		Float:    2.2,
 		Boolean:  false,
 		DateTime: time.Date(2024, 2, 23, 10, 0, 0, 0, time.UTC),
+		IP:       ptr("10.0.0.2"),
 	}
 
 	allDocs := []TestDoc{doc0, doc1, doc2} // all available documents in the default sort order
@@ -202,6 +221,47 @@ func Test_PostgresQueryBuilder_Build(t *testing.T) {
 			},
 			wantDocuments: []TestDoc{doc2, doc1, doc0},
 		},
+		"sorting: multiple keys": {
+			resultSelector: query.ResultSelector{
+				Sorting: &sorting.Request{
+					Keys: []sorting.Key{
+						{Column: "booleanField", Direction: sorting.DirectionAscending},
+						{Column: "integerField", Direction: sorting.DirectionDescending},
+					},
+				},
+			},
+			wantDocuments: []TestDoc{doc2, doc0, doc1},
+		},
+		"sorting: nulls first": {
+			resultSelector: query.ResultSelector{
+				Sorting: &sorting.Request{
+					Keys: []sorting.Key{
+						{Column: "ipField", Direction: sorting.DirectionAscending, Nulls: sorting.NullsFirst},
+					},
+				},
+			},
+			wantDocuments: []TestDoc{doc0, doc2, doc1},
+		},
+		"sorting: nulls last": {
+			resultSelector: query.ResultSelector{
+				Sorting: &sorting.Request{
+					Keys: []sorting.Key{
+						{Column: "ipField", Direction: sorting.DirectionDescending, Nulls: sorting.NullsLast},
+					},
+				},
+			},
+			wantDocuments: []TestDoc{doc1, doc2, doc0},
+		},
+		"sorting: fail on invalid nulls order": {
+			resultSelector: query.ResultSelector{
+				Sorting: &sorting.Request{
+					Keys: []sorting.Key{
+						{Column: "ipField", Direction: sorting.DirectionDescending, Nulls: "middle"},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		"sorting: fail on invalid sort column": {
 			resultSelector: query.ResultSelector{
 				Sorting: &sorting.Request{
@@ -317,6 +377,117 @@ func Test_PostgresQueryBuilder_Build(t *testing.T) {
 			},
 			wantDocuments: []TestDoc{doc1},
 		},
+		"combine filters with nested groups": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{
+					Fields: []filter.RequestField{
+						{
+							Name:     "idField",
+							Operator: filter.CompareOperatorIsEqualTo,
+							Value:    doc0.ID,
+						},
+					},
+					Groups: []filter.Request{
+						{
+							Fields: []filter.RequestField{
+								{
+									Name:     "integerField",
+									Operator: filter.CompareOperatorIsGreaterThan,
+									Value:    doc0.Integer,
+								},
+								{
+									Name:     "booleanField",
+									Operator: filter.CompareOperatorIsEqualTo,
+									Value:    false,
+								},
+							},
+							Operator: filter.LogicOperatorAnd,
+						},
+					},
+					Operator: filter.LogicOperatorOr,
+				},
+			},
+			wantDocuments: []TestDoc{doc0, doc2}, // id = 0 OR (integer > 0 AND boolean = false)
+		},
+		"combine nested groups only": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{
+					Groups: []filter.Request{
+						{
+							Fields: []filter.RequestField{
+								{
+									Name:     "integerField",
+									Operator: filter.CompareOperatorIsEqualTo,
+									Value:    []any{doc1.Integer, doc2.Integer},
+								},
+							},
+						},
+						{
+							Fields: []filter.RequestField{
+								{
+									Name:     "stringField",
+									Operator: filter.CompareOperatorContains,
+									Value:    "two",
+								},
+							},
+							Groups: []filter.Request{
+								{
+									Fields: []filter.RequestField{
+										{
+											Name:     "idField",
+											Operator: filter.CompareOperatorIsEqualTo,
+											Value:    doc1.ID,
+										},
+									},
+								},
+							},
+							Operator: filter.LogicOperatorOr,
+						},
+					},
+					Operator: filter.LogicOperatorAnd,
+				},
+			},
+			wantDocuments: []TestDoc{doc1, doc2}, // integer IN (1, 2) AND (string contains 'two' OR id = 1)
+		},
+		"fail on empty nested group": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{
+					Fields: []filter.RequestField{
+						{
+							Name:     "idField",
+							Operator: filter.CompareOperatorIsEqualTo,
+							Value:    doc0.ID,
+						},
+					},
+					Groups:   []filter.Request{{Operator: filter.LogicOperatorAnd}},
+					Operator: filter.LogicOperatorAnd,
+				},
+			},
+			wantErr: true,
+		},
+		"fail on nested group with multiple filters and missing logic operator": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{
+					Groups: []filter.Request{
+						{
+							Fields: []filter.RequestField{
+								{
+									Name:     "integerField",
+									Operator: filter.CompareOperatorIsGreaterThan,
+									Value:    doc0.Integer,
+								},
+								{
+									Name:     "integerField",
+									Operator: filter.CompareOperatorIsLessThan,
+									Value:    doc2.Integer,
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		"fail with multiple filters and missing logic operator": {
 			resultSelector: query.ResultSelector{
 				Filter: &filter.Request{
@@ -755,6 +926,386 @@ func Test_PostgresQueryBuilder_Build(t *testing.T) {
 		})
 	}
 
+	// date range filter
+	for valueType, values := range dateValues {
+		addTest(fmt.Sprintf("operator BetweenDates: (%v)", valueType), testCase{
+			resultSelector: singleFilter(filter.RequestField{
+				Name:     "dateTimeField",
+				Operator: filter.CompareOperatorBetweenDates,
+				Value:    values,
+			}),
+			wantDocuments: []TestDoc{doc1, doc2},
+		})
+	}
+	addTest("operator BetweenDates: fail on single date", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "dateTimeField",
+			Operator: filter.CompareOperatorBetweenDates,
+			Value:    doc1.DateTime,
+		}),
+		wantErr: true,
+	})
+	addTest("operator BetweenDates: fail on invalid date", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "dateTimeField",
+			Operator: filter.CompareOperatorBetweenDates,
+			Value:    []any{"no date", doc2.DateTime},
+		}),
+		wantErr: true,
+	})
+
+	// existence filters
+	addTest("operator Exists", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "ipField",
+			Operator: filter.CompareOperatorExists,
+		}),
+		wantDocuments: []TestDoc{doc1, doc2},
+	})
+	addTest("operator DoesNotExist", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "ipField",
+			Operator: filter.CompareOperatorDoesNotExist,
+		}),
+		wantDocuments: []TestDoc{doc0},
+	})
+
+	// type specific equality operators
+	addTest("operator IsNumberEqualTo", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "integerField",
+			Operator: filter.CompareOperatorIsNumberEqualTo,
+			Value:    doc1.Integer,
+		}),
+		wantDocuments: []TestDoc{doc1},
+	})
+	addTest("operator IsNumberNotEqualTo: multiple values", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "integerField",
+			Operator: filter.CompareOperatorIsNumberNotEqualTo,
+			Value:    []any{doc1.Integer, doc2.Integer},
+		}),
+		wantDocuments: []TestDoc{doc0},
+	})
+	addTest("operator IsNumberEqualTo: invalid value", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "integerField",
+			Operator: filter.CompareOperatorIsNumberEqualTo,
+			Value:    "1",
+		}),
+		wantErr: true,
+	})
+	addTest("operator IsStringEqualTo", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "stringField",
+			Operator: filter.CompareOperatorIsStringEqualTo,
+			Value:    doc1.String,
+		}),
+		wantDocuments: []TestDoc{doc1},
+	})
+	addTest("operator IsStringNotEqualTo", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "stringField",
+			Operator: filter.CompareOperatorIsStringNotEqualTo,
+			Value:    doc1.String,
+		}),
+		wantDocuments: []TestDoc{doc0, doc2},
+	})
+	addTest("operator IsStringEqualTo: invalid value", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "integerField",
+			Operator: filter.CompareOperatorIsStringEqualTo,
+			Value:    doc1.Integer,
+		}),
+		wantErr: true,
+	})
+
+	// full text search
+	addTest("operator TextContains: all words must match", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "stringField",
+			Operator: filter.CompareOperatorTextContains,
+			Value:    "one string",
+		}),
+		wantDocuments: []TestDoc{doc1},
+	})
+	addTest("operator TextContains: multiple values", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "stringField",
+			Operator: filter.CompareOperatorTextContains,
+			Value:    []any{"one", "two"},
+		}),
+		wantDocuments: []TestDoc{doc1, doc2},
+	})
+	addTest("operator TextContains: invalid value", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "stringField",
+			Operator: filter.CompareOperatorTextContains,
+			Value:    1,
+		}),
+		wantErr: true,
+	})
+
+	// ip specific operators
+	addTest("operator IsIpEqualTo: single address", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "ipField",
+			Operator: filter.CompareOperatorIsIpEqualTo,
+			Value:    *doc1.IP,
+		}),
+		wantDocuments: []TestDoc{doc1},
+	})
+	addTest("operator IsIpEqualTo: network", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "ipField",
+			Operator: filter.CompareOperatorIsIpEqualTo,
+			Value:    "10.0.0.0/8",
+		}),
+		wantDocuments: []TestDoc{doc2},
+	})
+	addTest("operator IsIpEqualTo: multiple values", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "ipField",
+			Operator: filter.CompareOperatorIsIpEqualTo,
+			Value:    []any{"192.168.0.0/24", *doc2.IP},
+		}),
+		wantDocuments: []TestDoc{doc1, doc2},
+	})
+	addTest("operator IsIpNotEqualTo: network", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "ipField",
+			Operator: filter.CompareOperatorIsIpNotEqualTo,
+			Value:    "10.0.0.0/8",
+		}),
+		wantDocuments: []TestDoc{doc1}, // NULL values are not matched
+	})
+	addTest("operator IsIpEqualTo: typed values", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "ipField",
+			Operator: filter.CompareOperatorIsIpEqualTo,
+			Value:    []any{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParseAddr(*doc1.IP)},
+		}),
+		wantDocuments: []TestDoc{doc1, doc2},
+	})
+	addTest("operator IsIpEqualTo: invalid value", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "ipField",
+			Operator: filter.CompareOperatorIsIpEqualTo,
+			Value:    "no ip",
+		}),
+		wantErr: true,
+	})
+
+	// rating operators, see `stringFieldRating`
+	ratingOperators := map[string]struct {
+		operator filter.CompareOperator
+		value    any
+		wantDocs []TestDoc
+	}{
+		"IsEqualToRating: single value":    {filter.CompareOperatorIsEqualToRating, "low", []TestDoc{doc0, doc1}},
+		"IsEqualToRating: multiple values": {filter.CompareOperatorIsEqualToRating, []any{"low", "high"}, allDocs},
+		"IsNotEqualToRating":               {filter.CompareOperatorIsNotEqualToRating, "high", []TestDoc{doc0, doc1}},
+		"IsLessThanRating":                 {filter.CompareOperatorIsLessThanRating, "high", []TestDoc{doc0, doc1}},
+		"IsLessThanOrEqualToRating":        {filter.CompareOperatorIsLessThanOrEqualToRating, "low", []TestDoc{doc0, doc1}},
+		"IsGreaterThanRating":              {filter.CompareOperatorIsGreaterThanRating, "low", []TestDoc{doc2}},
+		"IsGreaterThanOrEqualToRating":     {filter.CompareOperatorIsGreaterThanOrEqualToRating, "low", allDocs},
+	}
+	for name, ratingTest := range ratingOperators {
+		addTest(fmt.Sprintf("operator %s", name), testCase{
+			resultSelector: singleFilter(filter.RequestField{
+				Name:     "floatField",
+				Operator: ratingTest.operator,
+				Value:    ratingTest.value,
+			}),
+			wantDocuments: ratingTest.wantDocs,
+		})
+	}
+	addTest("operator IsEqualToRating: fail on unknown rating", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "floatField",
+			Operator: filter.CompareOperatorIsEqualToRating,
+			Value:    "medium",
+		}),
+		wantErr: true,
+	})
+	addTest("operator IsEqualToRating: fail on field without ratings", testCase{
+		resultSelector: singleFilter(filter.RequestField{
+			Name:     "integerField",
+			Operator: filter.CompareOperatorIsEqualToRating,
+			Value:    "low",
+		}),
+		wantErr: true,
+	})
+
+	// cursor (keyset) pagination
+	cursorOf := func(sortColumn string, sortValue any, tieBreakerValue any) string {
+		var sortColumns []string
+		var sortValues []any
+		if sortColumn != "" {
+			sortColumns = []string{sortColumn}
+			sortValues = []any{sortValue}
+		}
+		cursor, err := paging.EncodeCursor(paging.Cursor{
+			SortColumns:     sortColumns,
+			SortValues:      sortValues,
+			TieBreakerValue: tieBreakerValue,
+		})
+		require.NoError(t, err)
+		return cursor
+	}
+	cursorTests := map[string]struct {
+		sorting  *sorting.Request
+		cursor   string
+		wantDocs []TestDoc
+	}{
+		"without sorting": {
+			cursor:   cursorOf("", nil, doc0.ID),
+			wantDocs: []TestDoc{doc1, doc2},
+		},
+		"ascending": {
+			sorting:  &sorting.Request{SortColumn: "integerField", SortDirection: sorting.DirectionAscending},
+			cursor:   cursorOf("integerField", doc1.Integer, doc1.ID),
+			wantDocs: []TestDoc{doc2},
+		},
+		"descending": {
+			sorting:  &sorting.Request{SortColumn: "integerField", SortDirection: sorting.DirectionDescending},
+			cursor:   cursorOf("integerField", doc1.Integer, doc1.ID),
+			wantDocs: []TestDoc{doc0},
+		},
+		"ascending, date value": {
+			sorting:  &sorting.Request{SortColumn: "dateTimeField", SortDirection: sorting.DirectionAscending},
+			cursor:   cursorOf("dateTimeField", doc0.DateTime, doc0.ID),
+			wantDocs: []TestDoc{doc1, doc2},
+		},
+		"ascending, followed by NULL values": {
+			sorting:  &sorting.Request{SortColumn: "ipField", SortDirection: sorting.DirectionAscending},
+			cursor:   cursorOf("ipField", *doc2.IP, doc2.ID),
+			wantDocs: []TestDoc{doc1, doc0},
+		},
+		"ascending, NULL value": {
+			sorting:  &sorting.Request{SortColumn: "ipField", SortDirection: sorting.DirectionAscending},
+			cursor:   cursorOf("ipField", nil, doc0.ID),
+			wantDocs: []TestDoc{},
+		},
+		"descending, NULL value": {
+			sorting:  &sorting.Request{SortColumn: "ipField", SortDirection: sorting.DirectionDescending},
+			cursor:   cursorOf("ipField", nil, doc0.ID),
+			wantDocs: []TestDoc{doc1, doc2},
+		},
+	}
+	for name, cursorTest := range cursorTests {
+		addTest(fmt.Sprintf("pagination: cursor %s", name), testCase{
+			resultSelector: query.ResultSelector{
+				Sorting: cursorTest.sorting,
+				Paging: &paging.Request{
+					PageSize: 10,
+					Cursor:   cursorTest.cursor,
+				},
+			},
+			wantDocuments: cursorTest.wantDocs,
+		})
+	}
+	addTest("pagination: cursor with multiple sort keys", testCase{
+		resultSelector: query.ResultSelector{
+			Sorting: &sorting.Request{
+				Keys: []sorting.Key{
+					{Column: "booleanField", Direction: sorting.DirectionAscending},
+					{Column: "integerField", Direction: sorting.DirectionDescending},
+				},
+			},
+			Paging: &paging.Request{
+				PageSize: 10,
+				Cursor: func() string {
+					cursor, err := paging.EncodeCursor(paging.Cursor{
+						SortColumns:     []string{"booleanField", "integerField"},
+						SortValues:      []any{doc2.Boolean, doc2.Integer},
+						TieBreakerValue: doc2.ID,
+					})
+					require.NoError(t, err)
+					return cursor
+				}(),
+			},
+		},
+		wantDocuments: []TestDoc{doc0, doc1},
+	})
+	addTest("pagination: cursor with NULL value sorted first", testCase{
+		resultSelector: query.ResultSelector{
+			Sorting: &sorting.Request{
+				Keys: []sorting.Key{
+					{Column: "ipField", Direction: sorting.DirectionAscending, Nulls: sorting.NullsFirst},
+				},
+			},
+			Paging: &paging.Request{
+				PageSize: 10,
+				Cursor:   cursorOf("ipField", nil, doc0.ID),
+			},
+		},
+		wantDocuments: []TestDoc{doc2, doc1},
+	})
+	addTest("pagination: cursor combined with filter", testCase{
+		resultSelector: query.ResultSelector{
+			Filter: &filter.Request{
+				Operator: filter.LogicOperatorOr,
+				Fields: []filter.RequestField{
+					{Name: "idField", Operator: filter.CompareOperatorIsEqualTo, Value: doc0.ID},
+					{Name: "idField", Operator: filter.CompareOperatorIsEqualTo, Value: doc2.ID},
+				},
+			},
+			Paging: &paging.Request{
+				PageSize: 1,
+				Cursor:   cursorOf("", nil, doc0.ID),
+			},
+		},
+		wantDocuments: []TestDoc{doc2},
+	})
+	addTest("pagination: fail on cursor for different sort column", testCase{
+		resultSelector: query.ResultSelector{
+			Sorting: &sorting.Request{SortColumn: "integerField", SortDirection: sorting.DirectionAscending},
+			Paging: &paging.Request{
+				PageSize: 10,
+				Cursor:   cursorOf("floatField", doc1.Float, doc1.ID),
+			},
+		},
+		wantErr: true,
+	})
+	addTest("pagination: fail on aggregation cursor", testCase{
+		resultSelector: query.ResultSelector{
+			Sorting: &sorting.Request{SortColumn: "integerField", SortDirection: sorting.DirectionAscending},
+			Paging: &paging.Request{
+				PageSize: 10,
+				Cursor: func() string {
+					cursor, err := paging.EncodeCursor(paging.Cursor{
+						Kind:        paging.CursorKindAggregation,
+						SortColumns: []string{"integerField"},
+						SortValues:  []any{doc1.Integer},
+					})
+					require.NoError(t, err)
+					return cursor
+				}(),
+			},
+		},
+		wantErr: true,
+	})
+	addTest("pagination: fail on invalid cursor", testCase{
+		resultSelector: query.ResultSelector{
+			Paging: &paging.Request{
+				PageSize: 10,
+				Cursor:   "invalid",
+			},
+		},
+		wantErr: true,
+	})
+	addTest("pagination: fail on cursor with page index", testCase{
+		resultSelector: query.ResultSelector{
+			Paging: &paging.Request{
+				PageSize:  10,
+				PageIndex: 1,
+				Cursor:    cursorOf("", nil, doc0.ID),
+			},
+		},
+		wantErr: true,
+	})
+
 	// database setup only needed once, as test cases are only reading data
 	db := pgtesting.NewDB(t, migrationsFS, migrationDir)
 	repo := NewTestRepository(db)
@@ -773,6 +1324,7 @@ func Test_PostgresQueryBuilder_Build(t *testing.T) {
 			querySettings := Settings{
 				FilterFieldMapping:      fieldMapping,
 				SortingTieBreakerColumn: sortingTieBreakerColumn,
+				StringFieldRating:       stringFieldRating,
 			}
 			builder, err := NewPostgresQueryBuilder(querySettings)
 			require.NoError(t, err, "failed to create Postgres query builder")
@@ -825,6 +1377,14 @@ func Test_NewPostgresQueryBuilder(t *testing.T) {
 			settings: Settings{},
 			wantErr:  true,
 		},
+		{
+			name: "failure with invalid column in field mapping",
+			settings: Settings{
+				FilterFieldMapping:      map[string]string{"field": ".column"},
+				SortingTieBreakerColumn: "id",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -840,3 +1400,116 @@ func Test_NewPostgresQueryBuilder(t *testing.T) {
 		})
 	}
 }
+
+func Test_NewPostgresQueryBuilder_CopiesSettings(t *testing.T) {
+	ratings := map[string]map[string]filter.RatingRange{
+		"floatField": {"high": {Min: 1.5, Max: 10}},
+	}
+	builder, err := NewPostgresQueryBuilder(Settings{
+		FilterFieldMapping:      map[string]string{"floatField": "float"},
+		SortingTieBreakerColumn: sortingTieBreakerColumn,
+		StringFieldRating:       ratings,
+	})
+	require.NoError(t, err)
+
+	ratings["floatField"]["high"] = filter.RatingRange{Min: 5, Max: 6}
+	ratings["floatField"]["low"] = filter.RatingRange{Min: 0, Max: 1.5}
+
+	_, args, err := builder.Build(query.ResultSelector{Filter: &filter.Request{
+		Operator: filter.LogicOperatorAnd,
+		Fields: []filter.RequestField{
+			{Name: "floatField", Operator: filter.CompareOperatorIsEqualToRating, Value: "high"},
+		},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []any{float32(1.5), float32(10)}, args)
+
+	_, _, err = builder.Build(query.ResultSelector{Filter: &filter.Request{
+		Operator: filter.LogicOperatorAnd,
+		Fields: []filter.RequestField{
+			{Name: "floatField", Operator: filter.CompareOperatorIsEqualToRating, Value: "low"},
+		},
+	}})
+	assert.Error(t, err)
+}
+
+func Test_PostgresQueryBuilder_NormalizedFilter(t *testing.T) {
+	id := uuid.MustParse("9bd5c4ea-5b0c-4a4f-a2a0-5a0ff0a3e1d2")
+	otherId := uuid.MustParse("2d1b0c4e-8c4f-4a59-9a1e-6f3f0f1b2c3d")
+	requestOptions := []filter.RequestOption{
+		{Name: filter.NewReadableValue("", "idField"), Control: filter.RequestOptionType{Type: filter.ControlTypeUuid}},
+	}
+	builder, err := NewPostgresQueryBuilder(Settings{
+		FilterFieldMapping:      fieldMapping,
+		SortingTieBreakerColumn: sortingTieBreakerColumn,
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		value     any
+		wantQuery string
+		wantArgs  []any
+	}{
+		"single uuid": {
+			value:     id.String(),
+			wantQuery: `WHERE (("id" = $1)) ORDER BY id ASC`,
+			wantArgs:  []any{id},
+		},
+		"list of uuids": {
+			value:     []any{id.String(), otherId.String()},
+			wantQuery: `WHERE (("id" = $1) OR ("id" = $2)) ORDER BY id ASC`,
+			wantArgs:  []any{id, otherId},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			normalized, err := filter.NormalizeFilter(&filter.Request{
+				Operator: filter.LogicOperatorAnd,
+				Fields:   []filter.RequestField{{Name: "idField", Operator: filter.CompareOperatorIsEqualTo, Value: tt.value}},
+			}, requestOptions)
+			require.NoError(t, err)
+
+			gotQuery, gotArgs, err := builder.Build(query.ResultSelector{Filter: normalized})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantQuery, gotQuery)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func Test_PostgresQueryBuilder_Reuse(t *testing.T) {
+	builder, err := NewPostgresQueryBuilder(Settings{
+		FilterFieldMapping:      fieldMapping,
+		SortingTieBreakerColumn: sortingTieBreakerColumn,
+		StringFieldRating:       stringFieldRating,
+	})
+	require.NoError(t, err)
+
+	resultSelector := query.ResultSelector{
+		Filter: &filter.Request{
+			Operator: filter.LogicOperatorAnd,
+			Fields: []filter.RequestField{
+				{Name: "stringField", Operator: filter.CompareOperatorContains, Value: []any{"a", "b"}},
+				{Name: "floatField", Operator: filter.CompareOperatorIsEqualToRating, Value: "high"},
+			},
+		},
+		Sorting: &sorting.Request{SortColumn: "integerField", SortDirection: sorting.DirectionDescending},
+		Paging:  &paging.Request{PageIndex: 1, PageSize: 10},
+	}
+	wantQuery := `WHERE (("string" ILIKE '%' || $1 || '%') OR ("string" ILIKE '%' || $2 || '%')) AND ` +
+		`(("float" BETWEEN $3 AND $4)) ORDER BY integer DESC, id ASC OFFSET 10 LIMIT 10`
+	wantArgs := []any{"a", "b", float32(1.5), float32(10)}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			gotQuery, gotArgs, err := builder.Build(resultSelector)
+			assert.NoError(t, err)
+			assert.Equal(t, wantQuery, gotQuery)
+			assert.Equal(t, wantArgs, gotArgs)
+		}()
+	}
+	wg.Wait()
+}
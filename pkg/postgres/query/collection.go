@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/lib/pq"
+)
+
+// ArrayMatch defines how a list of values is matched against an array column by default, if the filter field
+// doesn't define it, see [filter.RequestField].
+type ArrayMatch = filter.ValueMatch
+
+const (
+	// ArrayMatchAny matches if any of the values matches an element of the array.
+	ArrayMatchAny = filter.ValueMatchAny
+	// ArrayMatchAll matches if each of the values matches an element of the array.
+	ArrayMatchAll = filter.ValueMatchAll
+)
+
+// composeJsonbQuery translates a filter field mapped to a `jsonb` column containing an object, e.g. tags like
+// `{"env": "prod"}`, into a SQL query condition. The keys of the field are the path to the compared value.
+// Supported are the (negated) equal, contains and begins with operators and the exists operators, which check
+// for the presence of the key. Like for tag filters, the value of the exists operator can be `yes` or `no` to
+// invert the check. The match of the field defines whether any or all values of a list need to match.
+// Negated conditions also match rows without the key.
+//
+// The equal operators use the containment operator `@>`, e.g. `labels @> '{"env": "prod"}'`, so the values
+// are compared as JSON strings and the condition can use a GIN index of the column. The other operators compare
+// the value at the path as text, e.g. `labels #>> '{env}' ILIKE 'pro%'`, which can't use a GIN index.
+func composeJsonbQuery(field filter.RequestField, args []any) (conditionTemplate string, conditionArgs []any, err error) {
+	if len(field.Keys) == 0 {
+		return "", nil, fmt.Errorf("operator '%s' on a jsonb field requires keys", field.Operator)
+	}
+	path := pq.Array(field.Keys)
+
+	switch field.Operator {
+	case filter.CompareOperatorExists, filter.CompareOperatorDoesNotExist:
+		exists := field.Operator == filter.CompareOperatorExists
+		if value, ok := field.Value.(string); ok && strings.EqualFold(value, "no") {
+			exists = !exists
+		}
+		statement := "(%s #> ?::text[]) IS NOT NULL"
+		if !exists {
+			statement = "(%s #> ?::text[]) IS NULL"
+		}
+		return chainStatementsByOr(false, fmt.Sprintf(statement, field.Name), 1), []any{path}, nil
+	}
+
+	var negate, containment bool
+	var singleStatement string
+	switch field.Operator {
+	case filter.CompareOperatorIsEqualTo, filter.CompareOperatorIsStringEqualTo,
+		filter.CompareOperatorIsNotEqualTo, filter.CompareOperatorIsStringNotEqualTo:
+		negate = field.Operator == filter.CompareOperatorIsNotEqualTo ||
+			field.Operator == filter.CompareOperatorIsStringNotEqualTo
+		containment = true
+		singleStatement = fmt.Sprintf("coalesce(%s @> ?::jsonb, false)", field.Name)
+	case filter.CompareOperatorContains, filter.CompareOperatorDoesNotContain:
+		negate = field.Operator == filter.CompareOperatorDoesNotContain
+		singleStatement = fmt.Sprintf("coalesce(%s #>> ?::text[] ILIKE '%%' || ? || '%%', false)", field.Name)
+	case filter.CompareOperatorBeginsWith, filter.CompareOperatorDoesNotBeginWith:
+		negate = field.Operator == filter.CompareOperatorDoesNotBeginWith
+		singleStatement = fmt.Sprintf("coalesce(%s #>> ?::text[] ILIKE ? || '%%', false)", field.Name)
+	default:
+		return "", nil, fmt.Errorf("operator '%s' is not supported for jsonb fields", field.Operator)
+	}
+	if err := checkStringValues(field); err != nil {
+		return "", nil, err
+	}
+
+	for _, arg := range args {
+		if containment {
+			document, err := jsonbDocument(field.Keys, arg)
+			if err != nil {
+				return "", nil, err
+			}
+			conditionArgs = append(conditionArgs, document)
+		} else {
+			// the text comparison needs the path and the value itself
+			conditionArgs = append(conditionArgs, path, arg)
+		}
+	}
+	return chainCollectionStatements(negate, singleStatement, len(args), field.Match), conditionArgs, nil
+}
+
+// jsonbDocument returns the JSON object containing the value at the path of the keys,
+// e.g. `{"nested": {"team": "blue"}}` for the keys `nested`, `team` and the value `blue`.
+func jsonbDocument(keys []string, value any) (string, error) {
+	for i := len(keys) - 1; i >= 0; i-- {
+		value = map[string]any{keys[i]: value}
+	}
+	document, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode jsonb value: %w", err)
+	}
+	return string(document), nil
+}
+
+// chainCollectionStatements chains the statements of the values of a collection field by OR, or by AND
+// if all values need to match.
+func chainCollectionStatements(negate bool, singleStatement string, count int, match filter.ValueMatch) string {
+	if match == filter.ValueMatchAll {
+		return chainStatements(negate, singleStatement, count, "AND")
+	}
+	return chainStatementsByOr(negate, singleStatement, count)
+}
+
+// composeArrayQuery translates a filter field mapped to an array column, e.g. `text[]`, into a SQL query
+// condition. A value matches if any element of the array matches it. The match of the field, or `match` if the
+// field doesn't define it, defines whether any or all values of a list need to match. Supported are the (negated) equal, contains and begins with operators and the exists
+// operators, which check whether the array has any elements.
+// Negated conditions also match rows without any element.
+func composeArrayQuery(field filter.RequestField, args []any, match ArrayMatch) (conditionTemplate string, conditionArgs []any, err error) {
+	switch field.Operator {
+	case filter.CompareOperatorExists:
+		return fmt.Sprintf("(coalesce(cardinality(%s), 0) > 0)", field.Name), nil, nil
+	case filter.CompareOperatorDoesNotExist:
+		return fmt.Sprintf("(coalesce(cardinality(%s), 0) = 0)", field.Name), nil, nil
+	}
+
+	var negate bool
+	var singleStatement string
+	switch field.Operator {
+	case filter.CompareOperatorIsEqualTo, filter.CompareOperatorIsNotEqualTo:
+		negate = field.Operator == filter.CompareOperatorIsNotEqualTo
+		singleStatement = fmt.Sprintf("coalesce(? = ANY(%s), false)", field.Name)
+	case filter.CompareOperatorContains, filter.CompareOperatorDoesNotContain:
+		negate = field.Operator == filter.CompareOperatorDoesNotContain
+		singleStatement = fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM unnest(%s) AS element WHERE element::text ILIKE '%%' || ? || '%%')", field.Name)
+	case filter.CompareOperatorBeginsWith, filter.CompareOperatorDoesNotBeginWith:
+		negate = field.Operator == filter.CompareOperatorDoesNotBeginWith
+		singleStatement = fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM unnest(%s) AS element WHERE element::text ILIKE ? || '%%')", field.Name)
+	default:
+		return "", nil, fmt.Errorf("operator '%s' is not supported for array fields", field.Operator)
+	}
+	if field.Operator != filter.CompareOperatorIsEqualTo && field.Operator != filter.CompareOperatorIsNotEqualTo {
+		if err := checkStringValues(field); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if field.Match != "" {
+		match = field.Match
+	}
+	return chainCollectionStatements(negate, singleStatement, len(args), match), args, nil
+}
+
+// checkStringValues validates that the single value or all values of a list are strings.
+func checkStringValues(field filter.RequestField) error {
+	_, err := checkValues(field.Value, func(value any) error {
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("operator '%s' requires string values, got %T", field.Operator, value)
+		}
+		return nil
+	})
+	return err
+}
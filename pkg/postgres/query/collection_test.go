@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"testing"
+
+	"github.com/greenbone/opensight-golang-libraries/internal/pgtesting"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/sorting"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PostgresQueryBuilder_CollectionFields(t *testing.T) {
+	rows := `INSERT INTO test_collection (id, labels, tags) VALUES
+		(1, '{"env": "prod", "owner": "alice"}', '{web,linux}'),
+		(2, '{"env": "dev", "nested": {"team": "blue"}}', '{db,linux}'),
+		(3, NULL, '{}'),
+		(4, '{"owner": "bob"}', NULL)`
+
+	collectionFieldMapping := map[string]string{
+		"id":     "id",
+		"label":  "labels",
+		"tag":    "tags",
+		"tagAll": "tags",
+	}
+
+	type testCase struct {
+		filter  *filter.Request
+		wantIDs []int
+		wantErr bool
+	}
+
+	tests := map[string]testCase{
+		"jsonb is equal to": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "label", Keys: []string{"env"}, Operator: filter.CompareOperatorIsEqualTo, Value: []any{"prod", "dev"}},
+			}},
+			wantIDs: []int{1, 2},
+		},
+		"jsonb is not equal to includes missing keys": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "label", Keys: []string{"env"}, Operator: filter.CompareOperatorIsNotEqualTo, Value: "prod"},
+			}},
+			wantIDs: []int{2, 3, 4},
+		},
+		"jsonb nested path is equal to": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "label", Keys: []string{"nested", "team"}, Operator: filter.CompareOperatorIsEqualTo, Value: "blue"},
+			}},
+			wantIDs: []int{2},
+		},
+		"jsonb is equal to all values": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "label", Keys: []string{"env"}, Operator: filter.CompareOperatorIsEqualTo, Match: filter.ValueMatchAll, Value: []any{"prod", "dev"}},
+			}},
+			wantIDs: nil,
+		},
+		"jsonb contains all values": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "label", Keys: []string{"owner"}, Operator: filter.CompareOperatorContains, Match: filter.ValueMatchAll, Value: []any{"al", "CE"}},
+			}},
+			wantIDs: []int{1},
+		},
+		"jsonb nested path begins with": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "label", Keys: []string{"nested", "team"}, Operator: filter.CompareOperatorBeginsWith, Value: "BL"},
+			}},
+			wantIDs: []int{2},
+		},
+		"jsonb contains": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "label", Keys: []string{"owner"}, Operator: filter.CompareOperatorContains, Value: "li"},
+			}},
+			wantIDs: []int{1},
+		},
+		"jsonb key exists": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "label", Keys: []string{"owner"}, Operator: filter.CompareOperatorExists, Value: "yes"},
+			}},
+			wantIDs: []int{1, 4},
+		},
+		"jsonb key exists with value no": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "label", Keys: []string{"owner"}, Operator: filter.CompareOperatorExists, Value: "no"},
+			}},
+			wantIDs: []int{2, 3},
+		},
+		"jsonb without keys": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "label", Operator: filter.CompareOperatorIsEqualTo, Value: "prod"},
+			}},
+			wantErr: true,
+		},
+		"jsonb unsupported operator": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "label", Keys: []string{"env"}, Operator: filter.CompareOperatorIsGreaterThan, Value: "a"},
+			}},
+			wantErr: true,
+		},
+		"array contains any value": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "tag", Operator: filter.CompareOperatorIsEqualTo, Value: []any{"web", "db"}},
+			}},
+			wantIDs: []int{1, 2},
+		},
+		"array contains all values": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "tagAll", Operator: filter.CompareOperatorIsEqualTo, Value: []any{"web", "linux"}},
+			}},
+			wantIDs: []int{1},
+		},
+		"array contains all values chosen by the request": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "tag", Operator: filter.CompareOperatorIsEqualTo, Match: filter.ValueMatchAll, Value: []any{"web", "linux"}},
+			}},
+			wantIDs: []int{1},
+		},
+		"array contains any value chosen by the request": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "tagAll", Operator: filter.CompareOperatorIsEqualTo, Match: filter.ValueMatchAny, Value: []any{"web", "db"}},
+			}},
+			wantIDs: []int{1, 2},
+		},
+		"all values of other field": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "id", Operator: filter.CompareOperatorIsEqualTo, Match: filter.ValueMatchAll, Value: []any{1, 2}},
+			}},
+			wantErr: true,
+		},
+		"array does not contain value": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "tag", Operator: filter.CompareOperatorIsNotEqualTo, Value: "web"},
+			}},
+			wantIDs: []int{2, 3, 4},
+		},
+		"array element contains": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "tag", Operator: filter.CompareOperatorContains, Value: "IN"},
+			}},
+			wantIDs: []int{1, 2},
+		},
+		"array element begins with": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "tag", Operator: filter.CompareOperatorBeginsWith, Value: "w"},
+			}},
+			wantIDs: []int{1},
+		},
+		"array has elements": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "tag", Operator: filter.CompareOperatorExists},
+			}},
+			wantIDs: []int{1, 2},
+		},
+		"array has no elements": {
+			filter: &filter.Request{Fields: []filter.RequestField{
+				{Name: "tag", Operator: filter.CompareOperatorDoesNotExist},
+			}},
+			wantIDs: []int{3, 4},
+		},
+	}
+
+	db := pgtesting.NewDB(t, migrationsFS, migrationDir)
+	_, err := db.Exec(rows)
+	require.NoError(t, err)
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder, err := NewPostgresQueryBuilder(Settings{
+				FilterFieldMapping:      collectionFieldMapping,
+				SortingTieBreakerColumn: "id",
+				JsonbFields:             map[string]bool{"label": true},
+				ArrayFields:             map[string]ArrayMatch{"tag": ArrayMatchAny, "tagAll": ArrayMatchAll},
+			})
+			require.NoError(t, err)
+
+			conditionalQuery, args, err := builder.Build(query.ResultSelector{
+				Filter:  tt.filter,
+				Sorting: &sorting.Request{SortColumn: "id", SortDirection: sorting.DirectionAscending},
+			})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			fullQuery := `SELECT id FROM test_collection ` + conditionalQuery
+			t.Logf("sending query to database: query: %v, args: %v", fullQuery, args)
+			rows, err := db.Query(fullQuery, args...)
+			require.NoError(t, err)
+			defer rows.Close()
+
+			var ids []int
+			for rows.Next() {
+				var id int
+				require.NoError(t, rows.Scan(&id))
+				ids = append(ids, id)
+			}
+			require.NoError(t, rows.Err())
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func Test_PostgresQueryBuilder_JsonbConditions(t *testing.T) {
+	builder, err := NewPostgresQueryBuilder(Settings{
+		FilterFieldMapping:      map[string]string{"label": "labels"},
+		SortingTieBreakerColumn: "id",
+		JsonbFields:             map[string]bool{"label": true},
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		field     filter.RequestField
+		wantQuery string
+		wantArgs  []any
+	}{
+		"equal to uses containment": {
+			field:     filter.RequestField{Name: "label", Keys: []string{"nested", "team"}, Operator: filter.CompareOperatorIsEqualTo, Value: "blue"},
+			wantQuery: `WHERE ((coalesce("labels" @> $1::jsonb, false))) ORDER BY id ASC`,
+			wantArgs:  []any{`{"nested":{"team":"blue"}}`},
+		},
+		"not equal to all values": {
+			field: filter.RequestField{Name: "label", Keys: []string{"env"}, Operator: filter.CompareOperatorIsNotEqualTo,
+				Match: filter.ValueMatchAll, Value: []any{"prod", "dev"}},
+			wantQuery: `WHERE  NOT ((coalesce("labels" @> $1::jsonb, false)) AND (coalesce("labels" @> $2::jsonb, false))) ORDER BY id ASC`,
+			wantArgs:  []any{`{"env":"prod"}`, `{"env":"dev"}`},
+		},
+		"begins with compares text": {
+			field:     filter.RequestField{Name: "label", Keys: []string{"env"}, Operator: filter.CompareOperatorBeginsWith, Value: "pro"},
+			wantQuery: `WHERE ((coalesce("labels" #>> $1::text[] ILIKE $2 || '%', false))) ORDER BY id ASC`,
+			wantArgs:  []any{pq.Array([]string{"env"}), "pro"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotQuery, gotArgs, err := builder.Build(query.ResultSelector{
+				Filter: &filter.Request{Operator: filter.LogicOperatorAnd, Fields: []filter.RequestField{tt.field}},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantQuery, gotQuery)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
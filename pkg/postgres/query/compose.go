@@ -14,31 +14,48 @@ import (
 
 // composeQuery takes a filter request field and translates it into a SQL query condition
 // which can be used in a WHERE clause.
-func composeQuery(
-	fieldMapping map[string]string, // Mapping of field names to database column names
+func (qb *Builder) composeQuery(
 	field filter.RequestField, // The filter request field containing the field name and operator
 ) (
 	conditionTemplate string, // Template for the SQL condition
+	args []any, // Values for the placeholders in the condition template
 	err error, // Error encountered during execution
 ) {
 	// translate filter field to database column name if field mapping exists
-	dbColumnName, ok := fieldMapping[field.Name]
+	quotedName, ok := qb.columns[field.Name]
 	if !ok {
-		return "", filter.NewInvalidFilterFieldError(
+		return "", nil, filter.NewInvalidFilterFieldError(
 			"invalid filter field '%s', available fields: ",
-			slices.Collect(maps.Keys(fieldMapping)))
-	}
-	quotedName, err := getQuotedName(dbColumnName)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse quoted name of field %s: %w", field.Name, err)
+			slices.Collect(maps.Keys(qb.querySettings.FilterFieldMapping)))
 	}
+	ratings := qb.querySettings.StringFieldRating[field.Name]
+	filterFieldName := field.Name
 	field.Name = quotedName
+	args = extractFieldValues(field.Value, field.Operator)
+
+	if qb.querySettings.JsonbFields[filterFieldName] {
+		return composeJsonbQuery(field, args)
+	}
+	if match, ok := qb.querySettings.ArrayFields[filterFieldName]; ok {
+		return composeArrayQuery(field, args, match)
+	}
+	if field.Match == filter.ValueMatchAll {
+		return "", nil, fmt.Errorf("match '%s' is only supported for jsonb and array fields", field.Match)
+	}
 
 	switch field.Operator {
 	case filter.CompareOperatorIsEqualTo:
 		conditionTemplate, err = buildComparisonStatementSimple(field, false, "=")
 	case filter.CompareOperatorIsNotEqualTo:
 		conditionTemplate, err = buildComparisonStatementSimple(field, true, "=")
+	case filter.CompareOperatorIsNumberEqualTo:
+		conditionTemplate, err = buildNumberComparisonStatement(field, false, "=")
+	case filter.CompareOperatorIsNumberNotEqualTo:
+		conditionTemplate, err = buildNumberComparisonStatement(field, true, "=")
+	case filter.CompareOperatorIsStringEqualTo:
+		conditionTemplate, err = buildStringComparisonStatement(field, false, "=", "?")
+	case filter.CompareOperatorIsStringNotEqualTo:
+		conditionTemplate, err = buildStringComparisonStatement(field, true, "=", "?")
 	case filter.CompareOperatorIsLessThan:
 		conditionTemplate, err = buildComparisonStatementSimple(field, false, "<")
 	case filter.CompareOperatorIsLessThanOrEqualTo:
@@ -57,12 +74,47 @@ func composeQuery(
 		conditionTemplate, err = buildStringComparisonStatement(field, true, "ILIKE", `? || '%'`)
 	case filter.CompareOperatorIsStringCaseInsensitiveEqualTo:
 		conditionTemplate, err = buildStringComparisonStatement(field, false, "ILIKE", "?")
+	case filter.CompareOperatorTextContains:
+		conditionTemplate, err = buildTextSearchStatement(field)
+	case filter.CompareOperatorIsIpEqualTo:
+		conditionTemplate, err = buildIpComparisonStatement(field, false)
+		args = ipArgs(args)
+	case filter.CompareOperatorIsIpNotEqualTo:
+		conditionTemplate, err = buildIpComparisonStatement(field, true)
+		args = ipArgs(args)
 	case filter.CompareOperatorBeforeDate:
 		conditionTemplate, err = buildDateTruncStatement(field, "<")
 	case filter.CompareOperatorAfterDate:
 		conditionTemplate, err = buildDateTruncStatement(field, ">")
+	case filter.CompareOperatorBetweenDates:
+		conditionTemplate, err = buildBetweenDatesStatement(field)
+	case filter.CompareOperatorExists:
+		conditionTemplate, args = fmt.Sprintf("(%s IS NOT NULL)", field.Name), nil
+	case filter.CompareOperatorDoesNotExist:
+		conditionTemplate, args = fmt.Sprintf("(%s IS NULL)", field.Name), nil
+	case filter.CompareOperatorIsEqualToRating:
+		conditionTemplate, args, err = buildRatingStatement(field, ratings, false, "%s BETWEEN ? AND ?",
+			func(r filter.RatingRange) []any { return []any{r.Min, r.Max} })
+	case filter.CompareOperatorIsNotEqualToRating:
+		conditionTemplate, args, err = buildRatingStatement(field, ratings, true, "%s BETWEEN ? AND ?",
+			func(r filter.RatingRange) []any { return []any{r.Min, r.Max} })
+	case filter.CompareOperatorIsLessThanRating:
+		conditionTemplate, args, err = buildRatingStatement(field, ratings, false, "%s < ?",
+			func(r filter.RatingRange) []any { return []any{r.Min} })
+	case filter.CompareOperatorIsLessThanOrEqualToRating:
+		conditionTemplate, args, err = buildRatingStatement(field, ratings, false, "%s <= ?",
+			func(r filter.RatingRange) []any { return []any{r.Max} })
+	case filter.CompareOperatorIsGreaterThanRating:
+		conditionTemplate, args, err = buildRatingStatement(field, ratings, false, "%s > ?",
+			func(r filter.RatingRange) []any { return []any{r.Max} })
+	case filter.CompareOperatorIsGreaterThanOrEqualToRating:
+		conditionTemplate, args, err = buildRatingStatement(field, ratings, false, "%s >= ?",
+			func(r filter.RatingRange) []any { return []any{r.Min} })
 	default:
 		err = fmt.Errorf("field '%s' with unknown operator '%s'", field.Name, field.Operator)
 	}
-	return conditionTemplate, err
+	if err != nil {
+		return "", nil, err
+	}
+	return conditionTemplate, args, nil
 }
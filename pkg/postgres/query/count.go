@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"fmt"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+)
+
+// TotalCountColumn is the name of the column added to the select list by [WithTotalCount].
+const TotalCountColumn = "total_count"
+
+// BuildWithCount is like [Builder.Build], but additionally returns a conditional query to count all results
+// matching the filter of the result selector, which is needed for the total results of [query.NewMetadata].
+// The count query only contains the joins needed by the filter and the `WHERE` clause, so it can be used like
+//
+//	`SELECT count(*) FROM table ` + countQuery
+//
+// The free text search is applied, but sorting and paging are not part of the count query, and neither is the position of a cursor, so `countArgs`
+// can differ from `args`.
+func (qb *Builder) BuildWithCount(resultSelector query.ResultSelector) (
+	query string, args []any, countQuery string, countArgs []any, err error,
+) {
+	searchCondition, searchArgs, err := qb.composeSearchCondition(resultSelector.Search)
+	if err != nil {
+		return "", nil, "", nil, fmt.Errorf("error adding search query: %w", err)
+	}
+	countQuery, countArgs, err = qb.composeWhereClause(resultSelector.Filter, searchCondition, searchArgs)
+	if err != nil {
+		return "", nil, "", nil, fmt.Errorf("error adding filter query: %w", err)
+	}
+
+	query, args, err = qb.Build(resultSelector)
+	if err != nil {
+		return "", nil, "", nil, err
+	}
+	countQuery = qb.composeJoins(filterFieldNames(resultSelector.Filter)) + countQuery
+	return query, args, rebind(countQuery), countArgs, nil
+}
+
+// WithTotalCount adds the window function `count(*) OVER()` as column [TotalCountColumn] to the given select
+// list. Combined with the query of [Builder.Build] each returned row contains the total number of rows matching
+// the filter, so no separate count query is needed:
+//
+//	`SELECT ` + WithTotalCount("*") + ` FROM table ` + query
+//
+// As the window function is evaluated before `OFFSET` and `LIMIT`, the count is not available for pages
+// beyond the last result. When paging by cursor, only the rows following the cursor are counted,
+// use [Builder.BuildWithCount] in that case.
+func WithTotalCount(selectList string) string {
+	return selectList + `, count(*) OVER() AS "` + TotalCountColumn + `"`
+}
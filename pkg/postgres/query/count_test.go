@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greenbone/opensight-golang-libraries/internal/pgtesting"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/sorting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PostgresQueryBuilder_BuildWithCount(t *testing.T) {
+	docs := []TestDoc{
+		{ID: 1, String: "a", Integer: 1, DateTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, String: "b", Integer: 2, DateTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, String: "c", Integer: 3, DateTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 4, String: "d", Integer: 4, DateTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	cursor, err := paging.EncodeCursor(paging.Cursor{
+		SortColumns: []string{"integerField"}, SortValues: []any{1}, TieBreakerValue: 1,
+	})
+	require.NoError(t, err)
+	sortByInteger := &sorting.Request{SortColumn: "integerField", SortDirection: sorting.DirectionAscending}
+
+	type testCase struct {
+		resultSelector query.ResultSelector
+		wantIDs        []int
+		wantTotal      uint64
+	}
+
+	tests := map[string]testCase{
+		"without filter": {
+			resultSelector: query.ResultSelector{
+				Sorting: sortByInteger,
+				Paging:  &paging.Request{PageIndex: 1, PageSize: 3},
+			},
+			wantIDs:   []int{4},
+			wantTotal: 4,
+		},
+		"with filter": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{Fields: []filter.RequestField{
+					{Name: "integerField", Operator: filter.CompareOperatorIsGreaterThan, Value: 1},
+				}},
+				Sorting: sortByInteger,
+				Paging:  &paging.Request{PageIndex: 0, PageSize: 2},
+			},
+			wantIDs:   []int{2, 3},
+			wantTotal: 3,
+		},
+		"with filter and cursor": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{Fields: []filter.RequestField{
+					{Name: "integerField", Operator: filter.CompareOperatorIsLessThan, Value: 4},
+				}},
+				Sorting: sortByInteger,
+				Paging:  &paging.Request{PageSize: 1, Cursor: cursor},
+			},
+			wantIDs:   []int{2},
+			wantTotal: 3,
+		},
+	}
+
+	db := pgtesting.NewDB(t, migrationsFS, migrationDir)
+	repo := NewTestRepository(db)
+	for _, doc := range docs {
+		require.NoError(t, repo.CreateTestDoc(&doc), "failed to create test document")
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder, err := NewPostgresQueryBuilder(Settings{
+				FilterFieldMapping:      fieldMapping,
+				SortingTieBreakerColumn: sortingTieBreakerColumn,
+			})
+			require.NoError(t, err)
+
+			conditionalQuery, args, countQuery, countArgs, err := builder.BuildWithCount(tt.resultSelector)
+			require.NoError(t, err)
+
+			var total uint64
+			fullCountQuery := `SELECT count(*) FROM test_table ` + countQuery
+			t.Logf("sending query to database: query: %v, args: %v", fullCountQuery, countArgs)
+			require.NoError(t, db.QueryRow(fullCountQuery, countArgs...).Scan(&total))
+			assert.Equal(t, tt.wantTotal, total)
+
+			fullQuery := `SELECT id FROM test_table ` + conditionalQuery
+			t.Logf("sending query to database: query: %v, args: %v", fullQuery, args)
+			rows, err := db.Query(fullQuery, args...)
+			require.NoError(t, err)
+			defer rows.Close()
+
+			var ids []int
+			for rows.Next() {
+				var id int
+				require.NoError(t, rows.Scan(&id))
+				ids = append(ids, id)
+			}
+			require.NoError(t, rows.Err())
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func Test_WithTotalCount(t *testing.T) {
+	docs := []TestDoc{
+		{ID: 1, String: "a", Integer: 1, DateTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, String: "b", Integer: 2, DateTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, String: "c", Integer: 3, DateTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	db := pgtesting.NewDB(t, migrationsFS, migrationDir)
+	repo := NewTestRepository(db)
+	for _, doc := range docs {
+		require.NoError(t, repo.CreateTestDoc(&doc), "failed to create test document")
+	}
+
+	builder, err := NewPostgresQueryBuilder(Settings{
+		FilterFieldMapping:      fieldMapping,
+		SortingTieBreakerColumn: sortingTieBreakerColumn,
+	})
+	require.NoError(t, err)
+	conditionalQuery, args, err := builder.Build(query.ResultSelector{
+		Filter: &filter.Request{Fields: []filter.RequestField{
+			{Name: "integerField", Operator: filter.CompareOperatorIsGreaterThan, Value: 1},
+		}},
+		Sorting: &sorting.Request{SortColumn: "integerField", SortDirection: sorting.DirectionDescending},
+		Paging:  &paging.Request{PageIndex: 0, PageSize: 1},
+	})
+	require.NoError(t, err)
+
+	fullQuery := `SELECT ` + WithTotalCount("id") + ` FROM test_table ` + conditionalQuery
+	t.Logf("sending query to database: query: %v, args: %v", fullQuery, args)
+	var id int
+	var total uint64
+	require.NoError(t, db.QueryRow(fullQuery, args...).Scan(&id, &total))
+	assert.Equal(t, 3, id)
+	assert.Equal(t, uint64(2), total)
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"fmt"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormScope returns a GORM scope applying the result selector to the query, with the same translation of
+// the filter, sorting and paging as [Builder.Build], including the joins of [Settings.FieldRelations] and paging
+// by cursor. It is used like
+//
+//	db.Model(&Asset{}).Scopes(builder.GormScope(resultSelector)).Find(&assets)
+//
+// If the result selector can't be translated, the error is added to the query, see [gorm.DB.AddError].
+func (qb *Builder) GormScope(resultSelector query.ResultSelector) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if err := checkSearchPaging(resultSelector); err != nil {
+			_ = db.AddError(fmt.Errorf("error adding paging query: %w", err))
+			return db
+		}
+		var cursorCondition string
+		var cursorArgs []any
+		if resultSelector.Paging != nil && resultSelector.Paging.Cursor != "" {
+			var err error
+			cursorCondition, cursorArgs, err = qb.composeCursorCondition(resultSelector.Paging.Cursor, resultSelector.Sorting)
+			if err != nil {
+				_ = db.AddError(fmt.Errorf("error adding paging query: %w", err))
+				return db
+			}
+		}
+
+		db, err := qb.applyFilter(db, resultSelector, cursorCondition, cursorArgs,
+			append(filterFieldNames(resultSelector.Filter), sortFieldNames(resultSelector.Sorting)...))
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+
+		orderBy, orderArgs, err := qb.composeOrderBy(resultSelector.Sorting, resultSelector.Search) // sorting is always applied
+		if err != nil {
+			_ = db.AddError(fmt.Errorf("error adding sort query: %w", err))
+			return db
+		}
+		db = db.Order(clause.OrderBy{Expression: clause.Expr{SQL: orderBy, Vars: orderArgs, WithoutParentheses: true}})
+
+		if resultSelector.Paging != nil {
+			pagingRequest := *resultSelector.Paging
+			if err := checkPaging(pagingRequest); err != nil {
+				_ = db.AddError(fmt.Errorf("error adding paging query: %w", err))
+				return db
+			}
+			if pagingRequest.PageIndex > 0 {
+				db = db.Offset(pagingRequest.PageIndex * pagingRequest.PageSize)
+			}
+			if pagingRequest.PageSize > 0 {
+				db = db.Limit(pagingRequest.PageSize)
+			}
+		}
+		return db
+	}
+}
+
+// GormFilterScope returns a GORM scope applying only the filter request and the free text search of the result
+// selector and the joins they need, like the count query of [Builder.BuildWithCount]. It is used to count all
+// results matching the filter:
+//
+//	db.Model(&Asset{}).Scopes(builder.GormFilterScope(resultSelector)).Count(&total)
+//
+// If the filter can't be translated, the error is added to the query, see [gorm.DB.AddError].
+func (qb *Builder) GormFilterScope(resultSelector query.ResultSelector) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db, err := qb.applyFilter(db, resultSelector, "", nil, filterFieldNames(resultSelector.Filter))
+		if err != nil {
+			_ = db.AddError(err)
+		}
+		return db
+	}
+}
+
+// applyFilter adds the joins needed by the given fields, the filter condition and the free text search condition
+// to the query.
+func (qb *Builder) applyFilter(db *gorm.DB, resultSelector query.ResultSelector, cursorCondition string,
+	cursorArgs []any, fields []string,
+) (*gorm.DB, error) {
+	extraCondition, extraArgs, err := qb.composeExtraConditions(resultSelector.Search, cursorCondition, cursorArgs)
+	if err != nil {
+		return db, fmt.Errorf("error adding search query: %w", err)
+	}
+	condition, args, err := qb.composeFilterCondition(resultSelector.Filter, extraCondition, extraArgs)
+	if err != nil {
+		return db, fmt.Errorf("error adding filter query: %w", err)
+	}
+	if joins := qb.composeJoins(fields); joins != "" {
+		db = db.Joins(joins)
+	}
+	if condition != "" {
+		db = db.Where(condition, args...)
+	}
+	return db, nil
+}
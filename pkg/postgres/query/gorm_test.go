@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greenbone/opensight-golang-libraries/internal/pgtesting"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/sorting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func Test_PostgresQueryBuilder_GormScope(t *testing.T) {
+	docs := []TestDoc{
+		{ID: 1, String: "alpha", Integer: 1, Boolean: true, DateTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, String: "beta", Integer: 2, Boolean: false, DateTime: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, String: "gamma", Integer: 3, Boolean: true, DateTime: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{ID: 4, String: "delta", Integer: 4, Boolean: true, DateTime: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)},
+	}
+	cursor, err := paging.EncodeCursor(paging.Cursor{
+		SortColumns: []string{"integerField"}, SortValues: []any{3}, TieBreakerValue: 3,
+	})
+	require.NoError(t, err)
+
+	type testCase struct {
+		resultSelector query.ResultSelector
+		wantIDs        []int
+		wantTotal      int64
+		wantErr        bool
+	}
+
+	tests := map[string]testCase{
+		"filter, sorting and paging": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{Operator: filter.LogicOperatorOr, Fields: []filter.RequestField{
+					{Name: "stringField", Operator: filter.CompareOperatorContains, Value: "ta"},
+					{Name: "integerField", Operator: filter.CompareOperatorIsEqualTo, Value: 1},
+				}},
+				Sorting: &sorting.Request{SortColumn: "integerField", SortDirection: sorting.DirectionDescending},
+				Paging:  &paging.Request{PageIndex: 1, PageSize: 2},
+			},
+			wantIDs:   []int{1},
+			wantTotal: 3,
+		},
+		"nested groups": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{
+					Operator: filter.LogicOperatorAnd,
+					Fields: []filter.RequestField{
+						{Name: "booleanField", Operator: filter.CompareOperatorIsEqualTo, Value: true},
+					},
+					Groups: []filter.Request{{
+						Operator: filter.LogicOperatorOr,
+						Fields: []filter.RequestField{
+							{Name: "integerField", Operator: filter.CompareOperatorIsLessThan, Value: 2},
+							{Name: "integerField", Operator: filter.CompareOperatorIsGreaterThan, Value: 3},
+						},
+					}},
+				},
+				Sorting: &sorting.Request{SortColumn: "idField", SortDirection: sorting.DirectionAscending},
+			},
+			wantIDs:   []int{1, 4},
+			wantTotal: 2,
+		},
+		"paging by cursor": {
+			resultSelector: query.ResultSelector{
+				Sorting: &sorting.Request{SortColumn: "integerField", SortDirection: sorting.DirectionAscending},
+				Paging:  &paging.Request{PageSize: 2, Cursor: cursor},
+			},
+			wantIDs:   []int{4},
+			wantTotal: 4,
+		},
+		"invalid filter field": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{Fields: []filter.RequestField{
+					{Name: "unknown", Operator: filter.CompareOperatorIsEqualTo, Value: 1},
+				}},
+				Sorting: &sorting.Request{SortColumn: "idField", SortDirection: sorting.DirectionAscending},
+			},
+			wantErr: true,
+		},
+	}
+
+	db := pgtesting.NewDB(t, migrationsFS, migrationDir)
+	repo := NewTestRepository(db)
+	for _, doc := range docs {
+		require.NoError(t, repo.CreateTestDoc(&doc), "failed to create test document")
+	}
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	require.NoError(t, err)
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder, err := NewPostgresQueryBuilder(Settings{
+				FilterFieldMapping:      fieldMapping,
+				SortingTieBreakerColumn: sortingTieBreakerColumn,
+			})
+			require.NoError(t, err)
+
+			var ids []int
+			err = gormDB.Table("test_table").
+				Scopes(builder.GormScope(tt.resultSelector)).
+				Pluck("id", &ids).Error
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantIDs, ids)
+
+			var total int64
+			err = gormDB.Table("test_table").
+				Scopes(builder.GormFilterScope(tt.resultSelector)).
+				Count(&total).Error
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, total)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/sorting"
+)
+
+// Relation declares how the column of a filter (or sorting) field in a related table is reached from the
+// table of the query. Exactly one of `Join` and `Exists` must be set.
+type Relation struct {
+	// Join is the join clause for a to-one relation, e.g. `LEFT JOIN appliance ON appliance.id = asset.appliance_id`.
+	// It is added to the query if the field is referenced by the filter or the sorting. Fields sharing the same
+	// join clause cause it to be added only once.
+	Join string
+	// Exists is the source of an `EXISTS` subquery for a to-many relation, consisting of the table and the
+	// condition correlating it with the table of the query, e.g. `tag WHERE tag.asset_id = asset.id`.
+	// The condition of the field is composed like `EXISTS (SELECT 1 FROM <Exists> AND (<condition>))`,
+	// so it matches if any related row matches. Negated operators and `doesNotExist` are composed like
+	// `NOT EXISTS (SELECT 1 FROM <Exists> AND (<positive condition>))`, so they match if no related row matches
+	// the positive condition, including rows without any related row.
+	// Such fields can not be used for sorting or aggregations.
+	Exists string
+}
+
+// positiveOperators maps the negated compare operators to their positive counterparts, which are used
+// inside of a `NOT EXISTS` subquery for fields of a to-many relation.
+var positiveOperators = map[filter.CompareOperator]filter.CompareOperator{
+	filter.CompareOperatorIsNotEqualTo:       filter.CompareOperatorIsEqualTo,
+	filter.CompareOperatorIsNumberNotEqualTo: filter.CompareOperatorIsNumberEqualTo,
+	filter.CompareOperatorIsStringNotEqualTo: filter.CompareOperatorIsStringEqualTo,
+	filter.CompareOperatorDoesNotContain:     filter.CompareOperatorContains,
+	filter.CompareOperatorDoesNotBeginWith:   filter.CompareOperatorBeginsWith,
+	filter.CompareOperatorIsIpNotEqualTo:     filter.CompareOperatorIsIpEqualTo,
+	filter.CompareOperatorDoesNotExist:       filter.CompareOperatorExists,
+	filter.CompareOperatorIsNotEqualToRating: filter.CompareOperatorIsEqualToRating,
+}
+
+// relationOf returns the relation of the field, if there is any.
+func (qb *Builder) relationOf(field string) (Relation, bool) {
+	relation, ok := qb.querySettings.FieldRelations[field]
+	return relation, ok
+}
+
+// checkNoExistsRelation returns an error if the field is mapped to a column of a to-many relation,
+// which can't be used outside of filter conditions.
+func (qb *Builder) checkNoExistsRelation(field string) error {
+	if relation, ok := qb.relationOf(field); ok && relation.Exists != "" {
+		return filter.NewInvalidFilterFieldError(
+			"field '%s' of a to-many relation can only be used in filter conditions", field)
+	}
+	return nil
+}
+
+// isExistsField returns true if the field is mapped to a column of a to-many relation.
+func (qb *Builder) isExistsField(field string) bool {
+	relation, ok := qb.relationOf(field)
+	return ok && relation.Exists != ""
+}
+
+// existsOperator returns the operator to compose the condition of the field with. For a negated operator on a
+// field of a to-many relation it returns the positive operator and true, as the negation has to be applied to
+// the `EXISTS` subquery instead of the condition inside of it.
+func (qb *Builder) existsOperator(field filter.RequestField) (operator filter.CompareOperator, negate bool) {
+	if positive, ok := positiveOperators[field.Operator]; ok && qb.isExistsField(field.Name) {
+		return positive, true
+	}
+	return field.Operator, false
+}
+
+// wrapExistsCondition wraps the condition of the field into an `EXISTS` subquery, or into a `NOT EXISTS`
+// subquery if negate is set, if the field is mapped to a column of a to-many relation.
+func (qb *Builder) wrapExistsCondition(field string, condition string, negate bool) string {
+	relation, ok := qb.relationOf(field)
+	if !ok || relation.Exists == "" {
+		return condition
+	}
+	if negate {
+		return fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s AND (%s))", relation.Exists, condition)
+	}
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM %s AND (%s))", relation.Exists, condition)
+}
+
+// composeJoins returns the join clauses needed by the given fields, each followed by a space, so that they can
+// be put in front of the conditional query. The clauses are deduplicated and kept in the order of their first
+// reference, as later joins may depend on earlier ones.
+func (qb *Builder) composeJoins(fields []string) string {
+	var joins []string
+	for _, field := range fields {
+		relation, ok := qb.relationOf(field)
+		if !ok || relation.Join == "" || slices.Contains(joins, relation.Join) {
+			continue
+		}
+		joins = append(joins, relation.Join)
+	}
+	if len(joins) == 0 {
+		return ""
+	}
+	return strings.Join(joins, " ") + " "
+}
+
+// filterFieldNames returns the names of all fields of the filter request, including the ones of nested groups.
+func filterFieldNames(request *filter.Request) []string {
+	if request == nil {
+		return nil
+	}
+	var names []string
+	for _, field := range request.Fields {
+		names = append(names, field.Name)
+	}
+	for i := range request.Groups {
+		names = append(names, filterFieldNames(&request.Groups[i])...)
+	}
+	return names
+}
+
+// sortFieldNames returns the names of the sort columns of the sorting request.
+func sortFieldNames(request *sorting.Request) []string {
+	var names []string
+	for _, key := range request.SortKeys() {
+		names = append(names, key.Column)
+	}
+	return names
+}
+
+// validateRelations checks that each relation declares exactly one kind of relation.
+func validateRelations(relations map[string]Relation) error {
+	for field, relation := range relations {
+		if (relation.Join == "") == (relation.Exists == "") {
+			return fmt.Errorf("relation of field '%s' must declare either a join or an exists subquery", field)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenbone/opensight-golang-libraries/internal/pgtesting"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/sorting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDetailJoin = `LEFT JOIN test_detail ON test_detail.test_id = test_table.id`
+
+func Test_PostgresQueryBuilder_FieldRelations(t *testing.T) {
+	docs := []TestDoc{
+		{ID: 1, String: "a", DateTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, String: "b", DateTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, String: "c", DateTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 4, String: "d", DateTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, // without details and tags
+	}
+	relatedRows := []string{
+		`INSERT INTO test_detail (test_id, label, rank) VALUES (1, 'server', 2), (2, 'client', 1), (3, 'server', 3)`,
+		`INSERT INTO test_tag (test_id, tag) VALUES (1, 'prod'), (1, 'web'), (2, 'dev'), (3, 'web')`,
+	}
+
+	relationMapping := maps.Clone(fieldMapping)
+	relationMapping["detailLabelField"] = "test_detail.label"
+	relationMapping["detailRankField"] = "test_detail.rank"
+	relationMapping["tagField"] = "test_tag.tag"
+	relations := map[string]Relation{
+		"detailLabelField": {Join: testDetailJoin},
+		"detailRankField":  {Join: testDetailJoin},
+		"tagField":         {Exists: `test_tag WHERE test_tag.test_id = test_table.id`},
+	}
+
+	type testCase struct {
+		resultSelector query.ResultSelector
+		wantIDs        []int
+		wantJoins      int
+		wantErr        bool
+	}
+
+	tests := map[string]testCase{
+		"no related field": {
+			resultSelector: query.ResultSelector{
+				Sorting: &sorting.Request{SortColumn: "stringField", SortDirection: sorting.DirectionDescending},
+			},
+			wantIDs:   []int{4, 3, 2, 1},
+			wantJoins: 0,
+		},
+		"filter by joined field": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{Fields: []filter.RequestField{
+					{Name: "detailLabelField", Operator: filter.CompareOperatorIsEqualTo, Value: "server"},
+				}},
+				Sorting: &sorting.Request{SortColumn: "idField", SortDirection: sorting.DirectionAscending},
+			},
+			wantIDs:   []int{1, 3},
+			wantJoins: 1,
+		},
+		"filter and sort by fields of the same join": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{Fields: []filter.RequestField{
+					{Name: "detailLabelField", Operator: filter.CompareOperatorIsEqualTo, Value: "server"},
+				}},
+				Sorting: &sorting.Request{SortColumn: "detailRankField", SortDirection: sorting.DirectionDescending},
+			},
+			wantIDs:   []int{3, 1},
+			wantJoins: 1,
+		},
+		"filter by to-many relation": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{Operator: filter.LogicOperatorAnd, Fields: []filter.RequestField{
+					{Name: "tagField", Operator: filter.CompareOperatorIsEqualTo, Value: "web"},
+					{Name: "tagField", Operator: filter.CompareOperatorIsEqualTo, Value: "prod"},
+				}},
+				Sorting: &sorting.Request{SortColumn: "idField", SortDirection: sorting.DirectionAscending},
+			},
+			wantIDs:   []int{1},
+			wantJoins: 0,
+		},
+		"filter by missing to-many relation": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{Fields: []filter.RequestField{
+					{Name: "tagField", Operator: filter.CompareOperatorDoesNotExist},
+				}},
+				Sorting: &sorting.Request{SortColumn: "idField", SortDirection: sorting.DirectionAscending},
+			},
+			wantIDs:   []int{4},
+			wantJoins: 0,
+		},
+		"filter by negated to-many relation": {
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{Fields: []filter.RequestField{
+					{Name: "tagField", Operator: filter.CompareOperatorIsNotEqualTo, Value: "web"},
+				}},
+				Sorting: &sorting.Request{SortColumn: "idField", SortDirection: sorting.DirectionAscending},
+			},
+			wantIDs:   []int{2, 4},
+			wantJoins: 0,
+		},
+		"sort by to-many relation": {
+			resultSelector: query.ResultSelector{
+				Sorting: &sorting.Request{SortColumn: "tagField", SortDirection: sorting.DirectionAscending},
+			},
+			wantErr: true,
+		},
+	}
+
+	db := pgtesting.NewDB(t, migrationsFS, migrationDir)
+	repo := NewTestRepository(db)
+	for _, doc := range docs {
+		require.NoError(t, repo.CreateTestDoc(&doc), "failed to create test document")
+	}
+	for _, statement := range relatedRows {
+		_, err := db.Exec(statement)
+		require.NoError(t, err)
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder, err := NewPostgresQueryBuilder(Settings{
+				FilterFieldMapping:      relationMapping,
+				SortingTieBreakerColumn: "test_table.id",
+				FieldRelations:          relations,
+			})
+			require.NoError(t, err)
+
+			conditionalQuery, args, err := builder.Build(tt.resultSelector)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantJoins, strings.Count(conditionalQuery, testDetailJoin))
+
+			fullQuery := `SELECT test_table.id FROM test_table ` + conditionalQuery
+			t.Logf("sending query to database: query: %v, args: %v", fullQuery, args)
+			rows, err := db.Query(fullQuery, args...)
+			require.NoError(t, err)
+			defer rows.Close()
+
+			var ids []int
+			for rows.Next() {
+				var id int
+				require.NoError(t, rows.Scan(&id))
+				ids = append(ids, id)
+			}
+			require.NoError(t, rows.Err())
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func Test_NewPostgresQueryBuilder_InvalidRelation(t *testing.T) {
+	_, err := NewPostgresQueryBuilder(Settings{
+		FilterFieldMapping:      fieldMapping,
+		SortingTieBreakerColumn: sortingTieBreakerColumn,
+		FieldRelations: map[string]Relation{
+			"stringField": {Join: testDetailJoin, Exists: "test_tag WHERE test_tag.test_id = test_table.id"},
+		},
+	})
+	assert.Error(t, err)
+}
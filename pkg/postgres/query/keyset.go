@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// composeKeysetCondition builds a condition matching all rows which follow the row with the given values
+// in the sort order defined by `columns` and the (ascending) tie breaker column.
+// The tie breaker value is expected to be unique and never NULL.
+//
+// If all columns are sorted ascending with NULL values last and none of the values is NULL, a row comparison
+// of the form `(col1, col2, tieBreaker) > (?, ?, ?)` is used, which can make use of a matching index.
+// Otherwise the condition is expanded to the form `(col1 > ?) OR (col1 = ? AND col2 > ?) OR ...`.
+func composeKeysetCondition(columns []sortColumn, values []any, tieBreaker string, tieBreakerValue any) (string, []any) {
+	if len(columns) == 0 {
+		return fmt.Sprintf("%s > ?", tieBreaker), []any{tieBreakerValue}
+	}
+
+	useRowComparison := true
+	for i, column := range columns {
+		if !column.ascending || column.nullsFirst() || values[i] == nil {
+			useRowComparison = false
+		}
+	}
+	if useRowComparison {
+		return composeRowComparisonCondition(columns, values, tieBreaker, tieBreakerValue)
+	}
+	return composeExpandedKeysetCondition(columns, values, tieBreaker, tieBreakerValue)
+}
+
+// composeRowComparisonCondition builds a keyset condition for columns sorted ascending with NULL values last
+// and non-NULL values. As a row comparison doesn't match NULL values, they are matched by additional terms.
+func composeRowComparisonCondition(columns []sortColumn, values []any, tieBreaker string, tieBreakerValue any) (string, []any) {
+	names := make([]string, 0, len(columns)+1)
+	for _, column := range columns {
+		names = append(names, column.name)
+	}
+	names = append(names, tieBreaker)
+	terms := []string{fmt.Sprintf("(%s) > (%s)",
+		strings.Join(names, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(names)), ", "))}
+	args := append(append([]any{}, values...), tieBreakerValue)
+
+	// rows with same values in the preceding columns and NULL in the current column follow
+	var prefix []string
+	var prefixArgs []any
+	for i, column := range columns {
+		terms = append(terms, strings.Join(append(prefix, fmt.Sprintf("%s IS NULL", column.name)), " AND "))
+		args = append(args, prefixArgs...)
+		prefix = append(prefix, fmt.Sprintf("%s = ?", column.name))
+		prefixArgs = append(prefixArgs, values[i])
+	}
+	return joinKeysetTerms(terms), args
+}
+
+// composeExpandedKeysetCondition builds a keyset condition for arbitrary sort directions and NULL positions.
+// For each column a term is added, which matches rows with the same values in the preceding columns
+// and a following value in the current column.
+func composeExpandedKeysetCondition(columns []sortColumn, values []any, tieBreaker string, tieBreakerValue any) (string, []any) {
+	var terms []string
+	var args []any
+	var prefix []string
+	var prefixArgs []any
+	for i, column := range columns {
+		value := values[i]
+		var following string
+		var followingArgs []any
+		switch {
+		case value == nil && column.nullsFirst():
+			following = fmt.Sprintf("%s IS NOT NULL", column.name)
+		case value == nil:
+			// no value follows NULL if NULL values are sorted last
+		default:
+			operator := "<"
+			if column.ascending {
+				operator = ">"
+			}
+			following = fmt.Sprintf("%s %s ?", column.name, operator)
+			if !column.nullsFirst() {
+				following = fmt.Sprintf("(%s OR %s IS NULL)", following, column.name)
+			}
+			followingArgs = []any{value}
+		}
+		if following != "" {
+			terms = append(terms, strings.Join(append(prefix, following), " AND "))
+			args = append(append(args, prefixArgs...), followingArgs...)
+		}
+
+		if value == nil {
+			prefix = append(prefix, fmt.Sprintf("%s IS NULL", column.name))
+		} else {
+			prefix = append(prefix, fmt.Sprintf("%s = ?", column.name))
+			prefixArgs = append(prefixArgs, value)
+		}
+	}
+	terms = append(terms, strings.Join(append(prefix, fmt.Sprintf("%s > ?", tieBreaker)), " AND "))
+	args = append(append(args, prefixArgs...), tieBreakerValue)
+
+	return joinKeysetTerms(terms), args
+}
+
+func joinKeysetTerms(terms []string) string {
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return "(" + strings.Join(terms, ") OR (") + ")"
+}
@@ -7,6 +7,7 @@ package query
 import (
 	"errors"
 	"fmt"
+	"net/netip"
 	"reflect"
 	"strings"
 	"time"
@@ -81,6 +82,12 @@ func buildComparisonStatement(field filter.RequestField, negate bool, operator s
 }
 
 func chainStatementsByOr(negate bool, singleStatement string, count int) string {
+	return chainStatements(negate, singleStatement, count, "OR")
+}
+
+// chainStatements repeats the statement `count` times, chained with the given logic operator:
+// [NOT] ((statement) operator (statement) ...)
+func chainStatements(negate bool, singleStatement string, count int, logicOperator string) string {
 	builder := strings.Builder{}
 
 	if negate {
@@ -89,7 +96,7 @@ func chainStatementsByOr(negate bool, singleStatement string, count int) string
 	builder.WriteRune('(')
 	for i := range count {
 		if i > 0 {
-			builder.WriteString(" OR ")
+			builder.WriteString(" " + logicOperator + " ")
 		}
 		builder.WriteRune('(')
 		builder.WriteString(singleStatement)
@@ -107,23 +114,10 @@ func sanitizeFilterValue(value any) (sanitizedValue any, err error) {
 
 	sanitizedValue = value
 
-	if reflect.TypeOf(value).Kind() == reflect.Slice ||
-		reflect.TypeOf(value).Kind() == reflect.Array {
-		slice := reflect.ValueOf(value)
-
-		if slice.Len() == 0 { // disallow empty list values, as the there is no clear way to interpret this kind of filter
+	if values, ok := filter.ListValues(value); ok {
+		if len(values) == 0 { // disallow empty list values, as the there is no clear way to interpret this kind of filter
 			return filter.RequestField{}, fmt.Errorf("field has empty list of values")
 		}
-		// convert to []any, so that handlers don't need to deal with different slice types
-		var values []any
-		if v, ok := value.([]any); ok {
-			values = v
-		} else {
-			values = make([]any, slice.Len())
-			for i := 0; i < slice.Len(); i++ {
-				values[i] = slice.Index(i).Interface()
-			}
-		}
 		sanitizedValue = values
 	}
 	return sanitizedValue, nil
@@ -165,3 +159,166 @@ func buildDateTruncStatement(field filter.RequestField, operator string) (string
 
 	return chainStatementsByOr(false, singleStatement, count), nil
 }
+
+// textSearchConfig is the text search configuration used for full text search. It does neither apply
+// stemming nor remove stop words, which resembles the standard analyzer of OpenSearch.
+const textSearchConfig = "simple"
+
+// checkValues calls check for the single value or for each element of a value list.
+// It returns the number of values.
+func checkValues(value any, check func(value any) error) (count int, err error) {
+	if valueList, ok := value.([]any); ok {
+		for _, element := range valueList {
+			if err := check(element); err != nil {
+				return 0, err
+			}
+		}
+		return len(valueList), nil
+	}
+	if err := check(value); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+// buildNumberComparisonStatement is same as buildComparisonStatementSimple, but with additional
+// validation that the input value(s) are numbers
+func buildNumberComparisonStatement(field filter.RequestField, negate bool, operator string) (string, error) {
+	_, err := checkValues(field.Value, func(value any) error {
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return nil
+		default:
+			return fmt.Errorf("operator '%s' requires number values, got %T", field.Operator, value)
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return buildComparisonStatement(field, negate, operator, "?")
+}
+
+// buildTextSearchStatement builds a full text search SQL filter statement of the form:
+// ((to_tsvector('simple', field) @@ plainto_tsquery('simple', ?)) OR ...)
+// All words of a single value need to be present in the field, in any order.
+func buildTextSearchStatement(field filter.RequestField) (string, error) {
+	count, err := checkValues(field.Value, func(value any) error {
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("operator '%s' requires string values, got %T", field.Operator, value)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	singleStatement := fmt.Sprintf("to_tsvector('%[1]s', %[2]s) @@ plainto_tsquery('%[1]s', ?)",
+		textSearchConfig, field.Name)
+
+	return chainStatementsByOr(false, singleStatement, count), nil
+}
+
+// buildIpComparisonStatement builds a SQL filter statement of the form:
+// [NOT] ((field::inet <<= ?::inet) OR ...)
+// A value can either be a single IP address or a network in CIDR notation, as string or as [netip.Addr]
+// and [netip.Prefix] respectively. For a network all addresses within it are matched.
+func buildIpComparisonStatement(field filter.RequestField, negate bool) (string, error) {
+	count, err := checkValues(field.Value, func(value any) error {
+		switch value.(type) {
+		case netip.Prefix, netip.Addr:
+			return nil
+		}
+		strValue, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("operator '%s' requires string values, got %T", field.Operator, value)
+		}
+		if _, err := netip.ParseAddr(strValue); err == nil {
+			return nil
+		}
+		if _, err := netip.ParsePrefix(strValue); err != nil {
+			return fmt.Errorf("operator '%s' requires IP addresses or networks in CIDR notation, got '%s'",
+				field.Operator, strValue)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	singleStatement := fmt.Sprintf("%s::inet <<= ?::inet", field.Name)
+
+	return chainStatementsByOr(negate, singleStatement, count), nil
+}
+
+// ipArgs converts [netip.Prefix] and [netip.Addr] values to their string representation,
+// which can be cast to `inet`.
+func ipArgs(args []any) []any {
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case netip.Prefix:
+			args[i] = v.String()
+		case netip.Addr:
+			args[i] = v.String()
+		}
+	}
+	return args
+}
+
+// buildBetweenDatesStatement builds a SQL filter statement of the form:
+// ((field >= ? AND field <= ?))
+// The value must be a list of exactly two dates, the start and the end of the range. Both bounds are inclusive.
+// Accepted are time.Time values and RFC3339Nano formatted strings.
+func buildBetweenDatesStatement(field filter.RequestField) (string, error) {
+	valueList, ok := field.Value.([]any)
+	if !ok || len(valueList) != 2 {
+		return "", fmt.Errorf("operator '%s' requires a list of two dates, got: %v", field.Operator, field.Value)
+	}
+	_, err := checkValues(valueList, func(value any) error {
+		switch v := value.(type) {
+		case time.Time:
+			return nil
+		case string:
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				return fmt.Errorf("invalid date string format: %w", err)
+			}
+			return nil
+		default:
+			return fmt.Errorf("operator '%s' requires a string or time.Time value, got: %T", field.Operator, value)
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+	singleStatement := fmt.Sprintf("%[1]s >= ? AND %[1]s <= ?", field.Name)
+
+	return chainStatementsByOr(false, singleStatement, 1), nil
+}
+
+// buildRatingStatement builds a SQL filter statement of the form:
+// [NOT] ((statement) OR (statement) OR ...)
+// Each value is the name of a rating, which is translated to its numeric range using `ratings`.
+// `bounds` selects the bounds of the range, which are passed as arguments for the placeholders in `statement`.
+// `statement` must contain a `%s` verb for the field name.
+func buildRatingStatement(field filter.RequestField, ratings map[string]filter.RatingRange, negate bool,
+	statement string, bounds func(filter.RatingRange) []any,
+) (string, []any, error) {
+	var args []any
+	count, err := checkValues(field.Value, func(value any) error {
+		rating, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("operator '%s' requires string values, got %T", field.Operator, value)
+		}
+		ratingRange, ok := ratings[rating]
+		if !ok {
+			return fmt.Errorf("unknown rating '%s'", rating)
+		}
+		args = append(args, bounds(ratingRange)...)
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return chainStatementsByOr(negate, fmt.Sprintf(statement, field.Name), count), args, nil
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+)
+
+// searchVector returns the expression combining the search columns into a single `tsvector`.
+func (qb *Builder) searchVector() string {
+	columns := qb.querySettings.SearchColumns
+	if len(columns) == 1 {
+		return columns[0]
+	}
+	vectors := make([]string, len(columns))
+	for i, column := range columns {
+		vectors[i] = fmt.Sprintf("coalesce(%s, ''::tsvector)", column)
+	}
+	return "(" + strings.Join(vectors, " || ") + ")"
+}
+
+// composeSearchCondition translates the free text search into a condition matching the search columns with
+// `websearch_to_tsquery`. Without search text the condition is empty.
+func (qb *Builder) composeSearchCondition(search *query.Search) (condition string, args []any, err error) {
+	if search.IsEmpty() {
+		return "", nil, nil
+	}
+	if len(qb.querySettings.SearchColumns) == 0 {
+		return "", nil, errors.New("free text search is not supported, no search columns are configured")
+	}
+	condition = fmt.Sprintf("%s @@ websearch_to_tsquery('%s', ?)", qb.searchVector(), textSearchConfig)
+	return condition, []any{search.Text}, nil
+}
+
+// composeRankOrder returns the sort expression ordering the results by their rank for the search text, if
+// requested by the search. Otherwise, the expression is empty.
+func (qb *Builder) composeRankOrder(search *query.Search) (orderBy string, args []any) {
+	if search.IsEmpty() || !search.SortByRank || len(qb.querySettings.SearchColumns) == 0 {
+		return "", nil
+	}
+	orderBy = fmt.Sprintf("ts_rank(%s, websearch_to_tsquery('%s', ?)) DESC", qb.searchVector(), textSearchConfig)
+	return orderBy, []any{search.Text}
+}
+
+// checkSearchPaging returns an error if the results are sorted by rank and paged by cursor, as the rank
+// is not part of the cursor.
+func checkSearchPaging(resultSelector query.ResultSelector) error {
+	if !resultSelector.Search.IsEmpty() && resultSelector.Search.SortByRank &&
+		resultSelector.Paging != nil && resultSelector.Paging.Cursor != "" {
+		return errors.New("paging by cursor is not supported when sorting by rank")
+	}
+	return nil
+}
+
+// composeExtraConditions composes the conditions added to the filter conditions: the free text search
+// and a cursor condition. They are chained with AND.
+func (qb *Builder) composeExtraConditions(search *query.Search, cursorCondition string, cursorArgs []any) (
+	condition string, args []any, err error,
+) {
+	searchCondition, searchArgs, err := qb.composeSearchCondition(search)
+	if err != nil {
+		return "", nil, err
+	}
+	switch {
+	case searchCondition == "":
+		return cursorCondition, cursorArgs, nil
+	case cursorCondition == "":
+		return searchCondition, searchArgs, nil
+	default:
+		return "(" + searchCondition + ") AND (" + cursorCondition + ")", append(searchArgs, cursorArgs...), nil
+	}
+}
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"testing"
+
+	"github.com/greenbone/opensight-golang-libraries/internal/pgtesting"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/sorting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PostgresQueryBuilder_Search(t *testing.T) {
+	rows := `INSERT INTO test_search (id, title, body) VALUES
+		(1, 'web server', 'nginx serving the web shop'),
+		(2, 'database', 'postgres for the web shop'),
+		(3, 'mail server', 'postfix'),
+		(4, 'web proxy', NULL)`
+
+	searchFieldMapping := map[string]string{
+		"id":    "id",
+		"title": "title",
+	}
+	sortByID := &sorting.Request{SortColumn: "id", SortDirection: sorting.DirectionAscending}
+
+	type testCase struct {
+		searchColumns  []string
+		resultSelector query.ResultSelector
+		wantIDs        []int
+		wantErr        bool
+	}
+
+	tests := map[string]testCase{
+		"single word": {
+			searchColumns: []string{"title_vector", "body_vector"},
+			resultSelector: query.ResultSelector{
+				Search:  &query.Search{Text: "web"},
+				Sorting: sortByID,
+			},
+			wantIDs: []int{1, 2, 4},
+		},
+		"all words must match": {
+			searchColumns: []string{"title_vector", "body_vector"},
+			resultSelector: query.ResultSelector{
+				Search:  &query.Search{Text: "web shop"},
+				Sorting: sortByID,
+			},
+			wantIDs: []int{1, 2},
+		},
+		"phrase and negation": {
+			searchColumns: []string{"title_vector", "body_vector"},
+			resultSelector: query.ResultSelector{
+				Search:  &query.Search{Text: `"web shop" -postgres`},
+				Sorting: sortByID,
+			},
+			wantIDs: []int{1},
+		},
+		"single search column": {
+			searchColumns: []string{"title_vector"},
+			resultSelector: query.ResultSelector{
+				Search:  &query.Search{Text: "web"},
+				Sorting: sortByID,
+			},
+			wantIDs: []int{1, 4},
+		},
+		"combined with filter": {
+			searchColumns: []string{"title_vector", "body_vector"},
+			resultSelector: query.ResultSelector{
+				Filter: &filter.Request{Fields: []filter.RequestField{
+					{Name: "title", Operator: filter.CompareOperatorContains, Value: "server"},
+				}},
+				Search:  &query.Search{Text: "web"},
+				Sorting: sortByID,
+			},
+			wantIDs: []int{1},
+		},
+		"sorted by rank": {
+			searchColumns: []string{"title_vector", "body_vector"},
+			resultSelector: query.ResultSelector{
+				Search:  &query.Search{Text: "web", SortByRank: true},
+				Sorting: &sorting.Request{SortColumn: "id", SortDirection: sorting.DirectionDescending},
+			},
+			wantIDs: []int{1, 4, 2}, // equal ranks are ordered by the sorting request
+		},
+		"empty search text": {
+			resultSelector: query.ResultSelector{
+				Search:  &query.Search{Text: "  "},
+				Sorting: sortByID,
+			},
+			wantIDs: []int{1, 2, 3, 4},
+		},
+		"without search columns": {
+			resultSelector: query.ResultSelector{
+				Search:  &query.Search{Text: "web"},
+				Sorting: sortByID,
+			},
+			wantErr: true,
+		},
+		"sorted by rank with cursor": {
+			searchColumns: []string{"title_vector", "body_vector"},
+			resultSelector: query.ResultSelector{
+				Search:  &query.Search{Text: "web", SortByRank: true},
+				Sorting: sortByID,
+				Paging:  &paging.Request{PageSize: 2, Cursor: "eyJ0Ijo0fQ"},
+			},
+			wantErr: true,
+		},
+	}
+
+	db := pgtesting.NewDB(t, migrationsFS, migrationDir)
+	_, err := db.Exec(rows)
+	require.NoError(t, err)
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder, err := NewPostgresQueryBuilder(Settings{
+				FilterFieldMapping:      searchFieldMapping,
+				SortingTieBreakerColumn: "id",
+				SearchColumns:           tt.searchColumns,
+			})
+			require.NoError(t, err)
+
+			conditionalQuery, args, err := builder.Build(tt.resultSelector)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			fullQuery := `SELECT id FROM test_search ` + conditionalQuery
+			t.Logf("sending query to database: query: %v, args: %v", fullQuery, args)
+			rows, err := db.Query(fullQuery, args...)
+			require.NoError(t, err)
+			defer rows.Close()
+
+			var ids []int
+			for rows.Next() {
+				var id int
+				require.NoError(t, rows.Scan(&id))
+				ids = append(ids, id)
+			}
+			require.NoError(t, rows.Err())
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/suggestion"
+)
+
+// BuildSuggestion generates the postgres SQL query parts to retrieve value suggestions for the field
+// of the suggestion request. The column of the field is resolved via the filter field mapping.
+//
+// `selectList` contains the expression for the SELECT clause: the distinct values of the column as text.
+//
+// `query` contains the joins needed by the fields, the WHERE clause of the filter of the request combined with
+// a case-insensitive match of the prefix, the ORDER BY clause ordering the values ascending and the LIMIT clause
+// of the requested size.
+//
+// The full query is composed like `"SELECT " + selectList + " FROM table " + query`, the result can be read
+// with [ScanSuggestionRows]. The request is expected to be validated with [suggestion.ValidateSuggestionRequest].
+func (qb *Builder) BuildSuggestion(request suggestion.Request) (selectList string, query string, args []any, err error) {
+	column, err := qb.quotedColumnOf(request.Field)
+	if err != nil {
+		return "", "", nil, err
+	}
+	column += "::text"
+
+	prefixCondition := column + ` ILIKE ? || '%'`
+	var statement strings.Builder
+	args, err = qb.addFilters(&statement, request.Filter, prefixCondition, []any{likeReplacer.Replace(request.Prefix)})
+	if err != nil {
+		return "", "", nil, fmt.Errorf("error adding filter query: %w", err)
+	}
+	fmt.Fprintf(&statement, " ORDER BY 1 LIMIT %d", request.EffectiveSize())
+
+	query = qb.composeJoins(append(filterFieldNames(request.Filter), request.Field)) + statement.String()
+	return "DISTINCT " + column + ` AS "value"`, rebind(query), args, nil
+}
+
+// ScanSuggestionRows reads the result of a query generated with [Builder.BuildSuggestion].
+func ScanSuggestionRows(rows *sql.Rows) (*suggestion.Response, error) {
+	response := &suggestion.Response{Values: []string{}}
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		response.Values = append(response.Values, value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get rows: %w", err)
+	}
+	return response, nil
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"testing"
+
+	"github.com/greenbone/opensight-golang-libraries/internal/pgtesting"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/suggestion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PostgresQueryBuilder_BuildSuggestion(t *testing.T) {
+	docs := []TestDoc{
+		{ID: 1, String: "Alpha", Integer: 1},
+		{ID: 2, String: "alpine", Integer: 2},
+		{ID: 3, String: "alpine", Integer: 3},
+		{ID: 4, String: "beta", Integer: 4},
+		{ID: 5, String: "10%", Integer: 5},
+		{ID: 6, String: "100", Integer: 6},
+	}
+
+	type testCase struct {
+		request    suggestion.Request
+		wantValues []string
+		wantErr    bool
+	}
+
+	tests := map[string]testCase{
+		"prefix is case-insensitive": {
+			request:    suggestion.Request{Field: "stringField", Prefix: "ALP"},
+			wantValues: []string{"Alpha", "alpine"},
+		},
+		"empty prefix": {
+			request:    suggestion.Request{Field: "stringField"},
+			wantValues: []string{"10%", "100", "Alpha", "alpine", "beta"},
+		},
+		"wildcards in prefix are escaped": {
+			request:    suggestion.Request{Field: "stringField", Prefix: "10%"},
+			wantValues: []string{"10%"},
+		},
+		"size": {
+			request:    suggestion.Request{Field: "stringField", Prefix: "al", Size: 1},
+			wantValues: []string{"Alpha"},
+		},
+		"with filter": {
+			request: suggestion.Request{
+				Field:  "stringField",
+				Prefix: "al",
+				Filter: &filter.Request{
+					Operator: filter.LogicOperatorAnd,
+					Fields:   []filter.RequestField{{Name: "integerField", Operator: filter.CompareOperatorIsLessThan, Value: 3}},
+				},
+			},
+			wantValues: []string{"Alpha", "alpine"},
+		},
+		"no match": {
+			request:    suggestion.Request{Field: "stringField", Prefix: "x"},
+			wantValues: []string{},
+		},
+		"unknown field": {
+			request: suggestion.Request{Field: "unknown"},
+			wantErr: true,
+		},
+	}
+
+	db := pgtesting.NewDB(t, migrationsFS, migrationDir)
+	repo := NewTestRepository(db)
+	for _, doc := range docs {
+		require.NoError(t, repo.CreateTestDoc(&doc), "failed to create test document")
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder, err := NewPostgresQueryBuilder(Settings{
+				FilterFieldMapping:      fieldMapping,
+				SortingTieBreakerColumn: sortingTieBreakerColumn,
+			})
+			require.NoError(t, err)
+
+			selectList, conditionalQuery, args, err := builder.BuildSuggestion(tt.request)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			fullQuery := `SELECT ` + selectList + ` FROM test_table ` + conditionalQuery
+			t.Logf("sending query to database: query: %v, args: %v", fullQuery, args)
+			rows, err := db.Query(fullQuery, args...)
+			require.NoError(t, err)
+			defer rows.Close()
+
+			response, err := ScanSuggestionRows(rows)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantValues, response.Values)
+		})
+	}
+}
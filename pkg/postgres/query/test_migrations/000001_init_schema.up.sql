@@ -7,5 +7,34 @@ CREATE TABLE test_table (
     "integer" INT,
     "float" FLOAT4,
     "boolean" BOOLEAN,
-    "date_time" TIMESTAMPTZ
+    "date_time" TIMESTAMPTZ,
+    "ip" INET
+);
+
+-- related tables for filtering on joined columns
+CREATE TABLE test_detail (
+    "test_id" INT PRIMARY KEY REFERENCES test_table ("id"),
+    "label" TEXT,
+    "rank" INT
+);
+
+CREATE TABLE test_tag (
+    "test_id" INT REFERENCES test_table ("id"),
+    "tag" TEXT
+);
+
+-- table with collection columns
+CREATE TABLE test_collection (
+    "id" INT PRIMARY KEY,
+    "labels" JSONB,
+    "tags" TEXT[]
+);
+
+-- table with text search columns
+CREATE TABLE test_search (
+    "id" INT PRIMARY KEY,
+    "title" TEXT,
+    "body" TEXT,
+    "title_vector" TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', coalesce("title", ''))) STORED,
+    "body_vector" TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', coalesce("body", ''))) STORED
 );
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package aggregation contains the model to request aggregated values, e.g. the number of results per severity,
+// instead of a list of results.
+package aggregation
+
+import "github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+
+// Request represents an aggregation request. The results are grouped into buckets by the distinct value
+// combinations of the group-by fields. For each bucket the number of results and the requested metrics are returned.
+//
+// Fields:
+// - GroupBy: ordered list of fields to group by
+// - Metrics: metrics to calculate for each bucket
+type Request struct {
+	GroupBy []GroupBy `json:"groupBy" binding:"required,dive"`
+	Metrics []Metric  `json:"metrics,omitempty" binding:"omitempty,dive"`
+}
+
+// GroupBy represents a field to group the results by.
+//
+// Fields:
+// - Field: the field to group by
+// - DateHistogram: optional, groups the values of a date field into intervals instead of distinct values
+type GroupBy struct {
+	Field         string         `json:"field" binding:"required"`
+	DateHistogram *DateHistogram `json:"dateHistogram,omitempty"`
+}
+
+// DateHistogram groups the values of a date field into calendar intervals. The key of a bucket is the start
+// of its interval.
+type DateHistogram struct {
+	Interval CalendarInterval `json:"interval" binding:"required"`
+}
+
+// CalendarInterval is the length of the intervals of a date histogram.
+type CalendarInterval string
+
+const (
+	IntervalMinute  CalendarInterval = "minute"
+	IntervalHour    CalendarInterval = "hour"
+	IntervalDay     CalendarInterval = "day"
+	IntervalWeek    CalendarInterval = "week"
+	IntervalMonth   CalendarInterval = "month"
+	IntervalQuarter CalendarInterval = "quarter"
+	IntervalYear    CalendarInterval = "year"
+)
+
+// IsValid returns true if the calendar interval is one of the defined values.
+func (i CalendarInterval) IsValid() bool {
+	switch i {
+	case IntervalMinute, IntervalHour, IntervalDay, IntervalWeek, IntervalMonth, IntervalQuarter, IntervalYear:
+		return true
+	default:
+		return false
+	}
+}
+
+// Metric represents a metric calculated from the values of a field for each bucket.
+//
+// Fields:
+// - Field: the field to calculate the metric from
+// - Metric: the kind of metric, e.g. avg
+type Metric struct {
+	Field  string                 `json:"field" binding:"required"`
+	Metric filter.AggregateMetric `json:"metric" binding:"required"`
+}
+
+// Key returns the key of the metric within a bucket, e.g. `avg(severity)`.
+func (m Metric) Key() string {
+	return string(m.Metric) + "(" + m.Field + ")"
+}
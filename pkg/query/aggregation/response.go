@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package aggregation
+
+// Response contains the buckets resulting from an aggregation request, ordered by their keys.
+type Response struct {
+	Buckets []Bucket `json:"buckets" binding:"required"`
+}
+
+// Bucket represents a group of results with the same values of the group-by fields.
+//
+// Fields:
+// - Keys: the values of the group-by fields by field name, for a date histogram the start of the interval
+// - Count: the number of results in the bucket
+// - Metrics: the values of the requested metrics by [Metric.Key], nil if the bucket has no values for the field
+type Bucket struct {
+	Keys    map[string]any      `json:"keys"`
+	Count   uint64              `json:"count"`
+	Metrics map[string]*float64 `json:"metrics,omitempty"`
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package aggregation
+
+import "fmt"
+
+// Error is returned for invalid aggregation requests.
+type Error struct {
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
+
+func NewAggregationError(format string, value ...any) error {
+	return &Error{
+		Msg: fmt.Sprintf(format, value...),
+	}
+}
+
+// ValidateAggregationRequest validates an aggregation request.
+func ValidateAggregationRequest(req *Request) error {
+	if req == nil {
+		return &Error{Msg: "aggregation request is nil"}
+	}
+	if len(req.GroupBy) == 0 {
+		return &Error{Msg: "aggregation request needs at least one group-by field"}
+	}
+
+	fields := make(map[string]bool, len(req.GroupBy))
+	for i, groupBy := range req.GroupBy {
+		if groupBy.Field == "" {
+			return NewAggregationError("group-by %d: field is empty", i)
+		}
+		if fields[groupBy.Field] {
+			return NewAggregationError("group-by %d: duplicate field %s", i, groupBy.Field)
+		}
+		fields[groupBy.Field] = true
+		if groupBy.DateHistogram != nil && !groupBy.DateHistogram.Interval.IsValid() {
+			return NewAggregationError("group-by %d: %s is no valid calendar interval", i, groupBy.DateHistogram.Interval)
+		}
+	}
+
+	metrics := make(map[string]bool, len(req.Metrics))
+	for i, metric := range req.Metrics {
+		if metric.Field == "" {
+			return NewAggregationError("metric %d: field is empty", i)
+		}
+		if !metric.Metric.IsValid() {
+			return NewAggregationError("metric %d: %s is no valid metric", i, metric.Metric)
+		}
+		if metrics[metric.Key()] {
+			return NewAggregationError("metric %d: duplicate metric %s", i, metric.Key())
+		}
+		metrics[metric.Key()] = true
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAggregationRequest(t *testing.T) {
+	tests := map[string]struct {
+		request *Request
+		wantErr string
+	}{
+		"valid request": {
+			request: &Request{
+				GroupBy: []GroupBy{
+					{Field: "severity"},
+					{Field: "created", DateHistogram: &DateHistogram{Interval: IntervalDay}},
+				},
+				Metrics: []Metric{
+					{Field: "cvss", Metric: filter.AggregateMetricAvg},
+					{Field: "cvss", Metric: filter.AggregateMetricMax},
+				},
+			},
+		},
+		"nil request": {
+			request: nil,
+			wantErr: "aggregation request is nil",
+		},
+		"missing group-by": {
+			request: &Request{Metrics: []Metric{{Field: "cvss", Metric: filter.AggregateMetricAvg}}},
+			wantErr: "aggregation request needs at least one group-by field",
+		},
+		"empty group-by field": {
+			request: &Request{GroupBy: []GroupBy{{Field: "severity"}, {}}},
+			wantErr: "group-by 1: field is empty",
+		},
+		"duplicate group-by field": {
+			request: &Request{GroupBy: []GroupBy{{Field: "severity"}, {Field: "severity"}}},
+			wantErr: "group-by 1: duplicate field severity",
+		},
+		"invalid interval": {
+			request: &Request{GroupBy: []GroupBy{{Field: "created", DateHistogram: &DateHistogram{Interval: "decade"}}}},
+			wantErr: "group-by 0: decade is no valid calendar interval",
+		},
+		"invalid metric": {
+			request: &Request{
+				GroupBy: []GroupBy{{Field: "severity"}},
+				Metrics: []Metric{{Field: "cvss", Metric: "median"}},
+			},
+			wantErr: "metric 0: median is no valid metric",
+		},
+		"duplicate metric": {
+			request: &Request{
+				GroupBy: []GroupBy{{Field: "severity"}},
+				Metrics: []Metric{{Field: "cvss", Metric: filter.AggregateMetricSum}, {Field: "cvss", Metric: filter.AggregateMetricSum}},
+			},
+			wantErr: "metric 1: duplicate metric sum(cvss)",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateAggregationRequest(tt.request)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var aggregationError *Error
+			assert.ErrorAs(t, err, &aggregationError)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
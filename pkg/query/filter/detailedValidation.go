@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FieldValidationErrorCode identifies the kind of problem of a [FieldValidationError].
+type FieldValidationErrorCode string
+
+const (
+	FieldValidationErrorCodeInvalidName            FieldValidationErrorCode = "invalidName"
+	FieldValidationErrorCodeInvalidOperator        FieldValidationErrorCode = "invalidOperator"
+	FieldValidationErrorCodeInvalidType            FieldValidationErrorCode = "invalidType"
+	FieldValidationErrorCodeInvalidValue           FieldValidationErrorCode = "invalidValue"
+	FieldValidationErrorCodeMissingValue           FieldValidationErrorCode = "missingValue"
+	FieldValidationErrorCodeInvalidUuid            FieldValidationErrorCode = "invalidUuid"
+	FieldValidationErrorCodeInvalidDateTime        FieldValidationErrorCode = "invalidDateTime"
+	FieldValidationErrorCodeInvalidKeys            FieldValidationErrorCode = "invalidKeys"
+	FieldValidationErrorCodeInvalidMatch           FieldValidationErrorCode = "invalidMatch"
+	FieldValidationErrorCodeUnsupportedControlType FieldValidationErrorCode = "unsupportedControlType"
+	// codes of problems of a group itself, see [FieldValidationError.Index]
+	FieldValidationErrorCodeEmptyGroup           FieldValidationErrorCode = "emptyGroup"
+	FieldValidationErrorCodeGroupTooDeep         FieldValidationErrorCode = "groupTooDeep"
+	FieldValidationErrorCodeInvalidLogicOperator FieldValidationErrorCode = "invalidLogicOperator"
+)
+
+// FieldValidationError describes the problem of a single field of a filter request.
+type FieldValidationError struct {
+	// Groups is the path of indices of the nested groups containing the field, empty for fields of the request itself.
+	Groups []int `json:"groups,omitempty"`
+	// Index is the index of the field within the fields of its request or group.
+	// It is -1 if the problem concerns the group itself, e.g. an empty group.
+	Index int `json:"index"`
+	// Name is the name of the field.
+	Name string `json:"name,omitempty"`
+	// Code identifies the kind of problem.
+	Code FieldValidationErrorCode `json:"code"`
+	// Value is the offending value, for a list of values the invalid element.
+	Value any `json:"value,omitempty"`
+	// Message is the human-readable description of the problem.
+	Message string `json:"message"`
+}
+
+// Key returns the location of the field within the filter request, e.g. `fields[0]` or `groups[1].fields[2]`.
+// For a problem of a group itself only the group is referenced, e.g. `groups[1]`.
+func (e FieldValidationError) Key() string {
+	parts := make([]string, 0, len(e.Groups)+1)
+	for _, group := range e.Groups {
+		parts = append(parts, "groups["+strconv.Itoa(group)+"]")
+	}
+	if e.Index >= 0 {
+		parts = append(parts, "fields["+strconv.Itoa(e.Index)+"]")
+	}
+	return strings.Join(parts, ".")
+}
+
+// FieldValidationErrors is the result of [ValidateFilterDetailed] containing all problems of a filter request.
+type FieldValidationErrors []FieldValidationError
+
+func (e FieldValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldError := range e {
+		messages = append(messages, fieldError.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ErrorMap returns the messages of the problems by their [FieldValidationError.Key]. It can be passed as `errors`
+// to `errorResponses.NewErrorValidationResponse`.
+func (e FieldValidationErrors) ErrorMap() map[string]string {
+	errorMap := make(map[string]string, len(e))
+	for _, fieldError := range e {
+		errorMap[fieldError.Key()] = fieldError.Message
+	}
+	return errorMap
+}
+
+// ValidateFilterDetailed validates the filter in the request, including all nested groups, like [ValidateFilter].
+// Instead of stopping at the first problem, it collects the problems of all fields and returns them as
+// [FieldValidationErrors]. For each field only its first problem is reported.
+// In contrast to [ValidateFilter] the request is not modified, surrounding spaces of string values are ignored
+// during validation but not trimmed.
+func ValidateFilterDetailed(request *Request, requestOptions []RequestOption) error {
+	if request == nil {
+		return nil
+	}
+
+	var errs FieldValidationErrors
+	collectValidationErrors(request, requestOptions, nil, 0, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func collectValidationErrors(request *Request, requestOptions []RequestOption, groups []int, depth int,
+	errs *FieldValidationErrors,
+) {
+	for i, field := range request.Fields {
+		code, value, err := checkField(field, requestOptions)
+		if err != nil {
+			*errs = append(*errs, FieldValidationError{
+				Groups:  groups,
+				Index:   i,
+				Name:    field.Name,
+				Code:    code,
+				Value:   value,
+				Message: err.Error(),
+			})
+		}
+	}
+
+	for i := range request.Groups {
+		group := &request.Groups[i]
+		groupPath := append(slices.Clone(groups), i)
+		groupError := func(code FieldValidationErrorCode, format string, value ...any) {
+			*errs = append(*errs, FieldValidationError{
+				Groups:  groupPath,
+				Index:   -1,
+				Code:    code,
+				Message: fmt.Sprintf(format, value...),
+			})
+		}
+
+		switch {
+		case depth+1 > MaxGroupDepth:
+			groupError(FieldValidationErrorCodeGroupTooDeep,
+				"filter groups must not be nested deeper than %d levels", MaxGroupDepth)
+		case group.IsEmpty():
+			groupError(FieldValidationErrorCodeEmptyGroup, "filter group must not be empty")
+		case len(group.Fields)+len(group.Groups) > 1 && !group.Operator.IsValid():
+			groupError(FieldValidationErrorCodeInvalidLogicOperator,
+				"filter group has invalid logic operator '%s'", group.Operator)
+			collectValidationErrors(group, requestOptions, groupPath, depth+1, errs)
+		default:
+			collectValidationErrors(group, requestOptions, groupPath, depth+1, errs)
+		}
+	}
+}
+
+// checkField validates a single field without modifying it. It returns the code of the first problem,
+// the offending value and the error.
+func checkField(field RequestField, requestOptions []RequestOption) (FieldValidationErrorCode, any, error) {
+	if field.Name == "tag" {
+		if code, err := checkTagValues(field); err != nil {
+			return code, field.Value, err
+		}
+	}
+
+	optionIndex := slices.IndexFunc(requestOptions, func(option RequestOption) bool {
+		return option.Name.Value == field.Name
+	})
+	if optionIndex < 0 {
+		return FieldValidationErrorCodeInvalidName, field.Name, NewValidationError("field name '%s' is invalid", field.Name)
+	}
+	requestOption := requestOptions[optionIndex]
+
+	fieldCanHaveOperator := slices.ContainsFunc(requestOption.Operators, func(operator ReadableValue[CompareOperator]) bool {
+		return field.Operator == operator.Value
+	})
+	if !fieldCanHaveOperator {
+		return FieldValidationErrorCodeInvalidOperator, field.Operator,
+			NewValidationError("field '%s' can not have the operator '%s'", field.Name, field.Operator)
+	}
+	if !field.Match.IsValid() {
+		return FieldValidationErrorCodeInvalidMatch, field.Match,
+			NewValidationError("field '%s' can not have the match '%s'", field.Name, field.Match)
+	}
+
+	if !requestOption.MultiSelect {
+		code, err := checkFieldValueType(requestOption, field.Name, trimSpace(field.Value))
+		return code, field.Value, err
+	}
+	values, ok := field.Value.([]any)
+	if !ok {
+		return FieldValidationErrorCodeInvalidType, field.Value,
+			NewValidationError("field '%s' must be from type '[]%s'", field.Name, requestOption.Control.Type)
+	}
+	for _, value := range values {
+		if code, err := checkFieldValueType(requestOption, field.Name, trimSpace(value)); err != nil {
+			return code, value, err
+		}
+	}
+	return "", nil, nil
+}
+
+func trimSpace(value any) any {
+	if s, ok := value.(string); ok {
+		return strings.TrimSpace(s)
+	}
+	return value
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package filter
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFilterDetailed(t *testing.T) {
+	requestOptions := []RequestOption{
+		{
+			Name:        NewReadableValue("Name", "name"),
+			Control:     RequestOptionType{Type: ControlTypeString},
+			Operators:   []ReadableValue[CompareOperator]{{Value: CompareOperatorContains}},
+			MultiSelect: true,
+		},
+		{
+			Name:      NewReadableValue("State", "state"),
+			Control:   RequestOptionType{Type: ControlTypeEnum},
+			Operators: []ReadableValue[CompareOperator]{{Value: CompareOperatorIsEqualTo}},
+			Values:    []string{"active", "inactive"},
+		},
+		{
+			Name:      NewReadableValue("ID", "id"),
+			Control:   RequestOptionType{Type: ControlTypeUuid},
+			Operators: []ReadableValue[CompareOperator]{{Value: CompareOperatorIsEqualTo}},
+		},
+	}
+
+	tests := map[string]struct {
+		request    *Request
+		wantErrors FieldValidationErrors
+	}{
+		"nil request": {
+			request: nil,
+		},
+		"valid request with spaces": {
+			request: &Request{
+				Operator: LogicOperatorAnd,
+				Fields: []RequestField{
+					{Name: "name", Operator: CompareOperatorContains, Value: []any{" a ", "b"}},
+					{Name: "state", Operator: CompareOperatorIsEqualTo, Value: " active"},
+				},
+			},
+		},
+		"all problems of fields and groups": {
+			request: &Request{
+				Operator: LogicOperatorAnd,
+				Fields: []RequestField{
+					{Name: "unknown", Operator: CompareOperatorContains, Value: "a"},
+					{Name: "name", Operator: CompareOperatorContains, Value: []any{"a", 1.0}},
+					{Name: "state", Operator: CompareOperatorIsEqualTo, Value: "deleted"},
+					{Name: "state", Operator: CompareOperatorBeginsWith, Value: "active"},
+				},
+				Groups: []Request{
+					{},
+					{
+						Operator: "xor",
+						Fields: []RequestField{
+							{Name: "id", Operator: CompareOperatorIsEqualTo, Value: "123"},
+							{Name: "name", Operator: CompareOperatorContains, Value: "a"},
+						},
+					},
+				},
+			},
+			wantErrors: FieldValidationErrors{
+				{Index: 0, Name: "unknown", Code: FieldValidationErrorCodeInvalidName, Value: "unknown",
+					Message: "field name 'unknown' is invalid"},
+				{Index: 1, Name: "name", Code: FieldValidationErrorCodeInvalidType, Value: 1.0,
+					Message: "field 'name' must be from type 'string'"},
+				{Index: 2, Name: "state", Code: FieldValidationErrorCodeInvalidValue, Value: "deleted",
+					Message: "field 'state' can not have the value 'deleted'"},
+				{Index: 3, Name: "state", Code: FieldValidationErrorCodeInvalidOperator, Value: CompareOperatorBeginsWith,
+					Message: "field 'state' can not have the operator 'beginsWith'"},
+				{Groups: []int{0}, Index: -1, Code: FieldValidationErrorCodeEmptyGroup,
+					Message: "filter group must not be empty"},
+				{Groups: []int{1}, Index: -1, Code: FieldValidationErrorCodeInvalidLogicOperator,
+					Message: "filter group has invalid logic operator 'xor'"},
+				{Groups: []int{1}, Index: 0, Name: "id", Code: FieldValidationErrorCodeInvalidUuid, Value: "123",
+					Message: "field 'id' has an invalid UUID: 123"},
+				{Groups: []int{1}, Index: 1, Name: "name", Code: FieldValidationErrorCodeInvalidType, Value: "a",
+					Message: "field 'name' must be from type '[]string'"},
+			},
+		},
+		"invalid match": {
+			request: &Request{
+				Fields: []RequestField{
+					{Name: "name", Operator: CompareOperatorContains, Match: "some", Value: []any{"a"}},
+				},
+			},
+			wantErrors: FieldValidationErrors{
+				{Index: 0, Name: "name", Code: FieldValidationErrorCodeInvalidMatch, Value: ValueMatch("some"),
+					Message: "field 'name' can not have the match 'some'"},
+			},
+		},
+		"too deeply nested group": {
+			request: nestedRequest(MaxGroupDepth + 1),
+			wantErrors: FieldValidationErrors{
+				{Groups: []int{0, 0, 0, 0, 0, 0}, Index: -1, Code: FieldValidationErrorCodeGroupTooDeep,
+					Message: "filter groups must not be nested deeper than 5 levels"},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var original *Request
+			if tt.request != nil {
+				original = deepCopy(*tt.request)
+			}
+
+			err := ValidateFilterDetailed(tt.request, requestOptions)
+
+			assert.Equal(t, original, tt.request, "request must not be modified")
+			if tt.wantErrors == nil {
+				require.NoError(t, err)
+				return
+			}
+			var gotErrors FieldValidationErrors
+			require.True(t, errors.As(err, &gotErrors))
+			assert.Equal(t, tt.wantErrors, gotErrors)
+		})
+	}
+}
+
+func TestFieldValidationErrors_ErrorMap(t *testing.T) {
+	errs := FieldValidationErrors{
+		{Index: 0, Name: "a", Code: FieldValidationErrorCodeInvalidName, Message: "field name 'a' is invalid"},
+		{Groups: []int{1, 2}, Index: 3, Name: "b", Code: FieldValidationErrorCodeInvalidType, Message: "wrong type"},
+		{Groups: []int{0}, Index: -1, Code: FieldValidationErrorCodeEmptyGroup, Message: "filter group must not be empty"},
+	}
+
+	assert.Equal(t, map[string]string{
+		"fields[0]":                     "field name 'a' is invalid",
+		"groups[1].groups[2].fields[3]": "wrong type",
+		"groups[0]":                     "filter group must not be empty",
+	}, errs.ErrorMap())
+	assert.Equal(t, "field name 'a' is invalid; wrong type; filter group must not be empty", errs.Error())
+}
+
+func nestedRequest(depth int) *Request {
+	request := &Request{Fields: []RequestField{{Name: "name", Operator: CompareOperatorContains, Value: []any{"a"}}}}
+	for range depth {
+		request = &Request{Groups: []Request{*request}}
+	}
+	return request
+}
+
+func deepCopy(request Request) *Request {
+	result := request
+	result.Fields = slices.Clone(request.Fields)
+	for i, field := range result.Fields {
+		if values, ok := field.Value.([]any); ok {
+			result.Fields[i].Value = slices.Clone(values)
+		}
+	}
+	result.Groups = slices.Clone(request.Groups)
+	for i, group := range result.Groups {
+		result.Groups[i] = *deepCopy(group)
+	}
+	return &result
+}
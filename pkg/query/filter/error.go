@@ -9,19 +9,33 @@ import (
 )
 
 type ValidationError struct {
-	message string
+	message  string
+	position int
 }
 
 func (v *ValidationError) Error() string {
 	return v.message
 }
 
+// Position returns the position (1-based, in characters) of a syntax error within a filter text,
+// see [ParseText]. It returns 0 if the error does not refer to a position.
+func (v *ValidationError) Position() int {
+	return v.position
+}
+
 func NewValidationError(format string, value ...any) *ValidationError {
 	return &ValidationError{
 		message: fmt.Sprintf(format, value...),
 	}
 }
 
+func newSyntaxError(position int, format string, value ...any) *ValidationError {
+	return &ValidationError{
+		message:  fmt.Sprintf("syntax error at position %d: ", position) + fmt.Sprintf(format, value...),
+		position: position,
+	}
+}
+
 type InvalidFilterFieldError struct {
 	message string
 }
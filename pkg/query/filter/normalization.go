@@ -0,0 +1,373 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package filter
+
+import (
+	"encoding/json"
+	"math"
+	"net/netip"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Clock returns the current time. It is used to resolve relative dates like `now-7d`.
+type Clock func() time.Time
+
+// Normalizer coerces the values of a filter request into canonical typed values based on the control type
+// of their field, see [Normalizer.Normalize].
+type Normalizer struct {
+	now Clock
+}
+
+// NewNormalizer creates a normalizer resolving relative dates against the given clock.
+// If `now` is nil, [time.Now] is used.
+func NewNormalizer(now Clock) *Normalizer {
+	if now == nil {
+		now = time.Now
+	}
+	return &Normalizer{now: now}
+}
+
+// NormalizeFilter normalizes the request using the current time, see [Normalizer.Normalize].
+func NormalizeFilter(request *Request, requestOptions []RequestOption) (*Request, error) {
+	return NewNormalizer(nil).Normalize(request, requestOptions)
+}
+
+// Normalize returns a copy of the request with values coerced into canonical typed values, so that the query
+// builders receive well-typed values. The request itself is not modified. A list of values stays a list,
+// each element is coerced on its own. Depending on the control type of the field the values become:
+//
+//   - integer: int64, also from integral numbers and numeric strings, e.g. `"42"`
+//   - float: float64, also from numeric strings
+//   - bool: bool, also from strings like `"true"`
+//   - string, enum, autocomplete: string without surrounding spaces
+//   - uuid: [uuid.UUID]
+//   - dateTime: [time.Time], from RFC3339 strings, dates like `"2024-05-01"` (UTC), Unix timestamps in seconds
+//     (as number or string) and relative dates like `now-7d` or `now/d`, see [Normalizer.ParseRelativeDate]
+//
+// Values of the IP compare operators become [netip.Addr] for a single address and [netip.Prefix] for a network.
+// Values of the rating compare operators stay strings, values of `exists` and `doesNotExist` are kept as they are.
+func (n *Normalizer) Normalize(request *Request, requestOptions []RequestOption) (*Request, error) {
+	if request == nil {
+		return nil, nil
+	}
+
+	normalized := *request
+	normalized.Fields = slices.Clone(request.Fields)
+	for i, field := range normalized.Fields {
+		requestOption, ok := findRequestOption(requestOptions, field.Name)
+		if !ok {
+			return nil, NewValidationError("field name '%s' is invalid", field.Name)
+		}
+		value, err := n.normalizeValue(field, requestOption.Control.Type)
+		if err != nil {
+			return nil, err
+		}
+		normalized.Fields[i].Value = value
+	}
+
+	normalized.Groups = slices.Clone(request.Groups)
+	for i := range normalized.Groups {
+		group, err := n.Normalize(&normalized.Groups[i], requestOptions)
+		if err != nil {
+			return nil, err
+		}
+		normalized.Groups[i] = *group
+	}
+	return &normalized, nil
+}
+
+func findRequestOption(requestOptions []RequestOption, name string) (RequestOption, bool) {
+	index := slices.IndexFunc(requestOptions, func(option RequestOption) bool { return option.Name.Value == name })
+	if index < 0 {
+		return RequestOption{}, false
+	}
+	return requestOptions[index], true
+}
+
+func (n *Normalizer) normalizeValue(field RequestField, controlType ControlType) (any, error) {
+	var coerce func(value any) (any, error)
+	switch field.Operator {
+	case CompareOperatorExists, CompareOperatorDoesNotExist:
+		return field.Value, nil
+	case CompareOperatorIsIpEqualTo, CompareOperatorIsIpNotEqualTo:
+		coerce = toIp
+	case CompareOperatorIsEqualToRating, CompareOperatorIsNotEqualToRating,
+		CompareOperatorIsLessThanRating, CompareOperatorIsLessThanOrEqualToRating,
+		CompareOperatorIsGreaterThanRating, CompareOperatorIsGreaterThanOrEqualToRating:
+		coerce = toTrimmedString
+	default:
+		switch controlType {
+		case ControlTypeInteger:
+			coerce = toInt64
+		case ControlTypeFloat:
+			coerce = toFloat64
+		case ControlTypeBool:
+			coerce = toBool
+		case ControlTypeString, ControlTypeEnum, ControlTypeAutocomplete:
+			coerce = toTrimmedString
+		case ControlTypeUuid:
+			coerce = toUuid
+		case ControlTypeDateTime:
+			coerce = n.toTime
+		default:
+			return nil, NewValidationError("request option control type '%s' is not supported", controlType)
+		}
+	}
+
+	if values, ok := ListValues(field.Value); ok {
+		normalized := make([]any, 0, len(values))
+		for _, value := range values {
+			coerced, err := coerce(value)
+			if err != nil {
+				return nil, NewValidationError("field '%s' has invalid value: %v", field.Name, err)
+			}
+			normalized = append(normalized, coerced)
+		}
+		return normalized, nil
+	}
+	coerced, err := coerce(field.Value)
+	if err != nil {
+		return nil, NewValidationError("field '%s' has invalid value: %v", field.Name, err)
+	}
+	return coerced, nil
+}
+
+func toInt64(value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		number, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return nil, NewValidationError("'%s' is not an integer", v)
+		}
+		return number, nil
+	case json.Number:
+		return toInt64(string(v))
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflectValue.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if reflectValue.Uint() > math.MaxInt64 {
+			return nil, NewValidationError("%v is out of range", value)
+		}
+		return int64(reflectValue.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		number := reflectValue.Float()
+		if number != math.Trunc(number) || number < math.MinInt64 || number >= math.MaxInt64 {
+			return nil, NewValidationError("%v is not an integer", value)
+		}
+		return int64(number), nil
+	default:
+		return nil, NewValidationError("%v of type %T is not an integer", value, value)
+	}
+}
+
+func toFloat64(value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return nil, NewValidationError("'%s' is not a number", v)
+		}
+		return number, nil
+	case json.Number:
+		return toFloat64(string(v))
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(reflectValue.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(reflectValue.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return reflectValue.Float(), nil
+	default:
+		return nil, NewValidationError("%v of type %T is not a number", value, value)
+	}
+}
+
+func toBool(value any) (any, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return nil, NewValidationError("'%s' is not a boolean", v)
+		}
+		return b, nil
+	default:
+		return nil, NewValidationError("%v of type %T is not a boolean", value, value)
+	}
+}
+
+func toTrimmedString(value any) (any, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, NewValidationError("%v of type %T is not a string", value, value)
+	}
+	return strings.TrimSpace(s), nil
+}
+
+func toUuid(value any) (any, error) {
+	switch v := value.(type) {
+	case uuid.UUID:
+		return v, nil
+	case string:
+		id, err := uuid.Parse(strings.TrimSpace(v))
+		if err != nil {
+			return nil, NewUuidValidationError("'%s' is not a valid UUID", v)
+		}
+		return id, nil
+	default:
+		return nil, NewValidationError("%v of type %T is not a UUID", value, value)
+	}
+}
+
+// toIp keeps single addresses as [netip.Addr], so they can still be compared to addresses stored as text.
+func toIp(value any) (any, error) {
+	switch v := value.(type) {
+	case netip.Addr:
+		return v, nil
+	case netip.Prefix:
+		if v.IsSingleIP() {
+			return v.Addr(), nil
+		}
+		return v, nil
+	case string:
+		s := strings.TrimSpace(v)
+		if addr, err := netip.ParseAddr(s); err == nil {
+			return addr, nil
+		}
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, NewValidationError("'%s' is neither an IP address nor a network in CIDR notation", v)
+		}
+		if prefix.IsSingleIP() {
+			return prefix.Addr(), nil
+		}
+		return prefix, nil
+	default:
+		return nil, NewValidationError("%v of type %T is not an IP address", value, value)
+	}
+}
+
+func (n *Normalizer) toTime(value any) (any, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		s := strings.TrimSpace(v)
+		if strings.HasPrefix(s, "now") {
+			return n.ParseRelativeDate(s)
+		}
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", time.DateOnly} {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+		if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+			return unixTime(seconds), nil
+		}
+		return nil, NewValidationError("'%s' is not a valid date", v)
+	case json.Number:
+		return n.toTime(string(v))
+	}
+
+	seconds, err := toFloat64(value)
+	if err != nil {
+		return nil, NewValidationError("%v of type %T is not a date", value, value)
+	}
+	return unixTime(seconds.(float64)), nil
+}
+
+func unixTime(seconds float64) time.Time {
+	whole, fraction := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(fraction*1e9)).UTC()
+}
+
+// relativeDatePattern matches date math expressions like `now-7d`, `now+1h-30m` or `now-1d/d`.
+var relativeDatePattern = regexp.MustCompile(`^now((?:[+-]\d+[yMwdhms])*)(?:/([yMwdhms]))?$`)
+
+var relativeDateOperationPattern = regexp.MustCompile(`([+-])(\d+)([yMwdhms])`)
+
+// ParseRelativeDate resolves a relative date against the clock of the normalizer. The expression starts with
+// `now`, followed by any number of additions or subtractions of a duration and optionally a rounding down to
+// the start of a unit, e.g. `now-7d`, `now+1h-30m` or `now-1d/d` (start of yesterday).
+// Supported units are `y` (years), `M` (months), `w` (weeks), `d` (days), `h` (hours), `m` (minutes)
+// and `s` (seconds).
+func (n *Normalizer) ParseRelativeDate(expression string) (time.Time, error) {
+	match := relativeDatePattern.FindStringSubmatch(expression)
+	if match == nil {
+		return time.Time{}, NewValidationError("'%s' is not a valid relative date", expression)
+	}
+
+	t := n.now()
+	for _, operation := range relativeDateOperationPattern.FindAllStringSubmatch(match[1], -1) {
+		amount, err := strconv.Atoi(operation[2])
+		if err != nil {
+			return time.Time{}, NewValidationError("'%s' is not a valid relative date", expression)
+		}
+		if operation[1] == "-" {
+			amount = -amount
+		}
+		t = addDuration(t, amount, operation[3])
+	}
+	if match[2] != "" {
+		t = roundDown(t, match[2])
+	}
+	return t, nil
+}
+
+func addDuration(t time.Time, amount int, unit string) time.Time {
+	switch unit {
+	case "y":
+		return t.AddDate(amount, 0, 0)
+	case "M":
+		return t.AddDate(0, amount, 0)
+	case "w":
+		return t.AddDate(0, 0, 7*amount)
+	case "d":
+		return t.AddDate(0, 0, amount)
+	case "h":
+		return t.Add(time.Duration(amount) * time.Hour)
+	case "m":
+		return t.Add(time.Duration(amount) * time.Minute)
+	default:
+		return t.Add(time.Duration(amount) * time.Second)
+	}
+}
+
+// roundDown returns the start of the unit containing t. Weeks start on Monday.
+func roundDown(t time.Time, unit string) time.Time {
+	year, month, day := t.Date()
+	switch unit {
+	case "y":
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, t.Location())
+	case "M":
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	case "w":
+		daysSinceMonday := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-daysSinceMonday, 0, 0, 0, 0, t.Location())
+	case "d":
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	case "h":
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, t.Location())
+	case "m":
+		return time.Date(year, month, day, t.Hour(), t.Minute(), 0, 0, t.Location())
+	default:
+		return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	}
+}
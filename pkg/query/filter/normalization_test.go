@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package filter
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizer_Normalize(t *testing.T) {
+	now := time.Date(2025, 5, 14, 10, 30, 15, 0, time.UTC) // Wednesday
+	normalizer := NewNormalizer(func() time.Time { return now })
+
+	requestOptions := []RequestOption{
+		{Name: NewReadableValue("", "count"), Control: RequestOptionType{Type: ControlTypeInteger}},
+		{Name: NewReadableValue("", "score"), Control: RequestOptionType{Type: ControlTypeFloat}},
+		{Name: NewReadableValue("", "active"), Control: RequestOptionType{Type: ControlTypeBool}},
+		{Name: NewReadableValue("", "name"), Control: RequestOptionType{Type: ControlTypeString}},
+		{Name: NewReadableValue("", "id"), Control: RequestOptionType{Type: ControlTypeUuid}},
+		{Name: NewReadableValue("", "created"), Control: RequestOptionType{Type: ControlTypeDateTime}},
+	}
+	id := uuid.MustParse("9bd5c4ea-5b0c-4a4f-a2a0-5a0ff0a3e1d2")
+
+	tests := map[string]struct {
+		field RequestField
+		want  any
+	}{
+		"integer from float":        {field: RequestField{Name: "count", Operator: CompareOperatorIsEqualTo, Value: 42.0}, want: int64(42)},
+		"integer from string":       {field: RequestField{Name: "count", Operator: CompareOperatorIsEqualTo, Value: " 42"}, want: int64(42)},
+		"integer list":              {field: RequestField{Name: "count", Operator: CompareOperatorIsEqualTo, Value: []any{1.0, "2", 3}}, want: []any{int64(1), int64(2), int64(3)}},
+		"float from string":         {field: RequestField{Name: "score", Operator: CompareOperatorIsLessThan, Value: "7.5"}, want: 7.5},
+		"float from int":            {field: RequestField{Name: "score", Operator: CompareOperatorIsLessThan, Value: 7}, want: 7.0},
+		"rating stays string":       {field: RequestField{Name: "score", Operator: CompareOperatorIsEqualToRating, Value: " high "}, want: "high"},
+		"bool from string":          {field: RequestField{Name: "active", Operator: CompareOperatorIsEqualTo, Value: "true"}, want: true},
+		"trimmed string":            {field: RequestField{Name: "name", Operator: CompareOperatorContains, Value: []string{" a ", "b"}}, want: []any{"a", "b"}},
+		"ip address":                {field: RequestField{Name: "name", Operator: CompareOperatorIsIpEqualTo, Value: "10.0.0.1"}, want: netip.MustParseAddr("10.0.0.1")},
+		"ip network":                {field: RequestField{Name: "name", Operator: CompareOperatorIsIpNotEqualTo, Value: "2001:db8::/32"}, want: netip.MustParsePrefix("2001:db8::/32")},
+		"ip host network":           {field: RequestField{Name: "name", Operator: CompareOperatorIsIpEqualTo, Value: "10.0.0.1/32"}, want: netip.MustParseAddr("10.0.0.1")},
+		"uuid":                      {field: RequestField{Name: "id", Operator: CompareOperatorIsEqualTo, Value: id.String()}, want: id},
+		"exists keeps value":        {field: RequestField{Name: "id", Operator: CompareOperatorExists, Value: "yes"}, want: "yes"},
+		"RFC3339 date":              {field: RequestField{Name: "created", Operator: CompareOperatorAfterDate, Value: "2024-05-01T12:00:00+02:00"}, want: time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("", 7200))},
+		"date only":                 {field: RequestField{Name: "created", Operator: CompareOperatorAfterDate, Value: "2024-05-01"}, want: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		"unix timestamp":            {field: RequestField{Name: "created", Operator: CompareOperatorAfterDate, Value: 1714521600.5}, want: time.Date(2024, 5, 1, 0, 0, 0, 5e8, time.UTC)},
+		"unix timestamp string":     {field: RequestField{Name: "created", Operator: CompareOperatorAfterDate, Value: "1714521600"}, want: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		"relative date":             {field: RequestField{Name: "created", Operator: CompareOperatorAfterDate, Value: "now-7d"}, want: now.AddDate(0, 0, -7)},
+		"relative dates in between": {field: RequestField{Name: "created", Operator: CompareOperatorBetweenDates, Value: []any{"now-1d/d", "now"}}, want: []any{time.Date(2025, 5, 13, 0, 0, 0, 0, time.UTC), now}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := &Request{Operator: LogicOperatorAnd, Groups: []Request{{Fields: []RequestField{tt.field}}}}
+			originalValue := tt.field.Value
+
+			got, err := normalizer.Normalize(request, requestOptions)
+			require.NoError(t, err)
+
+			value := got.Groups[0].Fields[0].Value
+			if wantTime, ok := tt.want.(time.Time); ok {
+				require.IsType(t, time.Time{}, value)
+				assert.True(t, wantTime.Equal(value.(time.Time)), "got %v", value)
+			} else {
+				assert.Equal(t, tt.want, value)
+			}
+			assert.Equal(t, originalValue, request.Groups[0].Fields[0].Value, "request must not be modified")
+
+			// the normalized request is still valid, unless the operator ignores the control type
+			if tt.field.Operator != CompareOperatorExists && tt.field.Operator != CompareOperatorIsEqualToRating {
+				requestOptions := []RequestOption{findOption(t, requestOptions, tt.field)}
+				assert.NoError(t, ValidateFilter(got, requestOptions))
+			}
+		})
+	}
+}
+
+func findOption(t *testing.T, requestOptions []RequestOption, field RequestField) RequestOption {
+	option, ok := findRequestOption(requestOptions, field.Name)
+	require.True(t, ok)
+	option.Operators = []ReadableValue[CompareOperator]{{Value: field.Operator}}
+	_, option.MultiSelect = ListValues(field.Value)
+	return option
+}
+
+func TestNormalizer_Normalize_Errors(t *testing.T) {
+	requestOptions := []RequestOption{
+		{Name: NewReadableValue("", "count"), Control: RequestOptionType{Type: ControlTypeInteger}},
+		{Name: NewReadableValue("", "id"), Control: RequestOptionType{Type: ControlTypeUuid}},
+		{Name: NewReadableValue("", "created"), Control: RequestOptionType{Type: ControlTypeDateTime}},
+	}
+
+	tests := map[string]RequestField{
+		"unknown field":         {Name: "unknown", Operator: CompareOperatorIsEqualTo, Value: 1},
+		"fractional integer":    {Name: "count", Operator: CompareOperatorIsEqualTo, Value: 1.5},
+		"non-numeric integer":   {Name: "count", Operator: CompareOperatorIsEqualTo, Value: []any{1, "x"}},
+		"invalid uuid":          {Name: "id", Operator: CompareOperatorIsEqualTo, Value: "123"},
+		"invalid date":          {Name: "created", Operator: CompareOperatorAfterDate, Value: "yesterday"},
+		"invalid relative date": {Name: "created", Operator: CompareOperatorAfterDate, Value: "now-7x"},
+		"invalid ip":            {Name: "id", Operator: CompareOperatorIsIpEqualTo, Value: "a.b.c.d"},
+	}
+
+	for name, field := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewNormalizer(nil).Normalize(&Request{Fields: []RequestField{field}}, requestOptions)
+			var validationError *ValidationError
+			assert.ErrorAs(t, err, &validationError)
+		})
+	}
+}
+
+func TestNormalizer_ParseRelativeDate(t *testing.T) {
+	now := time.Date(2025, 5, 14, 10, 30, 15, 0, time.UTC) // Wednesday
+	normalizer := NewNormalizer(func() time.Time { return now })
+
+	tests := map[string]time.Time{
+		"now":        now,
+		"now+1h-30m": time.Date(2025, 5, 14, 11, 0, 15, 0, time.UTC),
+		"now-1M":     time.Date(2025, 4, 14, 10, 30, 15, 0, time.UTC),
+		"now-1y/y":   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		"now/w":      time.Date(2025, 5, 12, 0, 0, 0, 0, time.UTC),
+		"now-2w/M":   time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		"now+10s/m":  time.Date(2025, 5, 14, 10, 30, 0, 0, time.UTC),
+		"now-36h/h":  time.Date(2025, 5, 12, 22, 0, 0, 0, time.UTC),
+	}
+
+	for expression, want := range tests {
+		t.Run(expression, func(t *testing.T) {
+			got, err := normalizer.ParseRelativeDate(expression)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+
+	_, err := normalizer.ParseRelativeDate("now-7")
+	assert.Error(t, err)
+}
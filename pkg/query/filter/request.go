@@ -5,6 +5,9 @@
 package filter
 
 import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
 	"slices"
 	"strings"
 )
@@ -12,9 +15,19 @@ import (
 // Request is a struct representing a filter request.
 // Operator is the logic operator used for the request.
 // Fields is a slice of RequestField, representing the fields to be used for the filtering.
+// Groups is a slice of nested Request. Each group is evaluated on its own and the results are combined
+// with the Fields using Operator. This allows expressing filters like `(a AND b) OR c`.
 type Request struct {
 	Operator LogicOperator  `json:"operator" binding:"required"`
 	Fields   []RequestField `json:"fields" binding:"dive"`
+	Groups   []Request      `json:"groups,omitempty" binding:"omitempty,dive"`
+}
+
+// IsEmpty returns true if the request contains neither fields nor groups.
+// An empty request matches everything, but empty nested groups are invalid, as there is no clear way
+// to interpret them, e.g. whether an empty group in an `or` request matches everything or nothing.
+func (r *Request) IsEmpty() bool {
+	return r == nil || (len(r.Fields) == 0 && len(r.Groups) == 0)
 }
 
 // RequestOption configures a field for validation
@@ -66,15 +79,73 @@ type RequestOptionType struct {
 	Type ControlType `json:"type" enums:"string,float,integer,enum,bool"`
 }
 
+// RatingRange represents a closed interval of float32 values. A rating is a named range of numeric values,
+// e.g. `high` for a severity between 7.0 and 8.9, which is used by the `*Rating` compare operators.
+type RatingRange struct {
+	Min float32 // Lower bound of the rating range (inclusive)
+	Max float32 // Upper bound of the rating range (inclusive)
+}
+
 // RequestField represents a field in a request
 // Field Name: The name of the field
 // Field Keys: Sequence of keys of a nested key structure - only used for fields with a nested structure. Example: Tag -> Name: ABC (which would be represented as []string{"Tag", "Name: ABC"} )
 // Field Operator: The comparison operator for the field
 // Field Value: The value of the field, which can be a list of values or a single value
+// Field Match: Whether any or all values of a list need to match, empty means any. Matching all values is only
+// supported for fields mapped to collections, e.g. the jsonb and array fields of the Postgres query builder.
 type RequestField struct {
 	Name     string          `json:"name" binding:"required"`
 	Keys     []string        `json:"keys,omitempty"`
 	Operator CompareOperator `json:"operator" binding:"required"`
 	// Value can be a list of values or a value
-	Value any `json:"value" binding:"required"`
+	Value any        `json:"value" binding:"required"`
+	Match ValueMatch `json:"match,omitempty"`
+}
+
+// ValueMatch defines how a list of values is matched against a collection, e.g. the tags of an asset.
+type ValueMatch string
+
+const (
+	// ValueMatchAny matches if any of the values matches an element of the collection.
+	ValueMatchAny ValueMatch = "any"
+	// ValueMatchAll matches if each of the values matches an element of the collection.
+	ValueMatchAll ValueMatch = "all"
+)
+
+// IsValid returns true if the match is empty or one of the defined values.
+func (m ValueMatch) IsValid() bool {
+	switch m {
+	case "", ValueMatchAny, ValueMatchAll:
+		return true
+	default:
+		return false
+	}
+}
+
+// ListValues returns the elements of a field value which is a list of values, converted to []any so that
+// consumers don't need to deal with different slice types. Slices and arrays are lists, except for []byte and
+// arrays representing a single value like [uuid.UUID], i.e. arrays implementing [fmt.Stringer] or [driver.Valuer].
+func ListValues(value any) ([]any, bool) {
+	if values, ok := value.([]any); ok {
+		return values, true
+	}
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Slice:
+		if reflectValue.Type().Elem().Kind() == reflect.Uint8 {
+			return nil, false
+		}
+	case reflect.Array:
+		switch value.(type) {
+		case fmt.Stringer, driver.Valuer:
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	values := make([]any, reflectValue.Len())
+	for i := range values {
+		values[i] = reflectValue.Index(i).Interface()
+	}
+	return values, true
 }
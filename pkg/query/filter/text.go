@@ -0,0 +1,541 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// The text form of a filter request is a human-readable alternative to its JSON form, e.g.
+//
+//	hostname ~ "db" and severity >= 7 and tag.env exists
+//
+// Grammar:
+//
+//	expression := and-chain ( "or" and-chain )*
+//	and-chain  := primary ( "and" primary )*
+//	primary    := "(" [ expression ] ")" | condition
+//	condition  := field operator [ match ] [ value ]
+//	field      := name ( "." name )*      first name is the field name, the others are its keys
+//	name       := identifier | string
+//	operator   := "=" | "!=" | "~" | "!~" | ">" | ">=" | "<" | "<=" | compare operator name, e.g. beginsWith
+//	match      := "any" | "all"                whether any or all values of a list need to match
+//	value      := string | number | "true" | "false" | "null" | "[" [ value ( "," value )* ] "]"
+//
+// `and` binds stronger than `or`, parentheses create a nested group. Strings are double-quoted with
+// Go escape sequences, numbers are parsed as float64 like in the JSON form. A value is optional for the
+// operators `exists` and `doesNotExist` only.
+
+// operatorSymbols are the short forms of common compare operators in the text form.
+var operatorSymbols = map[CompareOperator]string{
+	CompareOperatorIsEqualTo:              "=",
+	CompareOperatorIsNotEqualTo:           "!=",
+	CompareOperatorContains:               "~",
+	CompareOperatorDoesNotContain:         "!~",
+	CompareOperatorIsGreaterThan:          ">",
+	CompareOperatorIsGreaterThanOrEqualTo: ">=",
+	CompareOperatorIsLessThan:             "<",
+	CompareOperatorIsLessThanOrEqualTo:    "<=",
+}
+
+// keywords can't be used as unquoted names in the text form.
+var keywords = []string{"and", "or", "true", "false", "null"}
+
+// ParseText parses the text form of a filter request. Syntax errors are returned as [ValidationError]
+// containing the position of the error.
+// An empty text results in an empty request.
+func ParseText(text string) (*Request, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &textParser{tokens: tokens}
+	if p.peek().kind == tokenEnd {
+		return &Request{}, nil
+	}
+
+	entry, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if token := p.peek(); token.kind != tokenEnd {
+		return nil, newSyntaxError(token.position, "expected 'and' or 'or', got %s", token)
+	}
+
+	switch {
+	case entry.field != nil:
+		return &Request{Fields: []RequestField{*entry.field}}, nil
+	case entry.parenthesized:
+		return &Request{Groups: []Request{*entry.group}}, nil
+	default:
+		return entry.group, nil
+	}
+}
+
+// FormatText returns the text form of the filter request, see [ParseText].
+// Parsing the text results in the same request, with the exception of the logic operator of requests
+// with a single entry, which has no meaning and is omitted. Fields are written before groups, numbers are
+// formatted to be parsed as float64.
+func FormatText(request *Request) (string, error) {
+	if request == nil {
+		return "", nil
+	}
+	return formatRequest(request)
+}
+
+func formatRequest(request *Request) (string, error) {
+	parts := make([]string, 0, len(request.Fields)+len(request.Groups))
+	for _, field := range request.Fields {
+		part, err := formatField(field)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, part)
+	}
+	for _, group := range request.Groups {
+		part, err := formatRequest(&group)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, "("+part+")")
+	}
+
+	if len(parts) > 1 && !request.Operator.IsValid() {
+		return "", NewValidationError("invalid logic operator '%s'", request.Operator)
+	}
+	return strings.Join(parts, " "+string(request.Operator)+" "), nil
+}
+
+func formatField(field RequestField) (string, error) {
+	var text strings.Builder
+	text.WriteString(formatName(field.Name))
+	for _, key := range field.Keys {
+		text.WriteRune('.')
+		text.WriteString(formatName(key))
+	}
+
+	if !field.Operator.IsValid() {
+		return "", NewValidationError("field '%s' has invalid operator '%s'", field.Name, field.Operator)
+	}
+	text.WriteRune(' ')
+	if symbol, ok := operatorSymbols[field.Operator]; ok {
+		text.WriteString(symbol)
+	} else {
+		text.WriteString(string(field.Operator))
+	}
+
+	if field.Match != "" {
+		if !field.Match.IsValid() {
+			return "", NewValidationError("field '%s' has invalid match '%s'", field.Name, field.Match)
+		}
+		text.WriteRune(' ')
+		text.WriteString(string(field.Match))
+	}
+	if field.Value == nil && isExistenceOperator(field.Operator) {
+		return text.String(), nil
+	}
+	value, err := formatValue(field.Value)
+	if err != nil {
+		return "", NewValidationError("field '%s' has invalid value: %v", field.Name, err)
+	}
+	text.WriteRune(' ')
+	text.WriteString(value)
+	return text.String(), nil
+}
+
+func formatName(name string) string {
+	if isIdentifier(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+func formatValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		return strconv.Quote(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case time.Time:
+		return strconv.Quote(v.Format(time.RFC3339Nano)), nil
+	}
+
+	if list, ok := ListValues(value); ok {
+		values := make([]string, 0, len(list))
+		for _, listValue := range list {
+			element, err := formatValue(listValue)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, element)
+		}
+		return "[" + strings.Join(values, ", ") + "]", nil
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(reflectValue.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(reflectValue.Uint(), 10), nil
+	case reflect.String:
+		return strconv.Quote(reflectValue.String()), nil
+	}
+	if stringer, ok := value.(fmt.Stringer); ok { // e.g. normalized values like uuid.UUID or netip.Addr
+		return strconv.Quote(stringer.String()), nil
+	}
+	return "", fmt.Errorf("unsupported value type %T", value)
+}
+
+func isExistenceOperator(operator CompareOperator) bool {
+	return operator == CompareOperatorExists || operator == CompareOperatorDoesNotExist
+}
+
+func isIdentifier(name string) bool {
+	if name == "" || isKeyword(name) {
+		return false
+	}
+	for i, r := range name {
+		if !isIdentifierRune(r, i == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func isIdentifierRune(r rune, first bool) bool {
+	return r == '_' || unicode.IsLetter(r) || (!first && unicode.IsDigit(r))
+}
+
+func isKeyword(word string) bool {
+	for _, keyword := range keywords {
+		if strings.EqualFold(word, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenIdentifier
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+type token struct {
+	kind     tokenKind
+	text     string // raw text, for strings the unquoted value
+	position int    // 1-based position of the first character
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEnd:
+		return "end of input"
+	case tokenString:
+		return strconv.Quote(t.text)
+	default:
+		return "'" + t.text + "'"
+	}
+}
+
+// is returns true if the token is the given symbol or (case-insensitive) keyword.
+func (t token) is(text string) bool {
+	switch t.kind {
+	case tokenSymbol:
+		return t.text == text
+	case tokenIdentifier:
+		return strings.EqualFold(t.text, text)
+	default:
+		return false
+	}
+}
+
+func tokenize(text string) ([]token, error) {
+	runes := []rune(text)
+	var tokens []token
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		position := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case isIdentifierRune(r, true):
+			start := i
+			for i < len(runes) && isIdentifierRune(runes[i], false) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, text: string(runes[start:i]), position: position})
+		case r == '"':
+			start := i
+			for i++; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(runes) {
+				return nil, newSyntaxError(position, "unterminated string")
+			}
+			i++
+			value, err := strconv.Unquote(string(runes[start:i]))
+			if err != nil {
+				return nil, newSyntaxError(position, "invalid string %s", string(runes[start:i]))
+			}
+			tokens = append(tokens, token{kind: tokenString, text: value, position: position})
+		case r == '-' || unicode.IsDigit(r):
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || strings.ContainsRune(".eE", runes[i]) ||
+				(strings.ContainsRune("+-", runes[i]) && strings.ContainsRune("eE", runes[i-1]))) {
+				i++
+			}
+			number := string(runes[start:i])
+			if _, err := strconv.ParseFloat(number, 64); err != nil {
+				return nil, newSyntaxError(position, "invalid number '%s'", number)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: number, position: position})
+		case strings.ContainsRune("()[],.~=", r):
+			tokens = append(tokens, token{kind: tokenSymbol, text: string(r), position: position})
+			i++
+		case strings.ContainsRune("!<>", r):
+			symbol := string(r)
+			if i+1 < len(runes) && (runes[i+1] == '=' || (r == '!' && runes[i+1] == '~')) {
+				symbol += string(runes[i+1])
+			}
+			if symbol == "!" {
+				return nil, newSyntaxError(position, "unexpected character '!'")
+			}
+			tokens = append(tokens, token{kind: tokenSymbol, text: symbol, position: position})
+			i += len(symbol)
+		default:
+			return nil, newSyntaxError(position, "unexpected character '%c'", r)
+		}
+	}
+	return append(tokens, token{kind: tokenEnd, position: len(runes) + 1}), nil
+}
+
+type textParser struct {
+	tokens []token
+	index  int
+}
+
+// textEntry is a parsed entry of a request, either a single field or a group of entries.
+type textEntry struct {
+	field         *RequestField
+	group         *Request
+	parenthesized bool // group was enclosed in parentheses
+}
+
+func (p *textParser) peek() token {
+	return p.tokens[p.index]
+}
+
+func (p *textParser) next() token {
+	t := p.tokens[p.index]
+	if t.kind != tokenEnd {
+		p.index++
+	}
+	return t
+}
+
+func (p *textParser) parseExpression() (textEntry, error) {
+	return p.parseChain(LogicOperatorOr, p.parseAndChain)
+}
+
+func (p *textParser) parseAndChain() (textEntry, error) {
+	return p.parseChain(LogicOperatorAnd, p.parsePrimary)
+}
+
+// parseChain parses entries separated by the given logic operator.
+func (p *textParser) parseChain(operator LogicOperator, parseEntry func() (textEntry, error)) (textEntry, error) {
+	entry, err := parseEntry()
+	if err != nil {
+		return textEntry{}, err
+	}
+	if !p.peek().is(string(operator)) {
+		return entry, nil
+	}
+
+	chain := &Request{Operator: operator}
+	addEntry(chain, entry)
+	for p.peek().is(string(operator)) {
+		p.next()
+		entry, err := parseEntry()
+		if err != nil {
+			return textEntry{}, err
+		}
+		addEntry(chain, entry)
+	}
+	return textEntry{group: chain}, nil
+}
+
+func addEntry(request *Request, entry textEntry) {
+	if entry.field != nil {
+		request.Fields = append(request.Fields, *entry.field)
+	} else {
+		request.Groups = append(request.Groups, *entry.group)
+	}
+}
+
+func (p *textParser) parsePrimary() (textEntry, error) {
+	if !p.peek().is("(") {
+		field, err := p.parseCondition()
+		if err != nil {
+			return textEntry{}, err
+		}
+		return textEntry{field: field}, nil
+	}
+
+	p.next()
+	group := &Request{}
+	if !p.peek().is(")") {
+		entry, err := p.parseExpression()
+		if err != nil {
+			return textEntry{}, err
+		}
+		switch {
+		case entry.field != nil:
+			group.Fields = []RequestField{*entry.field}
+		case entry.parenthesized:
+			group.Groups = []Request{*entry.group}
+		default:
+			group = entry.group
+		}
+	}
+	if token := p.next(); !token.is(")") {
+		return textEntry{}, newSyntaxError(token.position, "expected ')', got %s", token)
+	}
+	return textEntry{group: group, parenthesized: true}, nil
+}
+
+func (p *textParser) parseCondition() (*RequestField, error) {
+	name, err := p.parseName("field name")
+	if err != nil {
+		return nil, err
+	}
+	field := &RequestField{Name: name}
+	for p.peek().is(".") {
+		p.next()
+		key, err := p.parseName("key")
+		if err != nil {
+			return nil, err
+		}
+		field.Keys = append(field.Keys, key)
+	}
+
+	field.Operator, err = p.parseOperator()
+	if err != nil {
+		return nil, err
+	}
+	if token := p.peek(); token.is(string(ValueMatchAny)) || token.is(string(ValueMatchAll)) {
+		p.next()
+		field.Match = ValueMatch(strings.ToLower(token.text))
+	}
+
+	if isExistenceOperator(field.Operator) && !p.isValueStart() {
+		return field, nil
+	}
+	field.Value, err = p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+func (p *textParser) parseName(expected string) (string, error) {
+	token := p.next()
+	switch {
+	case token.kind == tokenString:
+		return token.text, nil
+	case token.kind == tokenIdentifier && !isKeyword(token.text):
+		return token.text, nil
+	default:
+		return "", newSyntaxError(token.position, "expected %s, got %s", expected, token)
+	}
+}
+
+func (p *textParser) parseOperator() (CompareOperator, error) {
+	token := p.next()
+	switch token.kind {
+	case tokenSymbol:
+		for operator, symbol := range operatorSymbols {
+			if symbol == token.text {
+				return operator, nil
+			}
+		}
+	case tokenIdentifier:
+		if operator, err := ParseCompareOperator(token.text); err == nil {
+			return operator, nil
+		}
+		if !isKeyword(token.text) {
+			return "", newSyntaxError(token.position, "unknown operator %s", token)
+		}
+	}
+	return "", newSyntaxError(token.position, "expected operator, got %s", token)
+}
+
+func (p *textParser) isValueStart() bool {
+	token := p.peek()
+	return token.kind == tokenString || token.kind == tokenNumber || token.is("[") ||
+		token.is("true") || token.is("false") || token.is("null")
+}
+
+func (p *textParser) parseValue() (any, error) {
+	token := p.next()
+	switch {
+	case token.kind == tokenString:
+		return token.text, nil
+	case token.kind == tokenNumber:
+		value, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, newSyntaxError(token.position, "invalid number %s", token)
+		}
+		return value, nil
+	case token.is("true"):
+		return true, nil
+	case token.is("false"):
+		return false, nil
+	case token.is("null"):
+		return nil, nil
+	case token.is("["):
+		values := []any{}
+		if p.peek().is("]") {
+			p.next()
+			return values, nil
+		}
+		for {
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+
+			separator := p.next()
+			if separator.is("]") {
+				return values, nil
+			}
+			if !separator.is(",") {
+				return nil, newSyntaxError(separator.position, "expected ',' or ']', got %s", separator)
+			}
+		}
+	default:
+		return nil, newSyntaxError(token.position, "expected value, got %s", token)
+	}
+}
@@ -0,0 +1,269 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package filter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseText(t *testing.T) {
+	tests := map[string]struct {
+		text string
+		want *Request
+	}{
+		"empty text": {
+			text: "  ",
+			want: &Request{},
+		},
+		"single condition": {
+			text: `hostname ~ "db"`,
+			want: &Request{Fields: []RequestField{
+				{Name: "hostname", Operator: CompareOperatorContains, Value: "db"},
+			}},
+		},
+		"and chain with keys and existence operator": {
+			text: `hostname ~ "db" and severity >= 7 and tag.env exists`,
+			want: &Request{
+				Operator: LogicOperatorAnd,
+				Fields: []RequestField{
+					{Name: "hostname", Operator: CompareOperatorContains, Value: "db"},
+					{Name: "severity", Operator: CompareOperatorIsGreaterThanOrEqualTo, Value: float64(7)},
+					{Name: "tag", Keys: []string{"env"}, Operator: CompareOperatorExists},
+				},
+			},
+		},
+		"and binds stronger than or": {
+			text: `a = 1 OR b = 2 And c = 3`,
+			want: &Request{
+				Operator: LogicOperatorOr,
+				Fields:   []RequestField{{Name: "a", Operator: CompareOperatorIsEqualTo, Value: float64(1)}},
+				Groups: []Request{{
+					Operator: LogicOperatorAnd,
+					Fields: []RequestField{
+						{Name: "b", Operator: CompareOperatorIsEqualTo, Value: float64(2)},
+						{Name: "c", Operator: CompareOperatorIsEqualTo, Value: float64(3)},
+					},
+				}},
+			},
+		},
+		"parentheses create groups": {
+			text: `(a != "x" or b !~ "y") and (c < -1.5e3)`,
+			want: &Request{
+				Operator: LogicOperatorAnd,
+				Groups: []Request{
+					{
+						Operator: LogicOperatorOr,
+						Fields: []RequestField{
+							{Name: "a", Operator: CompareOperatorIsNotEqualTo, Value: "x"},
+							{Name: "b", Operator: CompareOperatorDoesNotContain, Value: "y"},
+						},
+					},
+					{Fields: []RequestField{{Name: "c", Operator: CompareOperatorIsLessThan, Value: -1500.0}}},
+				},
+			},
+		},
+		"single group": {
+			text: `(a > 1)`,
+			want: &Request{Groups: []Request{
+				{Fields: []RequestField{{Name: "a", Operator: CompareOperatorIsGreaterThan, Value: float64(1)}}},
+			}},
+		},
+		"match of list values": {
+			text: `tag = ALL ["web", "linux"]`,
+			want: &Request{Fields: []RequestField{
+				{Name: "tag", Operator: CompareOperatorIsEqualTo, Match: ValueMatchAll, Value: []any{"web", "linux"}},
+			}},
+		},
+		"operator names, quoted names and list values": {
+			text: `"host name" beginsWith ["a\"b", "c"] and tag."my key" isNotEqualTo [true, false, null, []] and x doesNotExist`,
+			want: &Request{
+				Operator: LogicOperatorAnd,
+				Fields: []RequestField{
+					{Name: "host name", Operator: CompareOperatorBeginsWith, Value: []any{`a"b`, "c"}},
+					{Name: "tag", Keys: []string{"my key"}, Operator: CompareOperatorIsNotEqualTo, Value: []any{true, false, nil, []any{}}},
+					{Name: "x", Operator: CompareOperatorDoesNotExist},
+				},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseText(tt.text)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseText_SyntaxErrors(t *testing.T) {
+	tests := map[string]struct {
+		text         string
+		wantPosition int
+		wantMessage  string
+	}{
+		"missing operator": {
+			text:         `hostname and a = 1`,
+			wantPosition: 10,
+			wantMessage:  "syntax error at position 10: expected operator, got 'and'",
+		},
+		"unknown operator": {
+			text:         `hostname like "x"`,
+			wantPosition: 10,
+			wantMessage:  "syntax error at position 10: unknown operator 'like'",
+		},
+		"missing value": {
+			text:         `a = 1 and b =`,
+			wantPosition: 14,
+			wantMessage:  "syntax error at position 14: expected value, got end of input",
+		},
+		"unterminated string": {
+			text:         `a = "x`,
+			wantPosition: 5,
+			wantMessage:  "syntax error at position 5: unterminated string",
+		},
+		"unclosed group": {
+			text:         `(a = 1 or b = 2`,
+			wantPosition: 16,
+			wantMessage:  "syntax error at position 16: expected ')', got end of input",
+		},
+		"unexpected character": {
+			text:         `a = 1 & b = 2`,
+			wantPosition: 7,
+			wantMessage:  "syntax error at position 7: unexpected character '&'",
+		},
+		"keyword as name": {
+			text:         `and = 1`,
+			wantPosition: 1,
+			wantMessage:  "syntax error at position 1: expected field name, got 'and'",
+		},
+		"missing logic operator": {
+			text:         `a = 1 b = 2`,
+			wantPosition: 7,
+			wantMessage:  "syntax error at position 7: expected 'and' or 'or', got 'b'",
+		},
+		"unclosed list": {
+			text:         `a = [1 2]`,
+			wantPosition: 8,
+			wantMessage:  "syntax error at position 8: expected ',' or ']', got '2'",
+		},
+		"invalid number": {
+			text:         `a = 1.2.3`,
+			wantPosition: 5,
+			wantMessage:  "syntax error at position 5: invalid number '1.2.3'",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseText(tt.text)
+			require.Error(t, err)
+			assert.Nil(t, got)
+
+			var validationError *ValidationError
+			require.True(t, errors.As(err, &validationError))
+			assert.Equal(t, tt.wantPosition, validationError.Position())
+			assert.Equal(t, tt.wantMessage, validationError.Error())
+		})
+	}
+}
+
+func TestFormatText(t *testing.T) {
+	tests := map[string]struct {
+		request *Request
+		want    string
+	}{
+		"nil request": {
+			request: nil,
+			want:    "",
+		},
+		"symbols, operator names and value types": {
+			request: &Request{
+				Operator: LogicOperatorAnd,
+				Fields: []RequestField{
+					{Name: "hostname", Operator: CompareOperatorContains, Value: "db"},
+					{Name: "severity", Operator: CompareOperatorIsGreaterThanOrEqualTo, Value: 7},
+					{Name: "tag", Keys: []string{"env", "a.b"}, Operator: CompareOperatorExists},
+					{Name: "or", Operator: CompareOperatorBeginsWith, Value: []string{"a", "b"}},
+				},
+				Groups: []Request{{
+					Operator: LogicOperatorOr,
+					Fields: []RequestField{
+						{Name: "a", Operator: CompareOperatorIsEqualTo, Value: 1.5},
+						{Name: "b", Operator: CompareOperatorIsNotEqualTo, Value: nil},
+					},
+				}},
+			},
+			want: `hostname ~ "db" and severity >= 7 and tag.env."a.b" exists and "or" beginsWith ["a", "b"] and (a = 1.5 or b != null)`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := FormatText(tt.request)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatText_Errors(t *testing.T) {
+	tests := map[string]*Request{
+		"missing logic operator": {
+			Fields: []RequestField{
+				{Name: "a", Operator: CompareOperatorExists},
+				{Name: "b", Operator: CompareOperatorExists},
+			},
+		},
+		"invalid compare operator": {
+			Fields: []RequestField{{Name: "a", Operator: "like", Value: "x"}},
+		},
+		"invalid match": {
+			Fields: []RequestField{{Name: "a", Operator: CompareOperatorIsEqualTo, Match: "some", Value: []any{"x"}}},
+		},
+		"unsupported value type": {
+			Fields: []RequestField{{Name: "a", Operator: CompareOperatorIsEqualTo, Value: map[string]any{}}},
+		},
+	}
+
+	for name, request := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := FormatText(request)
+			var validationError *ValidationError
+			assert.True(t, errors.As(err, &validationError))
+		})
+	}
+}
+
+func TestText_RoundTrip(t *testing.T) {
+	texts := []string{
+		`hostname ~ "db" and severity >= 7 and tag.env exists`,
+		`a = 1 or (b = 2 and c = 3)`,
+		`(a > 1)`,
+		`(c isNumberNotEqualTo -0.5 and (a = "x\ny" or b exists))`,
+		`"host name" beginsWith ["a", 1, true, null, [2]]`,
+		`a = 1 and () and (b = 2)`,
+		`tag = all ["web", "linux"] and label.env != any ["dev"]`,
+	}
+
+	for _, text := range texts {
+		t.Run(text, func(t *testing.T) {
+			request, err := ParseText(text)
+			require.NoError(t, err)
+
+			formatted, err := FormatText(request)
+			require.NoError(t, err)
+			assert.Equal(t, text, formatted)
+
+			reparsed, err := ParseText(formatted)
+			require.NoError(t, err)
+			assert.Equal(t, request, reparsed)
+		})
+	}
+}
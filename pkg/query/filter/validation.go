@@ -6,6 +6,7 @@ package filter
 
 import (
 	"errors"
+	"net/netip"
 	"strings"
 	"time"
 
@@ -13,12 +14,19 @@ import (
 	"github.com/greenbone/opensight-golang-libraries/pkg/slices"
 )
 
-// ValidateFilter validates the filter in the request
+// MaxGroupDepth is the maximum nesting depth of filter groups accepted by ValidateFilter.
+const MaxGroupDepth = 5
+
+// ValidateFilter validates the filter in the request, including all nested groups
 func ValidateFilter(request *Request, requestOptions []RequestOption) error {
 	if request == nil {
 		return nil
 	}
 
+	return validateRequest(request, requestOptions, 0)
+}
+
+func validateRequest(request *Request, requestOptions []RequestOption, depth int) error {
 	for i, field := range request.Fields {
 		fieldNameIsValid := false
 		if field.Name == "tag" {
@@ -41,6 +49,9 @@ func ValidateFilter(request *Request, requestOptions []RequestOption) error {
 				if !fieldCanHaveOperator {
 					return NewValidationError("field '%s' can not have the operator '%s'", field.Name, field.Operator)
 				}
+				if !field.Match.IsValid() {
+					return NewValidationError("field '%s' can not have the match '%s'", field.Name, field.Match)
+				}
 
 				// validate field value
 				if requestOption.MultiSelect {
@@ -82,65 +93,116 @@ func ValidateFilter(request *Request, requestOptions []RequestOption) error {
 		}
 	}
 
+	for i := range request.Groups {
+		err := validateGroup(&request.Groups[i], requestOptions, depth+1)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+func validateGroup(group *Request, requestOptions []RequestOption, depth int) error {
+	if depth > MaxGroupDepth {
+		return NewValidationError("filter groups must not be nested deeper than %d levels", MaxGroupDepth)
+	}
+	if group.IsEmpty() {
+		return NewValidationError("filter group must not be empty")
+	}
+	// for a group with a single entry `Operator` is not relevant
+	if len(group.Fields)+len(group.Groups) > 1 && !group.Operator.IsValid() {
+		return NewValidationError("filter group has invalid logic operator '%s'", group.Operator)
+	}
+
+	return validateRequest(group, requestOptions, depth)
+}
+
 func validateTagValues(request RequestField) error {
-	if len(strings.TrimSpace(request.Value.(string))) == 0 {
-		return NewValidationError("field '%s' must not be empty", request.Name)
+	_, err := checkTagValues(request)
+	return err
+}
+
+// checkTagValues validates the fields named `tag` and returns the code of a failed validation.
+func checkTagValues(request RequestField) (FieldValidationErrorCode, error) {
+	value, ok := request.Value.(string)
+	if !ok {
+		return FieldValidationErrorCodeInvalidType, NewValidationError("field '%s' must be from type 'string'", request.Name)
 	}
-	if request.Operator != CompareOperatorExists {
-		if request.Keys == nil || len(request.Keys) != 1 {
-			return NewValidationError("field '%s' number of keys must be 1", request.Name)
+	if len(strings.TrimSpace(value)) == 0 {
+		return FieldValidationErrorCodeMissingValue, NewValidationError("field '%s' must not be empty", request.Name)
+	}
+	if request.Keys == nil || len(request.Keys) != 1 {
+		return FieldValidationErrorCodeInvalidKeys, NewValidationError("field '%s' number of keys must be 1", request.Name)
+	}
+	if request.Operator == CompareOperatorExists &&
+		strings.ToLower(value) != "yes" && strings.ToLower(value) != "no" {
+		return FieldValidationErrorCodeInvalidValue, NewValidationError("for the field '%s' the value must be 'yes' or 'no'", request.Name)
+	}
+	return "", nil
+}
+
+func validateFieldValueType(requestOption RequestOption, fieldName string, fieldValue any) error {
+	_, err := checkFieldValueType(requestOption, fieldName, fieldValue)
+	return err
+}
+
+// checkFieldValueType validates the type of a single field value and returns the code of a failed validation.
+// Besides the types resulting from JSON decoding, the canonical types of [Normalizer.Normalize] are accepted.
+func checkFieldValueType(requestOption RequestOption, fieldName string, fieldValue any) (FieldValidationErrorCode, error) {
+	switch value := fieldValue.(type) {
+	case int64:
+		if requestOption.Control.Type == ControlTypeInteger {
+			return "", nil
 		}
-		if request.Value == "" {
-			return NewValidationError("field '%s' must have a value ", request.Name)
+	case uuid.UUID:
+		if requestOption.Control.Type == ControlTypeUuid {
+			return "", nil
 		}
-	} else {
-		// Check for CompareOperatorExists
-		if request.Keys == nil || len(request.Keys) != 1 {
-			return NewValidationError("field '%s' number of keys must be 1", request.Name)
+	case time.Time:
+		if requestOption.Control.Type == ControlTypeDateTime {
+			return "", nil
 		}
-
-		if strings.ToLower(request.Value.(string)) != "yes" &&
-			strings.ToLower(request.Value.(string)) != "no" {
-			return NewValidationError("for the field '%s' the value must be 'yes' or 'no'", request.Name)
+	case netip.Prefix:
+		if value.IsValid() && requestOption.Control.Type == ControlTypeString {
+			return "", nil // IP compare operators, which are allowed for string fields
+		}
+	case netip.Addr:
+		if value.IsValid() && requestOption.Control.Type == ControlTypeString {
+			return "", nil
 		}
 	}
-	return nil
-}
 
-func validateFieldValueType(requestOption RequestOption, fieldName string, fieldValue any) error {
 	switch requestOption.Control.Type {
 	case ControlTypeInteger, ControlTypeFloat:
 		if _, ok := fieldValue.(float64); !ok {
-			return NewValidationError("field '%s' must be from type '%s'", fieldName, requestOption.Control.Type)
+			return FieldValidationErrorCodeInvalidType, NewValidationError("field '%s' must be from type '%s'", fieldName, requestOption.Control.Type)
 		}
 	case ControlTypeBool:
 		if _, ok := fieldValue.(bool); !ok {
-			return NewValidationError("field '%s' must be from type '%s'", fieldName, requestOption.Control.Type)
+			return FieldValidationErrorCodeInvalidType, NewValidationError("field '%s' must be from type '%s'", fieldName, requestOption.Control.Type)
 		}
 	case ControlTypeString, ControlTypeEnum, ControlTypeUuid, ControlTypeDateTime, ControlTypeAutocomplete:
 		if _, ok := fieldValue.(string); !ok {
-			return NewValidationError("field '%s' must be from type '%s'", fieldName, requestOption.Control.Type)
+			return FieldValidationErrorCodeInvalidType, NewValidationError("field '%s' must be from type '%s'", fieldName, requestOption.Control.Type)
 		}
 		switch requestOption.Control.Type {
 		case ControlTypeEnum:
 			fieldCanHaveValue := slices.Contains(requestOption.Values, fieldValue.(string))
 			if !fieldCanHaveValue {
-				return NewValidationError("field '%s' can not have the value '%s'", fieldName, fieldValue)
+				return FieldValidationErrorCodeInvalidValue, NewValidationError("field '%s' can not have the value '%s'", fieldName, fieldValue)
 			}
 		case ControlTypeUuid:
 			if _, err := uuid.Parse(fieldValue.(string)); err != nil {
-				return NewUuidValidationError("field '%s' has an invalid UUID: %v", fieldName, fieldValue)
+				return FieldValidationErrorCodeInvalidUuid, NewUuidValidationError("field '%s' has an invalid UUID: %v", fieldName, fieldValue)
 			}
 		case ControlTypeDateTime:
 			if _, err := time.Parse(time.RFC3339, fieldValue.(string)); err != nil {
-				return NewValidationError("field '%s' must contain a valid RFC3339 time", fieldName)
+				return FieldValidationErrorCodeInvalidDateTime, NewValidationError("field '%s' must contain a valid RFC3339 time", fieldName)
 			}
 		}
 	default:
-		return NewValidationError("request option control type '%s' is not supported", requestOption.Control.Type)
+		return FieldValidationErrorCodeUnsupportedControlType, NewValidationError("request option control type '%s' is not supported", requestOption.Control.Type)
 	}
-	return nil
+	return "", nil
 }
@@ -6,8 +6,11 @@ package filter
 
 import (
 	"errors"
+	"net/netip"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -257,6 +260,125 @@ func TestRequestOptionValidation(t *testing.T) {
 		}, requestOptions)
 		require.NoError(t, err)
 	})
+
+	t.Run("shouldValidateFieldsOfNestedGroups", func(t *testing.T) {
+		setup(t)
+
+		req := &Request{
+			Operator: LogicOperatorOr,
+			Fields: []RequestField{
+				{
+					Name:     "optionNameTwo",
+					Operator: CompareOperatorIsStringEqualTo,
+					Value:    5.0,
+				},
+			},
+			Groups: []Request{
+				{
+					Operator: LogicOperatorAnd,
+					Fields: []RequestField{
+						{
+							Name:     "optionNameOne",
+							Operator: CompareOperatorContains,
+							Value:    []interface{}{" First "},
+						},
+						{
+							Name:     "optionNameThree",
+							Operator: CompareOperatorIsStringEqualTo,
+							Value:    " Second ",
+						},
+					},
+				},
+			},
+		}
+
+		err := ValidateFilter(req, requestOptions)
+		require.NoError(t, err)
+		assert.Equal(t, "First", req.Groups[0].Fields[0].Value.([]interface{})[0])
+		assert.Equal(t, "Second", req.Groups[0].Fields[1].Value)
+	})
+
+	t.Run("shouldReturnErrorOnInvalidFieldInNestedGroup", func(t *testing.T) {
+		setup(t)
+
+		err := ValidateFilter(&Request{
+			Operator: LogicOperatorAnd,
+			Groups: []Request{
+				{
+					Groups: []Request{
+						{
+							Fields: []RequestField{
+								{
+									Name:     "invalidName",
+									Operator: CompareOperatorContains,
+									Value:    "testValue",
+								},
+							},
+						},
+					},
+				},
+			},
+		}, requestOptions)
+		var validationError *ValidationError
+		assert.True(t, errors.As(err, &validationError))
+		assert.Equal(t, "field name 'invalidName' is invalid", validationError.Error())
+	})
+
+	t.Run("shouldReturnErrorOnEmptyGroup", func(t *testing.T) {
+		setup(t)
+
+		err := ValidateFilter(&Request{
+			Operator: LogicOperatorAnd,
+			Groups:   []Request{{Operator: LogicOperatorAnd}},
+		}, requestOptions)
+		var validationError *ValidationError
+		assert.True(t, errors.As(err, &validationError))
+		assert.Equal(t, "filter group must not be empty", validationError.Error())
+	})
+
+	t.Run("shouldReturnErrorOnInvalidGroupOperator", func(t *testing.T) {
+		setup(t)
+
+		field := RequestField{
+			Name:     "optionNameThree",
+			Operator: CompareOperatorIsStringEqualTo,
+			Value:    "value",
+		}
+		err := ValidateFilter(&Request{
+			Operator: LogicOperatorAnd,
+			Groups: []Request{
+				{
+					Operator: "xor",
+					Fields:   []RequestField{field, field},
+				},
+			},
+		}, requestOptions)
+		var validationError *ValidationError
+		assert.True(t, errors.As(err, &validationError))
+		assert.Equal(t, "filter group has invalid logic operator 'xor'", validationError.Error())
+	})
+
+	t.Run("shouldReturnErrorOnTooDeeplyNestedGroups", func(t *testing.T) {
+		setup(t)
+
+		req := Request{
+			Fields: []RequestField{
+				{
+					Name:     "optionNameThree",
+					Operator: CompareOperatorIsStringEqualTo,
+					Value:    "value",
+				},
+			},
+		}
+		for range MaxGroupDepth + 1 {
+			req = Request{Groups: []Request{req}}
+		}
+
+		err := ValidateFilter(&req, requestOptions)
+		var validationError *ValidationError
+		assert.True(t, errors.As(err, &validationError))
+		assert.Equal(t, "filter groups must not be nested deeper than 5 levels", validationError.Error())
+	})
 }
 
 func TestCascadeRequestOption(t *testing.T) {
@@ -654,3 +776,42 @@ func TestBoolRequestOption(t *testing.T) {
 		assert.Equal(t, validationError.Error(), "field 'optionName' must be from type 'bool'")
 	})
 }
+
+func TestNormalizedValueValidation(t *testing.T) {
+	requestOption := func(controlType ControlType) RequestOption {
+		return RequestOption{
+			Name:    ReadableValue[string]{Value: "optionName"},
+			Control: RequestOptionType{Type: controlType},
+			Values:  []string{"First"},
+		}
+	}
+
+	tests := map[string]struct {
+		controlType ControlType
+		value       any
+		wantErr     bool
+	}{
+		"ip address on string field":   {controlType: ControlTypeString, value: netip.MustParseAddr("10.0.0.1")},
+		"ip network on string field":   {controlType: ControlTypeString, value: netip.MustParsePrefix("10.0.0.0/8")},
+		"uuid on uuid field":           {controlType: ControlTypeUuid, value: uuid.MustParse("9bd5c4ea-5b0c-4a4f-a2a0-5a0ff0a3e1d2")},
+		"time on dateTime field":       {controlType: ControlTypeDateTime, value: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		"integer on integer field":     {controlType: ControlTypeInteger, value: int64(1)},
+		"ip address on enum field":     {controlType: ControlTypeEnum, value: netip.MustParseAddr("10.0.0.1"), wantErr: true},
+		"ip network on integer field":  {controlType: ControlTypeInteger, value: netip.MustParsePrefix("10.0.0.0/8"), wantErr: true},
+		"ip address on bool field":     {controlType: ControlTypeBool, value: netip.MustParseAddr("10.0.0.1"), wantErr: true},
+		"invalid ip address on string": {controlType: ControlTypeString, value: netip.Addr{}, wantErr: true},
+		"uuid on string field":         {controlType: ControlTypeString, value: uuid.MustParse("9bd5c4ea-5b0c-4a4f-a2a0-5a0ff0a3e1d2"), wantErr: true},
+		"time on autocomplete field":   {controlType: ControlTypeAutocomplete, value: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateFieldValueType(requestOption(tt.controlType), "optionName", tt.value)
+			if tt.wantErr {
+				var validationError *ValidationError
+				assert.True(t, errors.As(err, &validationError))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
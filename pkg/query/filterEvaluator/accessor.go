@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package filterEvaluator
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filterSchema"
+)
+
+// StructFieldAccessor returns an accessor for items of struct type T (or pointer to struct).
+//
+// A filter field refers to the exported struct field with the same name, which is (in this order) the `name`
+// in the `filter` tag (see [filterSchema]), the name in the `json` tag or the name of the struct field.
+// Fields of embedded structs are included, fields with tag `filter:"-"` are ignored.
+//
+// The keys of a filter field are used to look up values of nested maps with string keys.
+func StructFieldAccessor[T any]() (FieldAccessor[T], error) {
+	itemType := reflect.TypeFor[T]()
+	for itemType.Kind() == reflect.Pointer {
+		itemType = itemType.Elem()
+	}
+	if itemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("item must be a struct or a pointer to a struct, got %s", reflect.TypeFor[T]())
+	}
+
+	indices := make(map[string][]int)
+	collectFieldIndices(itemType, nil, indices)
+
+	return func(item T, name string, keys []string) (any, error) {
+		index, ok := indices[name]
+		if !ok {
+			return nil, filter.NewInvalidFilterFieldError(
+				"invalid filter field '%s', available fields: %s", name,
+				strings.Join(slices.Sorted(maps.Keys(indices)), ", "))
+		}
+
+		value := reflect.ValueOf(item)
+		for value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				return nil, nil
+			}
+			value = value.Elem()
+		}
+		value, err := value.FieldByIndexErr(index)
+		if err != nil {
+			return nil, nil // embedded struct pointer is nil
+		}
+
+		for _, key := range keys {
+			for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+				if value.IsNil() {
+					return nil, nil
+				}
+				value = value.Elem()
+			}
+			if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String {
+				return nil, fmt.Errorf("field '%s' of type %s does not support keys", name, value.Type())
+			}
+			value = value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key()))
+			if !value.IsValid() {
+				return nil, nil
+			}
+		}
+		return value.Interface(), nil
+	}, nil
+}
+
+func collectFieldIndices(structType reflect.Type, parentIndex []int, indices map[string][]int) {
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		index := append(slices.Clone(parentIndex), i)
+		tag, hasTag := structField.Tag.Lookup(filterSchema.TagName)
+		if tag == "-" {
+			continue
+		}
+
+		embeddedType := structField.Type
+		if embeddedType.Kind() == reflect.Pointer {
+			embeddedType = embeddedType.Elem()
+		}
+		if !hasTag && structField.Anonymous && embeddedType.Kind() == reflect.Struct {
+			collectFieldIndices(embeddedType, index, indices)
+			continue
+		}
+		if !structField.IsExported() {
+			continue
+		}
+
+		name := fieldNameOf(structField, tag)
+		if name == "" {
+			continue
+		}
+		// fields of the outer struct take precedence over fields of embedded structs
+		if existing, exists := indices[name]; !exists || len(index) < len(existing) {
+			indices[name] = index
+		}
+	}
+}
+
+func fieldNameOf(structField reflect.StructField, filterTag string) string {
+	for _, entry := range strings.Split(filterTag, ",") {
+		if key, value, _ := strings.Cut(strings.TrimSpace(entry), "="); key == "name" && value != "" {
+			return value
+		}
+	}
+	jsonName, _, _ := strings.Cut(structField.Tag.Get("json"), ",")
+	switch jsonName {
+	case "-":
+		return ""
+	case "":
+		return structField.Name
+	default:
+		return jsonName
+	}
+}
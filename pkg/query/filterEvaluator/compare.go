@@ -0,0 +1,442 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package filterEvaluator
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+)
+
+// condition checks a single (non-nil) field value.
+type condition func(fieldValue any) (bool, error)
+
+// conditionOf translates the filter field into a condition, which is met if the field value matches any of the
+// values. If `negate` is true, the field matches if the condition is not met.
+// The values are validated upfront, so that invalid filters are reported independent of the field value.
+func (e *Evaluator[T]) conditionOf(field filter.RequestField, values []any) (check condition, negate bool, err error) {
+	switch field.Operator {
+	case filter.CompareOperatorIsEqualTo:
+		return anyValue(values, compareWith(isEqual)), false, nil
+	case filter.CompareOperatorIsNotEqualTo:
+		return anyValue(values, compareWith(isEqual)), true, nil
+	case filter.CompareOperatorIsLessThan:
+		return anyValue(values, compareWith(func(c int) bool { return c < 0 })), false, nil
+	case filter.CompareOperatorIsLessThanOrEqualTo:
+		return anyValue(values, compareWith(func(c int) bool { return c <= 0 })), false, nil
+	case filter.CompareOperatorIsGreaterThan:
+		return anyValue(values, compareWith(func(c int) bool { return c > 0 })), false, nil
+	case filter.CompareOperatorIsGreaterThanOrEqualTo:
+		return anyValue(values, compareWith(func(c int) bool { return c >= 0 })), false, nil
+	case filter.CompareOperatorIsNumberEqualTo, filter.CompareOperatorIsNumberNotEqualTo:
+		check, err = numberCondition(field.Operator, values)
+		return check, field.Operator == filter.CompareOperatorIsNumberNotEqualTo, err
+	case filter.CompareOperatorIsStringEqualTo:
+		check, err = stringCondition(field.Operator, values, func(s, v string) bool { return s == v })
+		return check, false, err
+	case filter.CompareOperatorIsStringNotEqualTo:
+		check, err = stringCondition(field.Operator, values, func(s, v string) bool { return s == v })
+		return check, true, err
+	case filter.CompareOperatorIsStringCaseInsensitiveEqualTo:
+		check, err = stringCondition(field.Operator, values, strings.EqualFold)
+		return check, false, err
+	case filter.CompareOperatorContains, filter.CompareOperatorDoesNotContain:
+		check, err = stringCondition(field.Operator, values, func(s, v string) bool {
+			return strings.Contains(strings.ToLower(s), strings.ToLower(v))
+		})
+		return check, field.Operator == filter.CompareOperatorDoesNotContain, err
+	case filter.CompareOperatorBeginsWith, filter.CompareOperatorDoesNotBeginWith:
+		check, err = stringCondition(field.Operator, values, func(s, v string) bool {
+			return strings.HasPrefix(strings.ToLower(s), strings.ToLower(v))
+		})
+		return check, field.Operator == filter.CompareOperatorDoesNotBeginWith, err
+	case filter.CompareOperatorTextContains:
+		check, err = stringCondition(field.Operator, values, containsWords)
+		return check, false, err
+	case filter.CompareOperatorIsIpEqualTo, filter.CompareOperatorIsIpNotEqualTo:
+		check, err = ipCondition(field.Operator, values)
+		return check, field.Operator == filter.CompareOperatorIsIpNotEqualTo, err
+	case filter.CompareOperatorBeforeDate:
+		check, err = dayCondition(field.Operator, values, func(c int) bool { return c < 0 })
+		return check, false, err
+	case filter.CompareOperatorAfterDate:
+		check, err = dayCondition(field.Operator, values, func(c int) bool { return c > 0 })
+		return check, false, err
+	case filter.CompareOperatorBetweenDates:
+		check, err = betweenDatesCondition(field.Operator, values)
+		return check, false, err
+	case filter.CompareOperatorIsEqualToRating, filter.CompareOperatorIsNotEqualToRating:
+		check, err = e.ratingCondition(field, values, func(number float64, r numberRange) bool {
+			return number >= r.Min && number <= r.Max
+		})
+		return check, field.Operator == filter.CompareOperatorIsNotEqualToRating, err
+	case filter.CompareOperatorIsLessThanRating:
+		check, err = e.ratingCondition(field, values, func(number float64, r numberRange) bool { return number < r.Min })
+		return check, false, err
+	case filter.CompareOperatorIsLessThanOrEqualToRating:
+		check, err = e.ratingCondition(field, values, func(number float64, r numberRange) bool { return number <= r.Max })
+		return check, false, err
+	case filter.CompareOperatorIsGreaterThanRating:
+		check, err = e.ratingCondition(field, values, func(number float64, r numberRange) bool { return number > r.Max })
+		return check, false, err
+	case filter.CompareOperatorIsGreaterThanOrEqualToRating:
+		check, err = e.ratingCondition(field, values, func(number float64, r numberRange) bool { return number >= r.Min })
+		return check, false, err
+	default:
+		return nil, false, fmt.Errorf("unknown operator '%s'", field.Operator)
+	}
+}
+
+// anyValue returns a condition which is met if the check is met for any of the values.
+func anyValue(values []any, check func(fieldValue, value any) (bool, error)) condition {
+	return func(fieldValue any) (bool, error) {
+		for _, value := range values {
+			matches, err := check(fieldValue, value)
+			if err != nil || matches {
+				return matches, err
+			}
+		}
+		return false, nil
+	}
+}
+
+func isEqual(c int) bool { return c == 0 }
+
+func compareWith(accept func(c int) bool) func(fieldValue, value any) (bool, error) {
+	return func(fieldValue, value any) (bool, error) {
+		c, err := compareValues(fieldValue, value)
+		if err != nil {
+			return false, err
+		}
+		return accept(c), nil
+	}
+}
+
+// compareValues compares the field value with the filter value. The filter value is converted to the type of
+// the field value if needed, like a database does with query parameters. Numbers are compared by value, dates
+// can be given as time.Time or RFC3339 formatted string.
+func compareValues(fieldValue, value any) (int, error) {
+	value = dereference(value)
+	if value == nil {
+		return 0, errors.New("can not compare with nil value")
+	}
+
+	switch fv := fieldValue.(type) {
+	case time.Time:
+		t, err := toTime(value)
+		if err != nil {
+			return 0, err
+		}
+		return fv.Compare(t), nil
+	case bool:
+		b, ok := value.(bool)
+		if !ok {
+			return 0, fmt.Errorf("can not compare %T field with %T value", fieldValue, value)
+		}
+		return cmp.Compare(boolToInt(fv), boolToInt(b)), nil
+	}
+
+	if number, ok := toNumber(fieldValue); ok {
+		if n, ok := toNumber(value); ok {
+			return cmp.Compare(number, n), nil
+		}
+		if s, ok := toString(value); ok {
+			n, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return 0, fmt.Errorf("can not compare number field with value '%s'", s)
+			}
+			return cmp.Compare(number, n), nil
+		}
+		return 0, fmt.Errorf("can not compare %T field with %T value", fieldValue, value)
+	}
+
+	if s, ok := toFieldString(fieldValue); ok {
+		v, ok := toFieldString(value)
+		if !ok {
+			return 0, fmt.Errorf("can not compare %T field with %T value", fieldValue, value)
+		}
+		return strings.Compare(s, v), nil
+	}
+	return 0, fmt.Errorf("unsupported field value type %T", fieldValue)
+}
+
+func numberCondition(operator filter.CompareOperator, values []any) (condition, error) {
+	numbers := make([]float64, 0, len(values))
+	for _, value := range values {
+		number, ok := toNumber(value)
+		if !ok {
+			return nil, fmt.Errorf("operator '%s' requires number values, got %T", operator, value)
+		}
+		numbers = append(numbers, number)
+	}
+	return func(fieldValue any) (bool, error) {
+		number, ok := toNumber(fieldValue)
+		if !ok {
+			return false, fmt.Errorf("operator '%s' requires a number field, got %T", operator, fieldValue)
+		}
+		return slices.Contains(numbers, number), nil
+	}, nil
+}
+
+func stringCondition(operator filter.CompareOperator, values []any, check func(fieldValue, value string) bool) (condition, error) {
+	strValues := make([]string, 0, len(values))
+	for _, value := range values {
+		strValue, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("operator '%s' requires string values, got %T", operator, value)
+		}
+		strValues = append(strValues, strValue)
+	}
+	return func(fieldValue any) (bool, error) {
+		s, ok := toFieldString(fieldValue)
+		if !ok {
+			return false, fmt.Errorf("operator '%s' requires a string field, got %T", operator, fieldValue)
+		}
+		return slices.ContainsFunc(strValues, func(value string) bool { return check(s, value) }), nil
+	}, nil
+}
+
+// containsWords returns true if the text contains all words of the query, in any order. Like the `simple`
+// text search configuration of the postgres query builder, words are compared case-insensitive and without stemming.
+func containsWords(text, query string) bool {
+	queryWords := words(query)
+	if len(queryWords) == 0 {
+		return false // an empty text search query matches nothing
+	}
+	textWords := words(text)
+	for _, word := range queryWords {
+		if !slices.Contains(textWords, word) {
+			return false
+		}
+	}
+	return true
+}
+
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// ipCondition matches IP addresses. A value can either be a single IP address or a network in CIDR notation,
+// as string or as [netip.Addr] and [netip.Prefix] respectively. For a network all addresses within it are matched.
+func ipCondition(operator filter.CompareOperator, values []any) (condition, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		switch v := value.(type) {
+		case netip.Prefix:
+			prefixes = append(prefixes, v.Masked())
+			continue
+		case netip.Addr:
+			prefixes = append(prefixes, netip.PrefixFrom(v, v.BitLen()))
+			continue
+		}
+		strValue, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("operator '%s' requires string values, got %T", operator, value)
+		}
+		prefix, err := parsePrefix(strValue)
+		if err != nil {
+			return nil, fmt.Errorf("operator '%s' requires IP addresses or networks in CIDR notation, got '%s'",
+				operator, strValue)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return func(fieldValue any) (bool, error) {
+		s, ok := toFieldString(fieldValue)
+		if !ok {
+			return false, fmt.Errorf("operator '%s' requires an IP address field, got %T", operator, fieldValue)
+		}
+		fieldPrefix, err := parsePrefix(s)
+		if err != nil {
+			return false, fmt.Errorf("invalid IP address '%s' in field", s)
+		}
+		return slices.ContainsFunc(prefixes, func(prefix netip.Prefix) bool {
+			return prefix.Bits() <= fieldPrefix.Bits() && prefix.Contains(fieldPrefix.Addr())
+		}), nil
+	}, nil
+}
+
+// parsePrefix parses an IP address, which is returned as single address network, or a network in CIDR notation.
+func parsePrefix(s string) (netip.Prefix, error) {
+	if addr, err := netip.ParseAddr(s); err == nil {
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return prefix.Masked(), nil
+}
+
+// dayCondition compares the day (in UTC) of the field value with the day of the values.
+func dayCondition(operator filter.CompareOperator, values []any, accept func(c int) bool) (condition, error) {
+	days := make([]time.Time, 0, len(values))
+	for _, value := range values {
+		switch value.(type) {
+		case string, time.Time:
+		default:
+			return nil, fmt.Errorf("operator '%s' requires a string or time.Time value, got: %T", operator, value)
+		}
+		t, err := toTime(value)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, truncateToDay(t))
+	}
+	return func(fieldValue any) (bool, error) {
+		t, err := toTime(fieldValue)
+		if err != nil {
+			return false, fmt.Errorf("operator '%s' requires a date field: %w", operator, err)
+		}
+		fieldDay := truncateToDay(t)
+		return slices.ContainsFunc(days, func(day time.Time) bool { return accept(fieldDay.Compare(day)) }), nil
+	}, nil
+}
+
+func truncateToDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// betweenDatesCondition requires a list of exactly two dates, the start and the end of the range.
+// Both bounds are inclusive.
+func betweenDatesCondition(operator filter.CompareOperator, values []any) (condition, error) {
+	if len(values) != 2 {
+		return nil, fmt.Errorf("operator '%s' requires a list of two dates, got: %v", operator, values)
+	}
+	bounds := make([]time.Time, 0, 2)
+	for _, value := range values {
+		switch value.(type) {
+		case string, time.Time:
+		default:
+			return nil, fmt.Errorf("operator '%s' requires a string or time.Time value, got: %T", operator, value)
+		}
+		t, err := toTime(value)
+		if err != nil {
+			return nil, err
+		}
+		bounds = append(bounds, t)
+	}
+	return func(fieldValue any) (bool, error) {
+		t, err := toTime(fieldValue)
+		if err != nil {
+			return false, fmt.Errorf("operator '%s' requires a date field: %w", operator, err)
+		}
+		return !t.Before(bounds[0]) && !t.After(bounds[1]), nil
+	}, nil
+}
+
+// numberRange is a [filter.RatingRange] converted to float64 values.
+type numberRange struct {
+	Min float64
+	Max float64
+}
+
+// newNumberRange converts the bounds of the rating range by their decimal representation, so that e.g. a bound
+// of 0.1 stays 0.1 instead of 0.10000000149011612.
+func newNumberRange(r filter.RatingRange) numberRange {
+	toFloat64 := func(value float32) float64 {
+		converted, _ := strconv.ParseFloat(strconv.FormatFloat(float64(value), 'g', -1, 32), 64)
+		return converted
+	}
+	return numberRange{Min: toFloat64(r.Min), Max: toFloat64(r.Max)}
+}
+
+// ratingCondition translates each value, which is the name of a rating, to its numeric range and checks the
+// number of the field against it.
+func (e *Evaluator[T]) ratingCondition(field filter.RequestField, values []any,
+	check func(number float64, r numberRange) bool,
+) (condition, error) {
+	ranges := make([]numberRange, 0, len(values))
+	for _, value := range values {
+		rating, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("operator '%s' requires string values, got %T", field.Operator, value)
+		}
+		ratingRange, ok := e.stringFieldRating[field.Name][rating]
+		if !ok {
+			return nil, fmt.Errorf("unknown rating '%s'", rating)
+		}
+		ranges = append(ranges, newNumberRange(ratingRange))
+	}
+	return func(fieldValue any) (bool, error) {
+		number, ok := toNumber(fieldValue)
+		if !ok {
+			return false, fmt.Errorf("operator '%s' requires a number field, got %T", field.Operator, fieldValue)
+		}
+		return slices.ContainsFunc(ranges, func(r numberRange) bool { return check(number, r) }), nil
+	}, nil
+}
+
+func toNumber(value any) (float64, bool) {
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(reflectValue.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(reflectValue.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return reflectValue.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// toString returns the value of strings, including types derived from string like enums.
+func toString(value any) (string, bool) {
+	reflectValue := reflect.ValueOf(value)
+	if reflectValue.Kind() != reflect.String {
+		return "", false
+	}
+	return reflectValue.String(), true
+}
+
+// toFieldString is like toString, but additionally accepts values implementing fmt.Stringer
+// like uuid.UUID or netip.Addr.
+func toFieldString(value any) (string, bool) {
+	if s, ok := toString(value); ok {
+		return s, true
+	}
+	if stringer, ok := value.(fmt.Stringer); ok {
+		return stringer.String(), true
+	}
+	return "", false
+}
+
+// toTime returns time.Time values or parses strings in RFC3339 format or as date (`2006-01-02`).
+func toTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date string format: %s", v)
+		}
+		return t, nil
+	default:
+		return time.Time{}, fmt.Errorf("expected a string or time.Time value, got: %T", value)
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
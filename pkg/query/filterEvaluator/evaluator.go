@@ -0,0 +1,243 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package filterEvaluator evaluates filter requests against Go values in memory, e.g. for cached results,
+// webhook payloads or test fixtures.
+//
+// The compare operators have the same semantics as in the postgres query builder. A missing (nil) field value
+// behaves like a database NULL: it matches neither a comparison nor its negation, only `exists` and `doesNotExist`
+// take it into account. Additionally, if the field value is a slice, a comparison matches if any of the elements
+// match, like for arrays in OpenSearch documents. With [filter.ValueMatchAll] each of the filter values needs to
+// match. This way the evaluator can also serve as oracle in tests of the query builders.
+package filterEvaluator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+)
+
+// FieldAccessor returns the value of the filter field with the given name of the item. `keys` are the keys
+// of a field with nested key structure, see [filter.RequestField]. A missing value is returned as nil.
+// Unknown fields should be reported with [filter.InvalidFilterFieldError].
+type FieldAccessor[T any] func(item T, name string, keys []string) (value any, err error)
+
+// Evaluator evaluates filter requests against items of type T.
+type Evaluator[T any] struct {
+	accessor          FieldAccessor[T]
+	stringFieldRating map[string]map[string]filter.RatingRange
+}
+
+// NewEvaluator creates an evaluator reading the field values of an item with the given accessor.
+func NewEvaluator[T any](accessor FieldAccessor[T]) *Evaluator[T] {
+	return &Evaluator[T]{accessor: accessor}
+}
+
+// NewStructEvaluator creates an evaluator for items of struct type T (or pointer to struct),
+// reading the field values with [StructFieldAccessor].
+func NewStructEvaluator[T any]() (*Evaluator[T], error) {
+	accessor, err := StructFieldAccessor[T]()
+	if err != nil {
+		return nil, err
+	}
+	return NewEvaluator(accessor), nil
+}
+
+// WithStringFieldRating sets the ratings of filter fields, which are needed to evaluate the `*Rating` compare
+// operators. A rating is a named range of numeric values, e.g. `high` for a severity between 7.0 and 8.9.
+func (e *Evaluator[T]) WithStringFieldRating(ratings map[string]map[string]filter.RatingRange) *Evaluator[T] {
+	e.stringFieldRating = ratings
+	return e
+}
+
+// Matches returns true if the item matches the filter request. An empty request matches every item.
+func (e *Evaluator[T]) Matches(request *filter.Request, item T) (bool, error) {
+	if request.IsEmpty() {
+		return true, nil
+	}
+	return e.matchesRequest(request, item)
+}
+
+// Filter returns the items matching the filter request, keeping their order.
+func (e *Evaluator[T]) Filter(request *filter.Request, items []T) ([]T, error) {
+	var result []T
+	for _, item := range items {
+		matches, err := e.Matches(request, item)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+func (e *Evaluator[T]) matchesRequest(request *filter.Request, item T) (bool, error) {
+	operator := request.Operator
+	if operator == "" && len(request.Fields)+len(request.Groups) == 1 { // for single filter `Operator` is not relevant
+		operator = filter.LogicOperatorAnd
+	}
+	if operator != filter.LogicOperatorAnd && operator != filter.LogicOperatorOr {
+		return false, fmt.Errorf("invalid filter logic operator: %s", request.Operator)
+	}
+
+	// evaluate all entries, so that invalid filters are reported independent of the item
+	result := operator == filter.LogicOperatorAnd
+	combine := func(matches bool) {
+		if operator == filter.LogicOperatorAnd {
+			result = result && matches
+		} else {
+			result = result || matches
+		}
+	}
+	for _, field := range request.Fields {
+		matches, err := e.matchesField(field, item)
+		if err != nil {
+			return false, fmt.Errorf("error evaluating filter field %q: %w", field.Name, err)
+		}
+		combine(matches)
+	}
+	for _, group := range request.Groups {
+		// empty groups are invalid, see [filter.Request.IsEmpty]
+		if group.IsEmpty() {
+			return false, errors.New("filter group must not be empty")
+		}
+		matches, err := e.matchesRequest(&group, item)
+		if err != nil {
+			return false, err
+		}
+		combine(matches)
+	}
+	return result, nil
+}
+
+func (e *Evaluator[T]) matchesField(field filter.RequestField, item T) (bool, error) {
+	fieldValue, err := e.accessor(item, field.Name, field.Keys)
+	if err != nil {
+		return false, err
+	}
+	fieldValue = dereference(fieldValue)
+
+	switch field.Operator {
+	case filter.CompareOperatorExists:
+		return fieldValue != nil, nil
+	case filter.CompareOperatorDoesNotExist:
+		return fieldValue == nil, nil
+	}
+
+	values, err := sanitizeFilterValue(field.Value)
+	if err != nil {
+		return false, err
+	}
+	if field.Match == filter.ValueMatchAll {
+		return e.matchesAllValues(field, values, fieldValue)
+	}
+	condition, negate, err := e.conditionOf(field, values)
+	if err != nil {
+		return false, err
+	}
+	if fieldValue == nil {
+		return false, nil // like NULL in a database, a missing value matches neither the condition nor its negation
+	}
+
+	matches, err := matchesAny(fieldValue, condition)
+	if err != nil {
+		return false, err
+	}
+	return matches != negate, nil
+}
+
+// matchesAllValues checks whether each of the filter values matches the field value, or an element of it if it is
+// a slice. Negated operators match if not all of the values match.
+func (e *Evaluator[T]) matchesAllValues(field filter.RequestField, values []any, fieldValue any) (bool, error) {
+	matchesAll, negate := true, false
+	for _, value := range values {
+		condition, negateCondition, err := e.conditionOf(field, []any{value})
+		if err != nil {
+			return false, err
+		}
+		negate = negateCondition
+		if fieldValue == nil || !matchesAll {
+			continue
+		}
+		matchesAll, err = matchesAny(fieldValue, condition)
+		if err != nil {
+			return false, err
+		}
+	}
+	if fieldValue == nil {
+		return false, nil
+	}
+	return matchesAll != negate, nil
+}
+
+// matchesAny applies the condition to the field value, or to each element if the field value is a slice.
+// Arrays are treated as single value, as they are typically used for fixed size values like UUIDs.
+func matchesAny(fieldValue any, condition func(fieldValue any) (bool, error)) (bool, error) {
+	reflectValue := reflect.ValueOf(fieldValue)
+	if _, isBytes := fieldValue.([]byte); isBytes || reflectValue.Kind() != reflect.Slice {
+		return condition(fieldValue)
+	}
+	for i := range reflectValue.Len() {
+		element := dereference(reflectValue.Index(i).Interface())
+		if element == nil {
+			continue
+		}
+		matches, err := condition(element)
+		if err != nil || matches {
+			return matches, err
+		}
+	}
+	return false, nil
+}
+
+// sanitizeFilterValue returns the filter value as list of values. Like the query builders it disallows
+// nil values and empty lists.
+func sanitizeFilterValue(value any) ([]any, error) {
+	if value == nil {
+		return nil, errors.New("field has nil value")
+	}
+	if values, ok := value.([]any); ok {
+		if len(values) == 0 {
+			return nil, errors.New("field has empty list of values")
+		}
+		return values, nil
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	if _, isBytes := value.([]byte); isBytes || reflectValue.Kind() != reflect.Slice {
+		return []any{value}, nil
+	}
+	if reflectValue.Len() == 0 {
+		return nil, errors.New("field has empty list of values")
+	}
+	values := make([]any, reflectValue.Len())
+	for i := range values {
+		values[i] = reflectValue.Index(i).Interface()
+	}
+	return values, nil
+}
+
+// dereference resolves pointers and interfaces. It returns nil for nil pointers, slices and maps.
+func dereference(value any) any {
+	reflectValue := reflect.ValueOf(value)
+	for reflectValue.Kind() == reflect.Pointer || reflectValue.Kind() == reflect.Interface {
+		if reflectValue.IsNil() {
+			return nil
+		}
+		reflectValue = reflectValue.Elem()
+	}
+	switch reflectValue.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Slice, reflect.Map:
+		if reflectValue.IsNil() {
+			return nil
+		}
+	}
+	return reflectValue.Interface()
+}
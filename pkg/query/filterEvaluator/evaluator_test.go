@@ -0,0 +1,283 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package filterEvaluator
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLabels map[string]string
+
+type testBase struct {
+	ID string `json:"id"`
+}
+
+type testAsset struct {
+	testBase
+	Hostname  string     `json:"hostname"`
+	Severity  *float64   `json:"severity,omitempty"`
+	Port      int        `json:"port"`
+	IP        string     `json:"ip"`
+	Tags      []string   `json:"tags"`
+	Labels    testLabels `json:"labels"`
+	Active    bool       `json:"active"`
+	Created   time.Time  `json:"created" filter:"name=createdAt,control=dateTime,operators=afterDate"`
+	Internal  string     `json:"internal" filter:"-"`
+	Unchanged string
+}
+
+func ptr[T any](v T) *T { return &v }
+
+var testAssets = []testAsset{
+	{
+		testBase: testBase{ID: "1"},
+		Hostname: "DB-server.example.com", Severity: ptr(7.5), Port: 5432, IP: "192.168.0.10",
+		Tags: []string{"prod", "db"}, Labels: testLabels{"env": "prod"}, Active: true,
+		Created: time.Date(2025, 3, 10, 15, 30, 0, 0, time.UTC),
+	},
+	{
+		testBase: testBase{ID: "2"},
+		Hostname: "web server", Severity: ptr(2.0), Port: 443, IP: "10.0.0.1",
+		Labels:  testLabels{"env": "dev"},
+		Created: time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		testBase: testBase{ID: "3"},
+		Hostname: "mail", Port: 25, IP: "2001:db8::1",
+		Tags:    []string{"mail"},
+		Created: time.Date(2025, 3, 12, 23, 59, 59, 0, time.UTC),
+	},
+}
+
+var testRatings = map[string]map[string]filter.RatingRange{
+	"severity": {
+		"low":    {Min: 0.1, Max: 3.9},
+		"medium": {Min: 4.0, Max: 6.9},
+		"high":   {Min: 7.0, Max: 8.9},
+	},
+}
+
+func TestEvaluator_Filter(t *testing.T) {
+	evaluator, err := NewStructEvaluator[testAsset]()
+	require.NoError(t, err)
+	evaluator.WithStringFieldRating(testRatings)
+
+	field := func(name string, operator filter.CompareOperator, value any, keys ...string) *filter.Request {
+		return &filter.Request{Fields: []filter.RequestField{{Name: name, Keys: keys, Operator: operator, Value: value}}}
+	}
+
+	tests := map[string]struct {
+		request *filter.Request
+		wantIDs []string
+	}{
+		"empty request":             {request: &filter.Request{}, wantIDs: []string{"1", "2", "3"}},
+		"equal to":                  {request: field("hostname", filter.CompareOperatorIsEqualTo, "mail"), wantIDs: []string{"3"}},
+		"equal to any value":        {request: field("port", filter.CompareOperatorIsEqualTo, []any{25, 443.0}), wantIDs: []string{"2", "3"}},
+		"not equal to":              {request: field("port", filter.CompareOperatorIsNotEqualTo, []any{25, 443}), wantIDs: []string{"1"}},
+		"not equal excludes null":   {request: field("severity", filter.CompareOperatorIsNotEqualTo, 7.5), wantIDs: []string{"2"}},
+		"equal to bool":             {request: field("active", filter.CompareOperatorIsEqualTo, true), wantIDs: []string{"1"}},
+		"equal to embedded field":   {request: field("id", filter.CompareOperatorIsEqualTo, "2"), wantIDs: []string{"2"}},
+		"field without tags":        {request: field("Unchanged", filter.CompareOperatorIsEqualTo, ""), wantIDs: []string{"1", "2", "3"}},
+		"number equal to":           {request: field("severity", filter.CompareOperatorIsNumberEqualTo, 2), wantIDs: []string{"2"}},
+		"number not equal to":       {request: field("port", filter.CompareOperatorIsNumberNotEqualTo, 25), wantIDs: []string{"1", "2"}},
+		"string equal to":           {request: field("hostname", filter.CompareOperatorIsStringEqualTo, "Mail"), wantIDs: nil},
+		"string not equal to":       {request: field("hostname", filter.CompareOperatorIsStringNotEqualTo, "mail"), wantIDs: []string{"1", "2"}},
+		"string case insensitive":   {request: field("hostname", filter.CompareOperatorIsStringCaseInsensitiveEqualTo, "MAIL"), wantIDs: []string{"3"}},
+		"less than":                 {request: field("port", filter.CompareOperatorIsLessThan, 443), wantIDs: []string{"3"}},
+		"less than or equal":        {request: field("port", filter.CompareOperatorIsLessThanOrEqualTo, 443), wantIDs: []string{"2", "3"}},
+		"greater than":              {request: field("severity", filter.CompareOperatorIsGreaterThan, 2), wantIDs: []string{"1"}},
+		"greater than or equal":     {request: field("severity", filter.CompareOperatorIsGreaterThanOrEqualTo, 2), wantIDs: []string{"1", "2"}},
+		"greater than date":         {request: field("createdAt", filter.CompareOperatorIsGreaterThan, "2025-03-11T00:00:00Z"), wantIDs: []string{"3"}},
+		"contains":                  {request: field("hostname", filter.CompareOperatorContains, "SERVER"), wantIDs: []string{"1", "2"}},
+		"does not contain":          {request: field("hostname", filter.CompareOperatorDoesNotContain, []any{"db", "mail"}), wantIDs: []string{"2"}},
+		"begins with":               {request: field("hostname", filter.CompareOperatorBeginsWith, "db"), wantIDs: []string{"1"}},
+		"does not begin with":       {request: field("hostname", filter.CompareOperatorDoesNotBeginWith, "db"), wantIDs: []string{"2", "3"}},
+		"text contains":             {request: field("hostname", filter.CompareOperatorTextContains, "example DB"), wantIDs: []string{"1"}},
+		"text contains words":       {request: field("hostname", filter.CompareOperatorTextContains, "serv"), wantIDs: nil},
+		"ip equal to address":       {request: field("ip", filter.CompareOperatorIsIpEqualTo, "10.0.0.1"), wantIDs: []string{"2"}},
+		"ip equal to network":       {request: field("ip", filter.CompareOperatorIsIpEqualTo, []any{"192.168.0.0/24", "2001:db8::/32"}), wantIDs: []string{"1", "3"}},
+		"ip equal to typed network": {request: field("ip", filter.CompareOperatorIsIpEqualTo, netip.MustParsePrefix("10.0.0.0/8")), wantIDs: []string{"2"}},
+		"ip not equal to":           {request: field("ip", filter.CompareOperatorIsIpNotEqualTo, "192.168.0.0/16"), wantIDs: []string{"2", "3"}},
+		"before date":               {request: field("createdAt", filter.CompareOperatorBeforeDate, "2025-03-11T12:00:00Z"), wantIDs: []string{"1"}},
+		"after date":                {request: field("createdAt", filter.CompareOperatorAfterDate, time.Date(2025, 3, 11, 1, 0, 0, 0, time.UTC)), wantIDs: []string{"3"}},
+		"between dates": {
+			request: field("createdAt", filter.CompareOperatorBetweenDates, []any{"2025-03-10T15:30:00Z", "2025-03-11T00:00:00Z"}),
+			wantIDs: []string{"1", "2"},
+		},
+		"exists":                          {request: field("severity", filter.CompareOperatorExists, nil), wantIDs: []string{"1", "2"}},
+		"does not exist":                  {request: field("tags", filter.CompareOperatorDoesNotExist, nil), wantIDs: []string{"2"}},
+		"equal to rating":                 {request: field("severity", filter.CompareOperatorIsEqualToRating, []any{"low", "high"}), wantIDs: []string{"1", "2"}},
+		"not equal to rating":             {request: field("severity", filter.CompareOperatorIsNotEqualToRating, "low"), wantIDs: []string{"1"}},
+		"less than rating":                {request: field("severity", filter.CompareOperatorIsLessThanRating, "medium"), wantIDs: []string{"2"}},
+		"less than or equal to rating":    {request: field("severity", filter.CompareOperatorIsLessThanOrEqualToRating, "high"), wantIDs: []string{"1", "2"}},
+		"greater than rating":             {request: field("severity", filter.CompareOperatorIsGreaterThanRating, "low"), wantIDs: []string{"1"}},
+		"greater than or equal to rating": {request: field("severity", filter.CompareOperatorIsGreaterThanOrEqualToRating, "high"), wantIDs: []string{"1"}},
+		"slice field matches any element": {request: field("tags", filter.CompareOperatorIsEqualTo, "db"), wantIDs: []string{"1"}},
+		"slice field negation":            {request: field("tags", filter.CompareOperatorIsNotEqualTo, "db"), wantIDs: []string{"3"}},
+		"slice field matches all values": {
+			request: &filter.Request{Fields: []filter.RequestField{
+				{Name: "tags", Operator: filter.CompareOperatorIsEqualTo, Match: filter.ValueMatchAll, Value: []any{"prod", "db"}},
+			}},
+			wantIDs: []string{"1"},
+		},
+		"slice field negation of all values": {
+			request: &filter.Request{Fields: []filter.RequestField{
+				{Name: "tags", Operator: filter.CompareOperatorIsNotEqualTo, Match: filter.ValueMatchAll, Value: []any{"prod", "mail"}},
+			}},
+			wantIDs: []string{"1", "3"},
+		},
+		"map field with keys": {request: field("labels", filter.CompareOperatorIsEqualTo, "prod", "env"), wantIDs: []string{"1"}},
+		"missing key":         {request: field("labels", filter.CompareOperatorDoesNotExist, nil, "env"), wantIDs: []string{"3"}},
+		"and": {
+			request: &filter.Request{
+				Operator: filter.LogicOperatorAnd,
+				Fields: []filter.RequestField{
+					{Name: "hostname", Operator: filter.CompareOperatorContains, Value: "server"},
+					{Name: "port", Operator: filter.CompareOperatorIsLessThan, Value: 1000},
+				},
+			},
+			wantIDs: []string{"2"},
+		},
+		"or with nested group": {
+			request: &filter.Request{
+				Operator: filter.LogicOperatorOr,
+				Fields:   []filter.RequestField{{Name: "id", Operator: filter.CompareOperatorIsEqualTo, Value: "3"}},
+				Groups: []filter.Request{{
+					Operator: filter.LogicOperatorAnd,
+					Fields: []filter.RequestField{
+						{Name: "active", Operator: filter.CompareOperatorIsEqualTo, Value: true},
+						{Name: "tags", Operator: filter.CompareOperatorExists},
+					},
+				}},
+			},
+			wantIDs: []string{"1", "3"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := evaluator.Filter(tt.request, testAssets)
+			require.NoError(t, err)
+
+			var gotIDs []string
+			for _, asset := range got {
+				gotIDs = append(gotIDs, asset.ID)
+			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
+
+func TestEvaluator_Errors(t *testing.T) {
+	evaluator, err := NewStructEvaluator[*testAsset]()
+	require.NoError(t, err)
+	evaluator.WithStringFieldRating(testRatings)
+
+	tests := map[string]struct {
+		request         *filter.Request
+		wantFilterError bool
+	}{
+		"unknown field": {
+			request:         &filter.Request{Fields: []filter.RequestField{{Name: "internal", Operator: filter.CompareOperatorExists}}},
+			wantFilterError: true,
+		},
+		"unknown operator": {
+			request: &filter.Request{Fields: []filter.RequestField{{Name: "port", Operator: "like", Value: 1}}},
+		},
+		"invalid logic operator": {
+			request: &filter.Request{Fields: []filter.RequestField{
+				{Name: "port", Operator: filter.CompareOperatorExists},
+				{Name: "ip", Operator: filter.CompareOperatorExists},
+			}},
+		},
+		"empty group": {
+			request: &filter.Request{Groups: []filter.Request{{}}},
+		},
+		"nil value": {
+			request: &filter.Request{Fields: []filter.RequestField{{Name: "port", Operator: filter.CompareOperatorIsEqualTo}}},
+		},
+		"empty list": {
+			request: &filter.Request{Fields: []filter.RequestField{{Name: "port", Operator: filter.CompareOperatorIsEqualTo, Value: []any{}}}},
+		},
+		"invalid value type": {
+			request: &filter.Request{Fields: []filter.RequestField{{Name: "hostname", Operator: filter.CompareOperatorContains, Value: 1}}},
+		},
+		"invalid field type": {
+			request: &filter.Request{Fields: []filter.RequestField{{Name: "port", Operator: filter.CompareOperatorContains, Value: "1"}}},
+		},
+		"invalid ip": {
+			request: &filter.Request{Fields: []filter.RequestField{{Name: "ip", Operator: filter.CompareOperatorIsIpEqualTo, Value: "a.b.c.d"}}},
+		},
+		"unknown rating": {
+			request: &filter.Request{Fields: []filter.RequestField{{Name: "severity", Operator: filter.CompareOperatorIsEqualToRating, Value: "critical"}}},
+		},
+		"single date for between dates": {
+			request: &filter.Request{Fields: []filter.RequestField{{Name: "createdAt", Operator: filter.CompareOperatorBetweenDates, Value: "2025-03-10T00:00:00Z"}}},
+		},
+		"keys of non-map field": {
+			request: &filter.Request{Fields: []filter.RequestField{{Name: "hostname", Keys: []string{"a"}, Operator: filter.CompareOperatorExists}}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := evaluator.Matches(tt.request, &testAssets[2]) // item with missing severity and labels
+			require.Error(t, err)
+			if tt.wantFilterError {
+				var invalidFilterFieldError *filter.InvalidFilterFieldError
+				assert.ErrorAs(t, err, &invalidFilterFieldError)
+			}
+		})
+	}
+}
+
+func TestNewEvaluator_CustomAccessor(t *testing.T) {
+	payload := map[string]any{"severity": 9.5, "host": map[string]any{"name": "db"}}
+	evaluator := NewEvaluator(func(item map[string]any, name string, keys []string) (any, error) {
+		value := item[name]
+		for _, key := range keys {
+			nested, ok := value.(map[string]any)
+			if !ok {
+				return nil, nil
+			}
+			value = nested[key]
+		}
+		return value, nil
+	})
+
+	matches, err := evaluator.Matches(&filter.Request{
+		Operator: filter.LogicOperatorAnd,
+		Fields: []filter.RequestField{
+			{Name: "severity", Operator: filter.CompareOperatorIsGreaterThanOrEqualTo, Value: 9},
+			{Name: "host", Keys: []string{"name"}, Operator: filter.CompareOperatorIsEqualTo, Value: "db"},
+		},
+	}, payload)
+	require.NoError(t, err)
+	assert.True(t, matches)
+}
+
+func TestEvaluator_RatingBounds(t *testing.T) {
+	evaluator := NewEvaluator(func(severity float64, _ string, _ []string) (any, error) {
+		return severity, nil
+	}).WithStringFieldRating(testRatings)
+	request := &filter.Request{Fields: []filter.RequestField{
+		{Name: "severity", Operator: filter.CompareOperatorIsEqualToRating, Value: "low"},
+	}}
+
+	matching, err := evaluator.Filter(request, []float64{0.09, 0.1, 3.9, 3.91})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0.1, 3.9}, matching, "the bounds must be inclusive with their decimal value")
+}
+
+func TestNewStructEvaluator_InvalidType(t *testing.T) {
+	_, err := NewStructEvaluator[map[string]any]()
+	assert.Error(t, err)
+}
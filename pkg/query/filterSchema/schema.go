@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package filterSchema derives the filter configuration of a model from its struct tags. This way the request
+// options used for validation, the filter options offered to clients and the field mappings of the query
+// builders are kept in sync.
+//
+// A filterable field is declared with the `filter` tag, e.g.:
+//
+//	type Asset struct {
+//		Hostname string `json:"hostname" filter:"name=hostname,control=string,operators=contains|beginsWith,column=asset.hostname"`
+//	}
+//
+// Supported tag keys:
+//   - name: name of the filter field, defaults to the name in the `json` tag
+//   - label: human-readable name of the filter field, defaults to the name
+//   - control: control type of the field, see [filter.ControlType] (required)
+//   - operators: `|` separated list of compare operators, see [filter.CompareOperator] (required)
+//   - values: `|` separated list of possible values, e.g. for the `enum` control type
+//   - multiSelect: flag indicating that the field accepts multiple values
+//   - column: database column used by the postgres query builder, defaults to the name
+//   - field: document field used by the OpenSearch query builder, defaults to the name
+//
+// Fields of embedded structs are included. Fields without `filter` tag or with tag `filter:"-"` are ignored.
+package filterSchema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+)
+
+// TagName is the name of the struct tag holding the filter configuration of a field.
+const TagName = "filter"
+
+// Field is the filter configuration of a single struct field.
+type Field struct {
+	Name        string
+	Label       string
+	Control     filter.ControlType
+	Operators   []filter.CompareOperator
+	Values      []string
+	MultiSelect bool
+	// Column is the database column used by the postgres query builder.
+	Column string
+	// SearchField is the document field used by the OpenSearch query builder.
+	SearchField string
+}
+
+// Schema is the filter configuration of a model, derived from its struct tags.
+type Schema struct {
+	Fields []Field
+}
+
+// FromModel derives the filter schema from the `filter` struct tags of the given model,
+// which must be a struct or a pointer to a struct. The fields keep the order of the struct.
+func FromModel(model any) (*Schema, error) {
+	modelType := reflect.TypeOf(model)
+	for modelType != nil && modelType.Kind() == reflect.Pointer {
+		modelType = modelType.Elem()
+	}
+	if modelType == nil || modelType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("model must be a struct or a pointer to a struct, got %T", model)
+	}
+
+	fields, err := fieldsOf(modelType)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if names[field.Name] {
+			return nil, fmt.Errorf("duplicate filter field name '%s'", field.Name)
+		}
+		names[field.Name] = true
+	}
+	return &Schema{Fields: fields}, nil
+}
+
+func fieldsOf(structType reflect.Type) ([]Field, error) {
+	var fields []Field
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		tag, hasTag := structField.Tag.Lookup(TagName)
+		if tag == "-" {
+			continue
+		}
+		if !hasTag {
+			embeddedType := structField.Type
+			if embeddedType.Kind() == reflect.Pointer {
+				embeddedType = embeddedType.Elem()
+			}
+			if structField.Anonymous && embeddedType.Kind() == reflect.Struct {
+				embeddedFields, err := fieldsOf(embeddedType)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, embeddedFields...)
+			}
+			continue
+		}
+
+		field, err := parseTag(structField, tag)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter tag of field %s: %w", structField.Name, err)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func parseTag(structField reflect.StructField, tag string) (Field, error) {
+	var field Field
+	for _, entry := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		switch key {
+		case "name":
+			field.Name = value
+		case "label":
+			field.Label = value
+		case "control":
+			control, err := filter.ParseControlType(value)
+			if err != nil {
+				return Field{}, err
+			}
+			field.Control = control
+		case "operators":
+			for _, operatorName := range strings.Split(value, "|") {
+				operator, err := filter.ParseCompareOperator(operatorName)
+				if err != nil {
+					return Field{}, err
+				}
+				field.Operators = append(field.Operators, operator)
+			}
+		case "values":
+			field.Values = strings.Split(value, "|")
+		case "multiSelect":
+			field.MultiSelect = value == "" || value == "true"
+		case "column":
+			field.Column = value
+		case "field":
+			field.SearchField = value
+		case "":
+			// allow empty entries, e.g. trailing commas
+		default:
+			return Field{}, fmt.Errorf("unknown key '%s'", key)
+		}
+	}
+
+	if field.Name == "" {
+		jsonName, _, _ := strings.Cut(structField.Tag.Get("json"), ",")
+		if jsonName == "" || jsonName == "-" {
+			return Field{}, fmt.Errorf("missing name")
+		}
+		field.Name = jsonName
+	}
+	if field.Control == "" {
+		return Field{}, fmt.Errorf("missing control type")
+	}
+	if len(field.Operators) == 0 {
+		return Field{}, fmt.Errorf("missing operators")
+	}
+	if field.Label == "" {
+		field.Label = field.Name
+	}
+	if field.Column == "" {
+		field.Column = field.Name
+	}
+	if field.SearchField == "" {
+		// the column is a SQL expression like `asset.id`, which is not a document field
+		field.SearchField = field.Name
+	}
+	return field, nil
+}
+
+// RequestOptions returns the request options to validate filter requests with [filter.ValidateFilter].
+func (s *Schema) RequestOptions() []filter.RequestOption {
+	options := make([]filter.RequestOption, 0, len(s.Fields))
+	for _, field := range s.Fields {
+		options = append(options, filter.RequestOption{
+			Name:        filter.NewReadableValue(field.Label, field.Name),
+			Control:     filter.RequestOptionType{Type: field.Control},
+			Operators:   readableOperators(field.Operators),
+			Values:      field.Values,
+			MultiSelect: field.MultiSelect,
+		})
+	}
+	return options
+}
+
+// FilterOptions returns the filter options, which can be offered to clients to determine the possible filters.
+func (s *Schema) FilterOptions() []query.FilterOption {
+	options := make([]query.FilterOption, 0, len(s.Fields))
+	for _, field := range s.Fields {
+		options = append(options, query.FilterOption{
+			Name:        filter.NewReadableValue(field.Label, field.Name),
+			Control:     filter.RequestOptionType{Type: field.Control},
+			Operators:   readableOperators(field.Operators),
+			Values:      field.Values,
+			MultiSelect: field.MultiSelect,
+		})
+	}
+	return options
+}
+
+// ColumnMapping returns the mapping of filter fields to database columns,
+// to be used as `FilterFieldMapping` in the settings of the postgres query builder.
+func (s *Schema) ColumnMapping() map[string]string {
+	mapping := make(map[string]string, len(s.Fields))
+	for _, field := range s.Fields {
+		mapping[field.Name] = field.Column
+	}
+	return mapping
+}
+
+// SearchFieldMapping returns the mapping of filter fields to document fields,
+// to be used as `FilterFieldMapping` in the settings of the OpenSearch query builder.
+func (s *Schema) SearchFieldMapping() map[string]string {
+	mapping := make(map[string]string, len(s.Fields))
+	for _, field := range s.Fields {
+		mapping[field.Name] = field.SearchField
+	}
+	return mapping
+}
+
+func readableOperators(operators []filter.CompareOperator) []filter.ReadableValue[filter.CompareOperator] {
+	readable := make([]filter.ReadableValue[filter.CompareOperator], 0, len(operators))
+	for _, operator := range operators {
+		readable = append(readable, filter.NewReadableValue(operatorLabel(operator), operator))
+	}
+	return readable
+}
+
+// operatorLabel derives a human-readable label from the camel case name of the operator,
+// e.g. `is greater than` for `isGreaterThan`.
+func operatorLabel(operator filter.CompareOperator) string {
+	var label strings.Builder
+	for i, r := range string(operator) {
+		if unicode.IsUpper(r) && i > 0 {
+			label.WriteRune(' ')
+		}
+		label.WriteRune(unicode.ToLower(r))
+	}
+	return label.String()
+}
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package filterSchema
+
+import (
+	"testing"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Base struct {
+	ID string `json:"id" filter:"control=uuid,operators=isEqualTo|isNotEqualTo,column=asset.id"`
+}
+
+type Asset struct {
+	Base
+	Hostname string   `json:"hostname" filter:"name=hostname,label=Hostname,control=string,operators=contains|beginsWith,column=asset.hostname,field=hostname.keyword"`
+	Severity float32  `json:"severity" filter:"control=float,operators=isGreaterThan|isLessThanOrEqualTo"`
+	State    string   `json:"state" filter:"control=enum,operators=isEqualTo,values=active|inactive,multiSelect"`
+	Tags     []string `json:"tags"`
+	Internal string   `json:"internal" filter:"-"`
+}
+
+func TestFromModel(t *testing.T) {
+	wantSchema := &Schema{Fields: []Field{
+		{
+			Name:        "id",
+			Label:       "id",
+			Control:     filter.ControlTypeUuid,
+			Operators:   []filter.CompareOperator{filter.CompareOperatorIsEqualTo, filter.CompareOperatorIsNotEqualTo},
+			Column:      "asset.id",
+			SearchField: "id",
+		},
+		{
+			Name:        "hostname",
+			Label:       "Hostname",
+			Control:     filter.ControlTypeString,
+			Operators:   []filter.CompareOperator{filter.CompareOperatorContains, filter.CompareOperatorBeginsWith},
+			Column:      "asset.hostname",
+			SearchField: "hostname.keyword",
+		},
+		{
+			Name:        "severity",
+			Label:       "severity",
+			Control:     filter.ControlTypeFloat,
+			Operators:   []filter.CompareOperator{filter.CompareOperatorIsGreaterThan, filter.CompareOperatorIsLessThanOrEqualTo},
+			Column:      "severity",
+			SearchField: "severity",
+		},
+		{
+			Name:        "state",
+			Label:       "state",
+			Control:     filter.ControlTypeEnum,
+			Operators:   []filter.CompareOperator{filter.CompareOperatorIsEqualTo},
+			Values:      []string{"active", "inactive"},
+			MultiSelect: true,
+			Column:      "state",
+			SearchField: "state",
+		},
+	}}
+
+	t.Run("shouldDeriveSchemaFromStruct", func(t *testing.T) {
+		schema, err := FromModel(Asset{})
+		require.NoError(t, err)
+		assert.Equal(t, wantSchema, schema)
+	})
+
+	t.Run("shouldDeriveSchemaFromPointer", func(t *testing.T) {
+		schema, err := FromModel(&Asset{})
+		require.NoError(t, err)
+		assert.Equal(t, wantSchema, schema)
+	})
+
+	errorTests := map[string]struct {
+		model   any
+		wantErr string
+	}{
+		"no struct": {
+			model:   "asset",
+			wantErr: "model must be a struct",
+		},
+		"missing name": {
+			model: struct {
+				Hostname string `filter:"control=string,operators=contains"`
+			}{},
+			wantErr: "missing name",
+		},
+		"missing control": {
+			model: struct {
+				Hostname string `json:"hostname" filter:"operators=contains"`
+			}{},
+			wantErr: "missing control type",
+		},
+		"invalid control": {
+			model: struct {
+				Hostname string `json:"hostname" filter:"control=text,operators=contains"`
+			}{},
+			wantErr: "text is not a valid ControlType",
+		},
+		"missing operators": {
+			model: struct {
+				Hostname string `json:"hostname" filter:"control=string"`
+			}{},
+			wantErr: "missing operators",
+		},
+		"invalid operator": {
+			model: struct {
+				Hostname string `json:"hostname" filter:"control=string,operators=contains|like"`
+			}{},
+			wantErr: "like is not a valid CompareOperator",
+		},
+		"unknown key": {
+			model: struct {
+				Hostname string `json:"hostname" filter:"control=string,operators=contains,sortable"`
+			}{},
+			wantErr: "unknown key 'sortable'",
+		},
+		"duplicate name": {
+			model: struct {
+				Hostname string `json:"hostname" filter:"control=string,operators=contains"`
+				Name     string `json:"name" filter:"name=hostname,control=string,operators=contains"`
+			}{},
+			wantErr: "duplicate filter field name 'hostname'",
+		},
+	}
+	for name, tt := range errorTests {
+		t.Run(name, func(t *testing.T) {
+			schema, err := FromModel(tt.model)
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.Nil(t, schema)
+		})
+	}
+}
+
+func TestSchemaOutputs(t *testing.T) {
+	schema, err := FromModel(Asset{})
+	require.NoError(t, err)
+
+	t.Run("requestOptions", func(t *testing.T) {
+		options := schema.RequestOptions()
+		require.Len(t, options, 4)
+		assert.Equal(t, filter.RequestOption{
+			Name:    filter.NewReadableValue("Hostname", "hostname"),
+			Control: filter.RequestOptionType{Type: filter.ControlTypeString},
+			Operators: []filter.ReadableValue[filter.CompareOperator]{
+				filter.NewReadableValue("contains", filter.CompareOperatorContains),
+				filter.NewReadableValue("begins with", filter.CompareOperatorBeginsWith),
+			},
+		}, options[1])
+
+		err := filter.ValidateFilter(&filter.Request{
+			Operator: filter.LogicOperatorAnd,
+			Fields: []filter.RequestField{
+				{Name: "hostname", Operator: filter.CompareOperatorBeginsWith, Value: "host"},
+				{Name: "state", Operator: filter.CompareOperatorIsEqualTo, Value: []any{"active"}},
+			},
+		}, options)
+		assert.NoError(t, err)
+	})
+
+	t.Run("filterOptions", func(t *testing.T) {
+		options := schema.FilterOptions()
+		require.Len(t, options, 4)
+		assert.Equal(t, query.FilterOption{
+			Name:    filter.NewReadableValue("state", "state"),
+			Control: filter.RequestOptionType{Type: filter.ControlTypeEnum},
+			Operators: []filter.ReadableValue[filter.CompareOperator]{
+				filter.NewReadableValue("is equal to", filter.CompareOperatorIsEqualTo),
+			},
+			Values:      []string{"active", "inactive"},
+			MultiSelect: true,
+		}, options[3])
+	})
+
+	t.Run("columnMapping", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"id":       "asset.id",
+			"hostname": "asset.hostname",
+			"severity": "severity",
+			"state":    "state",
+		}, schema.ColumnMapping())
+	})
+
+	t.Run("searchFieldMapping", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"id":       "id",
+			"hostname": "hostname.keyword",
+			"severity": "severity",
+			"state":    "state",
+		}, schema.SearchFieldMapping())
+	})
+}
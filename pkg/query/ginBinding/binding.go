@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package ginBinding provides a gin middleware which binds, validates and normalizes the result selector
+// of a request according to the schema of the endpoint, so that handlers can use it right away.
+package ginBinding
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greenbone/opensight-golang-libraries/pkg/errorResponses"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/sorting"
+)
+
+const (
+	// resultSelectorKey is the key of the validated result selector in the gin context
+	resultSelectorKey = "ginBinding.resultSelector"
+	// sortingParamsKey is the key of the effective sorting parameters in the gin context
+	sortingParamsKey = "ginBinding.sortingParams"
+)
+
+// EndpointSchema declares which result selectors an endpoint accepts.
+type EndpointSchema struct {
+	// RequestOptions are the filter options of the endpoint, the filter of the request is validated against them.
+	RequestOptions []filter.RequestOption
+	// NormalizeFilter enables the coercion of the filter values into their canonical types, see [filter.NormalizeFilter].
+	NormalizeFilter bool
+	// SortingSettings provide the sortable columns and the default sorting. If nil, the sorting request is left as is.
+	SortingSettings sorting.SortingSettingsInterface
+	// PagingSettings provide the default page size and the paging limits. If nil, the paging request is left as is.
+	PagingSettings paging.PagingSettingsInterface
+	// PagingLimitMode defines whether paging requests exceeding the limits of the paging settings are clamped
+	// or rejected.
+	PagingLimitMode paging.LimitMode
+}
+
+// BindResultSelector returns a gin middleware which binds the JSON body of the request to a [query.ResultSelector]
+// and processes it according to the schema:
+//   - the filter is validated with [filter.ValidateFilterDetailed] and optionally normalized
+//   - the effective sorting is determined with [sorting.DetermineEffectiveSortingParams]
+//   - the paging defaults and limits are applied with [paging.ValidateAndApplyPagingRules], depending on the
+//     limit mode requests exceeding the limits are rejected
+//
+// If the body is empty, the result selector is decoded from the URL query parameters with
+// [query.DecodeURLValues] instead. As the values of these parameters are strings, the filter is normalized before
+// it is validated. Without any of these parameters the result selector is empty. If the request is invalid, the request is aborted
+// with status 400 and an [errorResponses.ErrorResponse] of type validation. Otherwise, the result selector
+// and the sorting parameters are put into the context, see [ResultSelectorFrom] and [SortingParamsFrom].
+func BindResultSelector(schema EndpointSchema) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var resultSelector query.ResultSelector
+		fromURL := false
+		if c.Request.Body == nil {
+			fromURL = true
+		} else if err := c.ShouldBindJSON(&resultSelector); err != nil {
+			if !errors.Is(err, io.EOF) {
+				abortWithValidationError(c, "invalid result selector", err.Error(), nil)
+				return
+			}
+			fromURL = true
+		}
+		if fromURL {
+			decoded, err := query.DecodeURLValues(c.Request.URL.Query())
+			if err != nil {
+				abortWithValidationError(c, "invalid result selector", err.Error(), nil)
+				return
+			}
+			resultSelector = decoded
+			if resultSelector.Filter != nil {
+				// query parameters carry strings only, they need to be converted before the type checks
+				normalizedFilter, err := filter.NormalizeFilter(resultSelector.Filter, schema.RequestOptions)
+				if err == nil {
+					resultSelector.Filter = normalizedFilter
+				} else if detailedErr := filter.ValidateFilterDetailed(resultSelector.Filter,
+					schema.RequestOptions); detailedErr != nil {
+					abortWithFilterError(c, detailedErr)
+					return
+				} else {
+					abortWithValidationError(c, "invalid filter", err.Error(), nil)
+					return
+				}
+			}
+		}
+
+		if err := filter.ValidateFilterDetailed(resultSelector.Filter, schema.RequestOptions); err != nil {
+			abortWithFilterError(c, err)
+			return
+		}
+		if schema.NormalizeFilter {
+			normalizedFilter, err := filter.NormalizeFilter(resultSelector.Filter, schema.RequestOptions)
+			if err != nil {
+				abortWithValidationError(c, "invalid filter", err.Error(), nil)
+				return
+			}
+			resultSelector.Filter = normalizedFilter
+		}
+
+		var sortingParams sorting.Params
+		if schema.SortingSettings != nil {
+			if resultSelector.Sorting == nil {
+				resultSelector.Sorting = &sorting.Request{}
+			}
+			params, err := sorting.DetermineEffectiveSortingParams(schema.SortingSettings, resultSelector.Sorting)
+			if err != nil {
+				_ = c.Error(err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponses.ErrorInternalResponse)
+				return
+			}
+			sortingParams = params
+		}
+
+		if schema.PagingSettings != nil {
+			if schema.PagingLimitMode == paging.LimitModeReject && resultSelector.Paging != nil {
+				// check before the defaults are applied, as these clamp the request to the limits
+				if _, err := paging.ApplyPagingLimits(schema.PagingSettings, resultSelector.Paging,
+					paging.LimitModeReject); err != nil {
+					abortWithValidationError(c, "invalid paging", err.Error(), nil)
+					return
+				}
+			}
+			pagingRequest, err := paging.ValidateAndApplyPagingRules(schema.PagingSettings, resultSelector.Paging)
+			if err != nil {
+				abortWithValidationError(c, "invalid paging", err.Error(), nil)
+				return
+			}
+			resultSelector.Paging = pagingRequest
+		}
+
+		c.Set(resultSelectorKey, resultSelector)
+		c.Set(sortingParamsKey, sortingParams)
+		c.Next()
+	}
+}
+
+// ResultSelectorFrom returns the result selector put into the context by [BindResultSelector].
+// It returns false if the middleware was not applied.
+func ResultSelectorFrom(c *gin.Context) (query.ResultSelector, bool) {
+	value, ok := c.Get(resultSelectorKey)
+	if !ok {
+		return query.ResultSelector{}, false
+	}
+	resultSelector, ok := value.(query.ResultSelector)
+	return resultSelector, ok
+}
+
+// SortingParamsFrom returns the effective sorting parameters put into the context by [BindResultSelector].
+// It returns false if the middleware was not applied. The parameters are empty if the schema
+// has no sorting settings.
+func SortingParamsFrom(c *gin.Context) (sorting.Params, bool) {
+	value, ok := c.Get(sortingParamsKey)
+	if !ok {
+		return sorting.Params{}, false
+	}
+	params, ok := value.(sorting.Params)
+	return params, ok
+}
+
+func abortWithValidationError(c *gin.Context, title, details string, errs map[string]string) {
+	c.AbortWithStatusJSON(http.StatusBadRequest, errorResponses.NewErrorValidationResponse(title, details, errs))
+}
+
+func abortWithFilterError(c *gin.Context, err error) {
+	var fieldErrors filter.FieldValidationErrors
+	if errors.As(err, &fieldErrors) {
+		abortWithValidationError(c, "invalid filter", "", prefixedErrorMap("filter.", fieldErrors.ErrorMap()))
+	} else {
+		abortWithValidationError(c, "invalid filter", err.Error(), nil)
+	}
+}
+
+func prefixedErrorMap(prefix string, errs map[string]string) map[string]string {
+	prefixed := make(map[string]string, len(errs))
+	for key, message := range errs {
+		prefixed[prefix+key] = message
+	}
+	return prefixed
+}
@@ -0,0 +1,265 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package ginBinding
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/greenbone/opensight-golang-libraries/pkg/httpassert"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/sorting"
+)
+
+type testSortingModel struct{}
+
+func (testSortingModel) GetSortDefault() sorting.SortDefault {
+	return sorting.SortDefault{Column: "name", Direction: sorting.DirectionAscending}
+}
+
+func (testSortingModel) GetSortingMap() map[string]string {
+	return map[string]string{"name": "name", "severity": "severity"}
+}
+
+func (testSortingModel) GetOverrideSortColumn(string) string {
+	return ""
+}
+
+type testPagingModel struct{}
+
+func (testPagingModel) GetPagingDefault() (pageSize int) {
+	return 20
+}
+
+func (testPagingModel) GetMaxPageSize() int {
+	return 100
+}
+
+func (testPagingModel) GetMaxOffset() int {
+	return 1000
+}
+
+func testRouter(schema EndpointSchema) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := func(c *gin.Context) {
+		resultSelector, ok := ResultSelectorFrom(c)
+		if !ok {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		sortingParams, _ := SortingParamsFrom(c)
+		c.JSON(http.StatusOK, gin.H{
+			"resultSelector":      resultSelector,
+			"effectiveSortColumn": sortingParams.EffectiveSortColumn,
+		})
+	}
+	router.GET("/results", BindResultSelector(schema), handler)
+	router.POST("/results", BindResultSelector(schema), handler)
+	return router
+}
+
+func testSchema() EndpointSchema {
+	return EndpointSchema{
+		RequestOptions: []filter.RequestOption{
+			{
+				Name:      filter.ReadableValue[string]{Value: "name"},
+				Control:   filter.RequestOptionType{Type: filter.ControlTypeString},
+				Operators: []filter.ReadableValue[filter.CompareOperator]{{Value: filter.CompareOperatorIsEqualTo}},
+			},
+			{
+				Name:      filter.ReadableValue[string]{Value: "severity"},
+				Control:   filter.RequestOptionType{Type: filter.ControlTypeFloat},
+				Operators: []filter.ReadableValue[filter.CompareOperator]{{Value: filter.CompareOperatorIsGreaterThan}},
+			},
+		},
+		SortingSettings: testSortingModel{},
+		PagingSettings:  testPagingModel{},
+		PagingLimitMode: paging.LimitModeReject,
+	}
+}
+
+func TestBindResultSelector(t *testing.T) {
+	schema := testSchema()
+
+	tests := map[string]struct {
+		schema         EndpointSchema
+		body           string
+		wantStatusCode int
+		wantBody       string
+	}{
+		"empty body applies defaults": {
+			schema:         schema,
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"resultSelector": {
+					"filter": null,
+					"sorting": {"column": "name", "direction": "asc"},
+					"paging": {"index": 0, "size": 20}
+				},
+				"effectiveSortColumn": "name"
+			}`,
+		},
+		"valid result selector": {
+			schema: schema,
+			body: `{
+				"filter": {"operator": "and", "fields": [{"name": "severity", "operator": "isGreaterThan", "value": 5}]},
+				"sorting": {"column": "severity", "direction": "desc"},
+				"paging": {"index": 1, "size": 50}
+			}`,
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"resultSelector": {
+					"filter": {"operator": "and", "fields": [{"name": "severity", "operator": "isGreaterThan", "value": 5}]},
+					"sorting": {"column": "severity", "direction": "desc"},
+					"paging": {"index": 1, "size": 50}
+				},
+				"effectiveSortColumn": "severity"
+			}`,
+		},
+		"invalid json": {
+			schema:         schema,
+			body:           `{"filter": `,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "greenbone/validation-error",
+				"title": "invalid result selector",
+				"details": "unexpected EOF"
+			}`,
+		},
+		"invalid filter fields": {
+			schema: schema,
+			body: `{"filter": {"operator": "and", "fields": [
+				{"name": "unknown", "operator": "isEqualTo", "value": "a"},
+				{"name": "severity", "operator": "isGreaterThan", "value": "high"}
+			]}}`,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "greenbone/validation-error",
+				"title": "invalid filter",
+				"errors": {
+					"filter.fields[0]": "field name 'unknown' is invalid",
+					"filter.fields[1]": "field 'severity' must be from type 'float'"
+				}
+			}`,
+		},
+		"page size too large": {
+			schema:         schema,
+			body:           `{"paging": {"index": 0, "size": 101}}`,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "greenbone/validation-error",
+				"title": "invalid paging",
+				"details": "page size must not be greater than 100, got 101"
+			}`,
+		},
+		"page size too large is clamped": {
+			schema: EndpointSchema{
+				RequestOptions:  schema.RequestOptions,
+				PagingSettings:  testPagingModel{},
+				PagingLimitMode: paging.LimitModeClamp,
+			},
+			body:           `{"paging": {"index": 0, "size": 101}}`,
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"resultSelector": {"filter": null, "sorting": null, "paging": {"index": 0, "size": 100}},
+				"effectiveSortColumn": ""
+			}`,
+		},
+		"normalized filter": {
+			schema: EndpointSchema{RequestOptions: schema.RequestOptions, NormalizeFilter: true},
+			body: `{
+				"filter": {"operator": "and", "fields": [{"name": "name", "operator": "isEqualTo", "value": " host "}]}
+			}`,
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"resultSelector": {
+					"filter": {"operator": "and", "fields": [{"name": "name", "operator": "isEqualTo", "value": "host"}]},
+					"sorting": null,
+					"paging": null
+				},
+				"effectiveSortColumn": ""
+			}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := httpassert.New(t, testRouter(tt.schema)).Post("/results")
+			if tt.body != "" {
+				request = request.JsonContent(tt.body)
+			}
+			request.Expect().
+				StatusCode(tt.wantStatusCode).
+				Json(tt.wantBody)
+		})
+	}
+}
+
+func TestBindResultSelectorFromURL(t *testing.T) {
+	schema := testSchema()
+
+	tests := map[string]struct {
+		query          string
+		wantStatusCode int
+		wantBody       string
+	}{
+		"no parameters applies defaults": {
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"resultSelector": {
+					"filter": null,
+					"sorting": {"column": "name", "direction": "asc"},
+					"paging": {"index": 0, "size": 20}
+				},
+				"effectiveSortColumn": "name"
+			}`,
+		},
+		"valid result selector": {
+			query: "?filter[op]=and&filter[0][name]=severity&filter[0][op]=isGreaterThan&filter[0][value]=5.5" +
+				"&sorting[column]=severity&sorting[direction]=desc&paging[index]=1&paging[size]=50&other=ignored&utm[x=1",
+			wantStatusCode: http.StatusOK,
+			wantBody: `{
+				"resultSelector": {
+					"filter": {"operator": "and", "fields": [{"name": "severity", "operator": "isGreaterThan", "value": 5.5}]},
+					"sorting": {"column": "severity", "direction": "desc"},
+					"paging": {"index": 1, "size": 50}
+				},
+				"effectiveSortColumn": "severity"
+			}`,
+		},
+		"invalid parameter": {
+			query:          "?paging[size]=many",
+			wantStatusCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "greenbone/validation-error",
+				"title": "invalid result selector",
+				"details": "invalid query parameter paging[size]: many is no valid number"
+			}`,
+		},
+		"invalid filter value": {
+			query:          "?filter[op]=and&filter[0][name]=severity&filter[0][op]=isGreaterThan&filter[0][value]=high",
+			wantStatusCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "greenbone/validation-error",
+				"title": "invalid filter",
+				"errors": {
+					"filter.fields[0]": "field 'severity' must be from type 'float'"
+				}
+			}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			httpassert.New(t, testRouter(schema)).Get("/results" + tt.query).
+				Expect().
+				StatusCode(tt.wantStatusCode).
+				Json(tt.wantBody)
+		})
+	}
+}
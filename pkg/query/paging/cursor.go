@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+)
+
+// CursorKind distinguishes cursors of document searches from cursors of aggregations, so that a cursor
+// can't be passed to a request of the other kind.
+type CursorKind string
+
+const (
+	// CursorKindDocument is the kind of cursors pointing to a row or document of a search, it is the default.
+	CursorKindDocument CursorKind = ""
+	// CursorKindAggregation is the kind of cursors pointing to a bucket of a composite aggregation.
+	// Buckets are unique by their keys, which are stored as sort values, so there is no tie breaker value.
+	CursorKindAggregation CursorKind = "aggregation"
+)
+
+func (k CursorKind) String() string {
+	if k == CursorKindDocument {
+		return "document"
+	}
+	return string(k)
+}
+
+// Cursor is the decoded form of an opaque cursor used for keyset pagination. It points to the last row
+// of a page, identified by its values of the sort columns and its value of the sorting tie breaker column.
+// As the position is only meaningful for a specific sort order, the sort columns are part of the cursor.
+type Cursor struct {
+	Kind            CursorKind `json:"k,omitempty"` // kind of the cursor, empty for document cursors
+	SortColumns     []string   `json:"c,omitempty"` // sort columns the cursor was created for, empty if sorted only by tie breaker
+	SortValues      []any      `json:"s,omitempty"` // values of the sort columns of the last row, nil for NULL values
+	TieBreakerValue any        `json:"t,omitempty"` // value of the tie breaker column of the last row, nil for aggregation cursors
+}
+
+// EncodeCursor returns the opaque string representation of the cursor, which can be passed to clients
+// in `Response.NextCursor`.
+func EncodeCursor(cursor Cursor) (string, error) {
+	if err := cursor.checkTieBreakerValue(); err != nil {
+		return "", err
+	}
+	if len(cursor.SortColumns) != len(cursor.SortValues) {
+		return "", NewPagingError("cursor requires a value for each sort column")
+	}
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return "", NewPagingError("failed to encode cursor: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// DecodeCursor parses a cursor previously created by [EncodeCursor].
+// Numbers are decoded as [json.Number] to retain their precision, time values are decoded as strings
+// in RFC3339 format.
+func DecodeCursor(encoded string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, NewPagingError("invalid cursor: %v", err)
+	}
+
+	var cursor Cursor
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&cursor); err != nil {
+		return Cursor{}, NewPagingError("invalid cursor: %v", err)
+	}
+	if err := cursor.checkTieBreakerValue(); err != nil {
+		return Cursor{}, NewPagingError("invalid cursor: %v", err)
+	}
+	if len(cursor.SortColumns) != len(cursor.SortValues) {
+		return Cursor{}, NewPagingError("invalid cursor: number of sort columns and values differ")
+	}
+	return cursor, nil
+}
+
+// DecodeCursorOfKind is like [DecodeCursor], but fails if the cursor is not of the given kind.
+func DecodeCursorOfKind(encoded string, kind CursorKind) (Cursor, error) {
+	cursor, err := DecodeCursor(encoded)
+	if err != nil {
+		return Cursor{}, err
+	}
+	if cursor.Kind != kind {
+		return Cursor{}, NewPagingError("invalid cursor: expected %s cursor, got %s cursor", kind, cursor.Kind)
+	}
+	return cursor, nil
+}
+
+// checkTieBreakerValue checks that only document cursors have a tie breaker value, which they require.
+func (c Cursor) checkTieBreakerValue() error {
+	switch c.Kind {
+	case CursorKindDocument:
+		if c.TieBreakerValue == nil {
+			return NewPagingError("cursor requires a tie breaker value")
+		}
+	case CursorKindAggregation:
+		if c.TieBreakerValue != nil {
+			return NewPagingError("%s cursor must not have a tie breaker value", c.Kind)
+		}
+	default:
+		return NewPagingError("cursor has unknown kind '%s'", c.Kind)
+	}
+	return nil
+}
+
+// HasSortColumns returns true if the cursor was created for exactly the given sort columns in this order.
+func (c Cursor) HasSortColumns(columns []string) bool {
+	return slices.Equal(c.SortColumns, columns)
+}
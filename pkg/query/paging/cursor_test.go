@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package paging
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	t.Run("shouldRoundTripCursor", func(t *testing.T) {
+		date := time.Date(2024, 1, 23, 10, 0, 0, 123, time.UTC)
+		encoded, err := EncodeCursor(Cursor{
+			SortColumns:     []string{"created", "name"},
+			SortValues:      []any{date, "abc"},
+			TieBreakerValue: int64(9007199254740993),
+		})
+		require.NoError(t, err)
+
+		cursor, err := DecodeCursor(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, Cursor{
+			SortColumns:     []string{"created", "name"},
+			SortValues:      []any{date.Format(time.RFC3339Nano), "abc"},
+			TieBreakerValue: json.Number("9007199254740993"),
+		}, cursor)
+		assert.True(t, cursor.HasSortColumns([]string{"created", "name"}))
+		assert.False(t, cursor.HasSortColumns([]string{"name", "created"}))
+	})
+
+	t.Run("shouldKeepNilSortValue", func(t *testing.T) {
+		encoded, err := EncodeCursor(Cursor{SortColumns: []string{"name"}, SortValues: []any{nil}, TieBreakerValue: "abc"})
+		require.NoError(t, err)
+
+		cursor, err := DecodeCursor(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, Cursor{SortColumns: []string{"name"}, SortValues: []any{nil}, TieBreakerValue: "abc"}, cursor)
+	})
+
+	t.Run("shouldRoundTripAggregationCursor", func(t *testing.T) {
+		encoded, err := EncodeCursor(Cursor{Kind: CursorKindAggregation, SortColumns: []string{"hostname"}, SortValues: []any{"host-a"}})
+		require.NoError(t, err)
+
+		cursor, err := DecodeCursorOfKind(encoded, CursorKindAggregation)
+		require.NoError(t, err)
+		assert.Equal(t, Cursor{Kind: CursorKindAggregation, SortColumns: []string{"hostname"}, SortValues: []any{"host-a"}}, cursor)
+	})
+
+	t.Run("shouldRaiseWrongKindError", func(t *testing.T) {
+		documentCursor, err := EncodeCursor(Cursor{SortColumns: []string{"hostname"}, SortValues: []any{"host-a"}, TieBreakerValue: "abc"})
+		require.NoError(t, err)
+		aggregationCursor, err := EncodeCursor(Cursor{Kind: CursorKindAggregation, SortColumns: []string{"hostname"}, SortValues: []any{"host-a"}})
+		require.NoError(t, err)
+
+		_, err = DecodeCursorOfKind(documentCursor, CursorKindAggregation)
+		assert.ErrorContains(t, err, "expected aggregation cursor, got document cursor")
+		_, err = DecodeCursorOfKind(aggregationCursor, CursorKindDocument)
+		assert.ErrorContains(t, err, "expected document cursor, got aggregation cursor")
+	})
+
+	t.Run("shouldRaiseTieBreakerOfAggregationCursorError", func(t *testing.T) {
+		_, err := EncodeCursor(Cursor{Kind: CursorKindAggregation, TieBreakerValue: "aggregation"})
+		assert.ErrorContains(t, err, "aggregation cursor must not have a tie breaker value")
+	})
+
+	t.Run("shouldRaiseUnknownKindError", func(t *testing.T) {
+		_, err := EncodeCursor(Cursor{Kind: "other", TieBreakerValue: "abc"})
+		assert.ErrorContains(t, err, "cursor has unknown kind 'other'")
+	})
+
+	t.Run("shouldRaiseMissingTieBreakerError", func(t *testing.T) {
+		_, err := EncodeCursor(Cursor{SortColumns: []string{"name"}, SortValues: []any{"abc"}})
+		assert.ErrorContains(t, err, "cursor requires a tie breaker value")
+	})
+
+	t.Run("shouldRaiseMissingSortValueError", func(t *testing.T) {
+		_, err := EncodeCursor(Cursor{SortColumns: []string{"name", "created"}, SortValues: []any{"abc"}, TieBreakerValue: 1})
+		assert.ErrorContains(t, err, "cursor requires a value for each sort column")
+	})
+
+	t.Run("shouldRaiseInvalidCursorError", func(t *testing.T) {
+		for _, encoded := range []string{"not base64!", "bm8ganNvbg", "e30", "eyJrIjoib3RoZXIiLCJ0IjoxfQ"} { // "no json", "{}", `{"k":"other","t":1}`
+			_, err := DecodeCursor(encoded)
+			var pagingErr *Error
+			assert.ErrorAs(t, err, &pagingErr)
+			assert.ErrorContains(t, err, "invalid cursor")
+		}
+	})
+}
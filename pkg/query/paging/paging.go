@@ -4,7 +4,18 @@
 
 package paging
 
+// LimitMode defines how a paging request exceeding the limits of the paging settings is handled.
+type LimitMode int
+
+const (
+	// LimitModeClamp reduces the page size and the page index to the limits.
+	LimitModeClamp LimitMode = iota
+	// LimitModeReject rejects the request with an error.
+	LimitModeReject
+)
+
 // ValidateAndApplyPagingRules performs a validation of the original request and adds correct the correct values (defaults) if needed
+// Page size and page index exceeding the limits of the paging settings are clamped, see [ApplyPagingLimits].
 func ValidateAndApplyPagingRules(model PagingSettingsInterface, request *Request) (*Request, error) {
 	if err := validatePagingRequest(request); err != nil {
 		// If there is an error in the request for Paging, get the defaults and set them on the request
@@ -15,5 +26,31 @@ func ValidateAndApplyPagingRules(model PagingSettingsInterface, request *Request
 		request.PageIndex = 0
 		request.PageSize = rowSize
 	}
+	return ApplyPagingLimits(model, request, LimitModeClamp)
+}
+
+// ApplyPagingLimits checks the request against the max page size and the max offset of the paging settings.
+// Depending on the mode, a request exceeding them is either clamped to the last reachable page or rejected
+// with an error. The request is expected to have a positive page size.
+func ApplyPagingLimits(model PagingSettingsInterface, request *Request, mode LimitMode) (*Request, error) {
+	if maxPageSize := model.GetMaxPageSize(); maxPageSize > 0 && request.PageSize > maxPageSize {
+		if mode == LimitModeReject {
+			return nil, NewPagingError("page size must not be greater than %d, got %d", maxPageSize, request.PageSize)
+		}
+		request.PageSize = maxPageSize
+	}
+
+	maxOffset := model.GetMaxOffset()
+	if maxOffset <= 0 || request.PageSize <= 0 || (request.PageIndex+1)*request.PageSize <= maxOffset {
+		return request, nil
+	}
+	if mode == LimitModeReject {
+		return nil, NewPagingError("page %d with size %d exceeds the maximum of %d reachable results",
+			request.PageIndex, request.PageSize, maxOffset)
+	}
+	if request.PageSize > maxOffset {
+		request.PageSize = maxOffset
+	}
+	request.PageIndex = maxOffset/request.PageSize - 1
 	return request, nil
 }
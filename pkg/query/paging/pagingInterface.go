@@ -6,4 +6,9 @@ package paging
 
 type PagingSettingsInterface interface {
 	GetPagingDefault() (pageSize int)
+	// GetMaxPageSize returns the maximum page size, 0 means no limit.
+	GetMaxPageSize() int
+	// GetMaxOffset returns the maximum number of results reachable by paging with an offset, 0 means no limit.
+	// The offset of a page plus its page size must not exceed it, like for `index.max_result_window` of OpenSearch.
+	GetMaxOffset() int
 }
@@ -19,6 +19,14 @@ func (t TestPagingModel) GetPagingDefault() (pageSize int) {
 	return 20
 }
 
+func (t TestPagingModel) GetMaxPageSize() int {
+	return 0
+}
+
+func (t TestPagingModel) GetMaxOffset() int {
+	return 0
+}
+
 type FailedTestPagingModel struct {
 	ID   int
 	Name string
@@ -28,6 +36,28 @@ func (f FailedTestPagingModel) GetPagingDefault() (pageSize int) {
 	return 0
 }
 
+func (f FailedTestPagingModel) GetMaxPageSize() int {
+	return 0
+}
+
+func (f FailedTestPagingModel) GetMaxOffset() int {
+	return 0
+}
+
+type LimitedTestPagingModel struct{}
+
+func (l LimitedTestPagingModel) GetPagingDefault() (pageSize int) {
+	return 20
+}
+
+func (l LimitedTestPagingModel) GetMaxPageSize() int {
+	return 100
+}
+
+func (l LimitedTestPagingModel) GetMaxOffset() int {
+	return 1000
+}
+
 func TestPagingRules(t *testing.T) {
 	testPagingModelRequest := &Request{
 		PageIndex: 0,
@@ -55,3 +85,71 @@ func TestPagingRules(t *testing.T) {
 		assert.EqualValues(t, request, testPagingModelRequest)
 	})
 }
+
+func TestApplyPagingLimits(t *testing.T) {
+	tests := map[string]struct {
+		request       *Request
+		mode          LimitMode
+		wantRequest   *Request
+		wantErrorText string
+	}{
+		"within limits": {
+			request:     &Request{PageIndex: 9, PageSize: 100},
+			mode:        LimitModeReject,
+			wantRequest: &Request{PageIndex: 9, PageSize: 100},
+		},
+		"clamp page size": {
+			request:     &Request{PageIndex: 1, PageSize: 1000000},
+			mode:        LimitModeClamp,
+			wantRequest: &Request{PageIndex: 1, PageSize: 100},
+		},
+		"clamp page index to last reachable page": {
+			request:     &Request{PageIndex: 50, PageSize: 30},
+			mode:        LimitModeClamp,
+			wantRequest: &Request{PageIndex: 32, PageSize: 30},
+		},
+		"reject page size": {
+			request:       &Request{PageIndex: 0, PageSize: 101},
+			mode:          LimitModeReject,
+			wantErrorText: "page size must not be greater than 100, got 101",
+		},
+		"reject page index": {
+			request:       &Request{PageIndex: 10, PageSize: 100},
+			mode:          LimitModeReject,
+			wantErrorText: "page 10 with size 100 exceeds the maximum of 1000 reachable results",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			request, err := ApplyPagingLimits(LimitedTestPagingModel{}, tt.request, tt.mode)
+			if tt.wantErrorText != "" {
+				assert.ErrorContains(t, err, tt.wantErrorText)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRequest, request)
+		})
+	}
+
+	t.Run("defaults are clamped", func(t *testing.T) {
+		request, err := ValidateAndApplyPagingRules(LimitedTestPagingModel{}, &Request{PageIndex: 100, PageSize: 500})
+		assert.NoError(t, err)
+		assert.Equal(t, &Request{PageIndex: 9, PageSize: 100}, request)
+	})
+}
+
+func TestNewResponseWithTotalResults(t *testing.T) {
+	request := &Request{PageIndex: 1, PageSize: 10}
+
+	t.Run("results within limit", func(t *testing.T) {
+		assert.Equal(t, &Response{PageIndex: 1, PageSize: 10, TotalDisplayableResults: 50},
+			NewResponseWithTotalResults(request, 50, 100))
+	})
+
+	t.Run("truncated results", func(t *testing.T) {
+		assert.Equal(t, &Response{
+			PageIndex: 1, PageSize: 10, TotalDisplayableResults: 100, TotalResults: 150, Truncated: true,
+		}, NewResponseWithTotalResults(request, 150, 100))
+	})
+}
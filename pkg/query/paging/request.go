@@ -8,9 +8,15 @@ import (
 	"gorm.io/gorm"
 )
 
+// Request represents the paging configuration of a query.
+//   - PageIndex: The index of the requested page (starting from 0). Must be 0 if a cursor is given.
+//   - PageSize: The number of records per page.
+//   - Cursor: Opaque cursor for keyset pagination, as returned in `Response.NextCursor` of the previous page.
+//     If set, the page directly following the row the cursor points to is returned instead of using an offset.
 type Request struct {
-	PageIndex int `json:"index"`
-	PageSize  int `json:"size"`
+	PageIndex int    `json:"index"`
+	PageSize  int    `json:"size"`
+	Cursor    string `json:"cursor,omitempty"`
 }
 
 // AddRequest adds pagination to the gorm transaction based on the given request.
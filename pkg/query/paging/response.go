@@ -9,11 +9,15 @@ package paging
 //   - PageSize: The number of records per page. This is required.
 //   - TotalDisplayableResults: The total number of results that can be paginated. Due to database restrictions, in case of large number of results, some of the results cannot be retrieved. In such cases, this number will be lower than the `TotalResults`. This is required.
 //   - TotalResults: The total count of results as it exists in database, including those that may not be retrieved. This is optional and must not be set if the value does not differ from `TotalDisplayableResults`
+//   - NextCursor: Opaque cursor pointing to the last row of the current page, which can be passed in `Request.Cursor` to retrieve the next page. This is optional and only set when using keyset pagination, see [EncodeCursor].
+//   - Truncated: Indicates that not all results can be retrieved, as `TotalResults` exceeds the result limit. Clients can use it to prompt users to refine their filter.
 type Response struct {
 	PageIndex               int    `json:"index" binding:"required"`
 	PageSize                int    `json:"size" binding:"required"`
 	TotalDisplayableResults uint64 `json:"totalDisplayableResults" binding:"required"`
 	TotalResults            uint64 `json:"totalResults,omitempty"`
+	NextCursor              string `json:"nextCursor,omitempty"`
+	Truncated               bool   `json:"truncated,omitempty"`
 }
 
 func NewResponse(request *Request, totalDisplayableResults uint64) *Response {
@@ -28,6 +32,8 @@ func NewResponse(request *Request, totalDisplayableResults uint64) *Response {
 	}
 }
 
+// NewResponseWithTotalResults creates a response for a data source which can only retrieve up to `resultLimit` results.
+// If there are more results, the response is marked as truncated and contains the total number of results.
 func NewResponseWithTotalResults(request *Request, totalResults, resultLimit uint64) *Response {
 	if request == nil {
 		return nil
@@ -39,6 +45,7 @@ func NewResponseWithTotalResults(request *Request, totalResults, resultLimit uin
 			PageSize:                request.PageSize,
 			TotalDisplayableResults: resultLimit,
 			TotalResults:            totalResults,
+			Truncated:               true,
 		}
 	}
 
@@ -28,21 +28,18 @@ type ResponseWithMetadata[T any] struct {
 }
 
 // Metadata represents the metadata used in a query.
+// Truncated indicates that not all results can be retrieved, as there are more results than the result limit.
+// It is the same as [paging.Response.Truncated], but is also set if no paging was requested.
 type Metadata struct {
-	Filter  *filter.Request  `json:"filter,omitempty"`
-	Paging  *paging.Response `json:"paging,omitempty"`
-	Sorting *sorting.Request `json:"sorting,omitempty"`
+	Filter    *filter.Request  `json:"filter,omitempty"`
+	Paging    *paging.Response `json:"paging,omitempty"`
+	Sorting   *sorting.Request `json:"sorting,omitempty"`
+	Truncated bool             `json:"truncated,omitempty"`
 }
 
 // NewMetadata creates a new Metadata object based on the provided ResultSelector and totalResults.
 func NewMetadata(resultSelector ResultSelector, totalResults uint64) Metadata {
-	if resultSelector.Filter != nil {
-		for i, field := range resultSelector.Filter.Fields {
-			if field.Keys == nil {
-				resultSelector.Filter.Fields[i].Keys = []string{}
-			}
-		}
-	}
+	initFilterKeys(resultSelector.Filter)
 	return Metadata{
 		Filter:  resultSelector.Filter,
 		Paging:  paging.NewResponse(resultSelector.Paging, totalResults),
@@ -51,17 +48,29 @@ func NewMetadata(resultSelector ResultSelector, totalResults uint64) Metadata {
 }
 
 // NewMetadataWithTotalResults creates a new Metadata object based on the provided ResultSelector and totalResults.
+// If totalResults exceeds resultLimit, the metadata is marked as truncated, also if no paging was requested.
 func NewMetadataWithTotalResults(resultSelector ResultSelector, totalResults, resultLimit uint64) Metadata {
-	if resultSelector.Filter != nil {
-		for i, field := range resultSelector.Filter.Fields {
-			if field.Keys == nil {
-				resultSelector.Filter.Fields[i].Keys = []string{}
-			}
+	initFilterKeys(resultSelector.Filter)
+	return Metadata{
+		Filter:    resultSelector.Filter,
+		Paging:    paging.NewResponseWithTotalResults(resultSelector.Paging, totalResults, resultLimit),
+		Sorting:   resultSelector.Sorting,
+		Truncated: totalResults > resultLimit,
+	}
+}
+
+// initFilterKeys replaces nil keys of all fields (including the ones of nested groups) with an empty slice,
+// so that they are serialized consistently.
+func initFilterKeys(request *filter.Request) {
+	if request == nil {
+		return
+	}
+	for i, field := range request.Fields {
+		if field.Keys == nil {
+			request.Fields[i].Keys = []string{}
 		}
 	}
-	return Metadata{
-		Filter:  resultSelector.Filter,
-		Paging:  paging.NewResponseWithTotalResults(resultSelector.Paging, totalResults, resultLimit),
-		Sorting: resultSelector.Sorting,
+	for i := range request.Groups {
+		initFilterKeys(&request.Groups[i])
 	}
 }
@@ -5,6 +5,9 @@
 package query
 
 import (
+	"strings"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/aggregation"
 	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
 	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
 	"github.com/greenbone/opensight-golang-libraries/pkg/query/sorting"
@@ -14,8 +17,26 @@ import (
 // Filter is a pointer to a filter.Request struct that specifies the filtering criteria for the query.
 // Sorting is a pointer to a sorting.Request struct that specifies the sorting order for the query.
 // Paging is a pointer to a paging.Request struct that specifies the paging configuration for the query.
+// Aggregation is a pointer to an aggregation.Request struct that requests buckets of aggregated values instead of a list of results.
+// Search is a pointer to a Search struct that specifies a free text search across the searchable fields of the results.
 type ResultSelector struct {
-	Filter  *filter.Request  `json:"filter" binding:"omitempty"`
-	Sorting *sorting.Request `json:"sorting" binding:"omitempty"`
-	Paging  *paging.Request  `json:"paging" binding:"omitempty"`
+	Filter      *filter.Request      `json:"filter" binding:"omitempty"`
+	Sorting     *sorting.Request     `json:"sorting" binding:"omitempty"`
+	Paging      *paging.Request      `json:"paging" binding:"omitempty"`
+	Aggregation *aggregation.Request `json:"aggregation,omitempty" binding:"omitempty"`
+	Search      *Search              `json:"search,omitempty" binding:"omitempty"`
+}
+
+// Search is a free text search across the searchable fields of the results, which are defined by the backend.
+// Text is the search text in web search syntax, e.g. `linux -"windows server" or macos`.
+// SortByRank orders the results by their relevance for the search text first, the sorting request is applied
+// to results of equal relevance.
+type Search struct {
+	Text       string `json:"text" binding:"required"`
+	SortByRank bool   `json:"sortByRank,omitempty"`
+}
+
+// IsEmpty returns true if there is no search text.
+func (s *Search) IsEmpty() bool {
+	return s == nil || strings.TrimSpace(s.Text) == ""
 }
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package savedView
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotFound is returned if a view does not exist or is not accessible by the user.
+	ErrNotFound = errors.New("saved view not found")
+	// ErrDuplicateName is returned if the owner already has a view with the same name in the scope.
+	ErrDuplicateName = errors.New("saved view with this name already exists")
+)
+
+// Error is returned for invalid saved views.
+type Error struct {
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
+
+func NewSavedViewError(format string, value ...any) error {
+	return &Error{
+		Msg: fmt.Sprintf(format, value...),
+	}
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package savedView
+
+import (
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+)
+
+// Migration migrates a stored result selector to the next schema version. The result selector is passed
+// in its generic JSON form, as it may not be decodable anymore, e.g. if a compare operator was renamed.
+type Migration func(resultSelector map[string]any) error
+
+// RenameOperator returns a migration replacing the compare operator `from` with `to` in all fields of the filter,
+// including the ones of nested groups.
+func RenameOperator(from, to filter.CompareOperator) Migration {
+	return func(resultSelector map[string]any) error {
+		forEachField(resultSelector["filter"], func(field map[string]any) {
+			if field["operator"] == string(from) {
+				field["operator"] = string(to)
+			}
+		})
+		return nil
+	}
+}
+
+// RenameField returns a migration replacing the field name `from` with `to` in the filter, including the fields
+// of nested groups, and in the sorting.
+func RenameField(from, to string) Migration {
+	return func(resultSelector map[string]any) error {
+		forEachField(resultSelector["filter"], func(field map[string]any) {
+			if field["name"] == from {
+				field["name"] = to
+			}
+		})
+		if sortingRequest, ok := resultSelector["sorting"].(map[string]any); ok {
+			if sortingRequest["column"] == from {
+				sortingRequest["column"] = to
+			}
+			for _, key := range objectsOf(sortingRequest["keys"]) {
+				if key["column"] == from {
+					key["column"] = to
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// forEachField calls fn for all fields of the generic filter request, including the fields of nested groups.
+func forEachField(request any, fn func(field map[string]any)) {
+	requestObject, ok := request.(map[string]any)
+	if !ok {
+		return
+	}
+	for _, field := range objectsOf(requestObject["fields"]) {
+		fn(field)
+	}
+	for _, group := range objectsOf(requestObject["groups"]) {
+		forEachField(group, fn)
+	}
+}
+
+func objectsOf(list any) []map[string]any {
+	elements, _ := list.([]any)
+	objects := make([]map[string]any, 0, len(elements))
+	for _, element := range elements {
+		if object, ok := element.(map[string]any); ok {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
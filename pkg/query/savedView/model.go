@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package savedView persists named result selectors, so that users can save and share their combinations
+// of filter, sorting and paging. The views are stored with GORM and are migrated and revalidated on load,
+// as the filter fields and compare operators of a service change over time.
+package savedView
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+)
+
+// Visibility defines who can see a saved view.
+type Visibility string
+
+const (
+	// VisibilityPrivate views are only visible to their owner.
+	VisibilityPrivate Visibility = "private"
+	// VisibilityShared views are visible to all users, but can only be modified by their owner.
+	VisibilityShared Visibility = "shared"
+)
+
+// IsValid returns true if the visibility is one of the defined values.
+func (v Visibility) IsValid() bool {
+	switch v {
+	case VisibilityPrivate, VisibilityShared:
+		return true
+	default:
+		return false
+	}
+}
+
+// SavedView is a named result selector of a user for a scope, e.g. the asset list.
+//
+// Fields:
+// - ID: unique id of the view, set on creation
+// - Scope: the list or endpoint the view belongs to, the filter fields depend on it
+// - Owner: id of the user who created the view
+// - Name: name of the view, unique per owner and scope
+// - Visibility: who can see the view
+// - IsDefault: the view is applied by default for its owner, there is at most one default view per owner and scope
+// - ResultSelector: the saved filter, sorting and paging
+// - RawResultSelector: the JSON encoded result selector as stored in the database, maintained by the [Store]
+// - SchemaVersion: version of the result selector, see [Config.SchemaVersion]
+// - ValidationErrors: problems of the filter with the current filter options, determined on load and not persisted
+type SavedView struct {
+	ID                uuid.UUID                    `json:"id" gorm:"type:uuid;primaryKey"`
+	Scope             string                       `json:"scope" gorm:"not null;uniqueIndex:idx_saved_views_name"`
+	Owner             string                       `json:"owner" gorm:"not null;uniqueIndex:idx_saved_views_name"`
+	Name              string                       `json:"name" gorm:"not null;uniqueIndex:idx_saved_views_name"`
+	Visibility        Visibility                   `json:"visibility" gorm:"not null"`
+	IsDefault         bool                         `json:"isDefault" gorm:"not null;default:false"`
+	ResultSelector    query.ResultSelector         `json:"resultSelector" gorm:"-"`
+	RawResultSelector string                       `json:"-" gorm:"column:result_selector;type:text;not null"`
+	SchemaVersion     int                          `json:"schemaVersion" gorm:"not null"`
+	CreatedAt         time.Time                    `json:"createdAt"`
+	UpdatedAt         time.Time                    `json:"updatedAt"`
+	ValidationErrors  filter.FieldValidationErrors `json:"validationErrors,omitempty" gorm:"-"`
+}
+
+// IsValid returns true if the filter of the view is valid with the current filter options.
+func (v SavedView) IsValid() bool {
+	return len(v.ValidationErrors) == 0
+}
@@ -0,0 +1,358 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package savedView
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"gorm.io/gorm"
+)
+
+// nameIndex is the unique index on scope, owner and name of the views, see [SavedView].
+const nameIndex = "idx_saved_views_name"
+
+// Config configures the handling of stored result selectors.
+type Config struct {
+	// SchemaVersion is the current version of the result selectors. Increase it whenever filter fields or
+	// compare operators change incompatibly and add a migration for the previous version.
+	SchemaVersion int
+	// Migrations migrate a result selector from the version of the key to the next version.
+	Migrations map[int]Migration
+	// RequestOptions are the current filter options per scope. The filters of loaded views are revalidated
+	// against them, problems are reported in [SavedView.ValidationErrors]. Scopes without options are not validated.
+	RequestOptions map[string][]filter.RequestOption
+}
+
+// Store provides CRUD operations for saved views.
+type Store struct {
+	db     *gorm.DB
+	config Config
+}
+
+// NewStore creates a new store for saved views using the given database.
+func NewStore(db *gorm.DB, config Config) *Store {
+	return &Store{db: db, config: config}
+}
+
+// AutoMigrate creates or updates the table of the saved views.
+func (s *Store) AutoMigrate() error {
+	return s.db.AutoMigrate(&SavedView{})
+}
+
+// Create stores a new view. The ID and the schema version are set by the store.
+// If the view is the default, the previous default view of the owner in the scope is reset.
+func (s *Store) Create(ctx context.Context, view *SavedView) error {
+	if err := validateView(view); err != nil {
+		return err
+	}
+	view.ID = uuid.New()
+	view.SchemaVersion = s.config.SchemaVersion
+	if err := encodeResultSelector(view); err != nil {
+		return err
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkNameIsUnique(tx, view); err != nil {
+			return err
+		}
+		if err := resetDefault(tx, view); err != nil {
+			return err
+		}
+		if err := tx.Create(view).Error; err != nil {
+			if isDuplicateName(err) {
+				return ErrDuplicateName
+			}
+			return fmt.Errorf("failed to create saved view: %w", err)
+		}
+		return nil
+	})
+}
+
+// Get returns the view with the given id, if it is owned by the user or shared.
+// The view is migrated to the current schema version and revalidated.
+func (s *Store) Get(ctx context.Context, id uuid.UUID, user string) (*SavedView, error) {
+	var view SavedView
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ? AND (owner = ? OR visibility = ?)", id, user, VisibilityShared).
+			Take(&view).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get saved view: %w", err)
+		}
+		return s.load(tx, &view)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// GetDefault returns the default view of the user in the scope, or [ErrNotFound] if there is none.
+func (s *Store) GetDefault(ctx context.Context, scope string, user string) (*SavedView, error) {
+	var view SavedView
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("scope = ? AND owner = ? AND is_default = ?", scope, user, true).
+			Take(&view).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get default saved view: %w", err)
+		}
+		return s.load(tx, &view)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// List returns the views of the scope which are owned by the user or shared, ordered by name.
+// The views are migrated to the current schema version and revalidated.
+func (s *Store) List(ctx context.Context, scope string, user string) ([]SavedView, error) {
+	var views []SavedView
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("scope = ? AND (owner = ? OR visibility = ?)", scope, user, VisibilityShared).
+			Order("name, id").
+			Find(&views).Error
+		if err != nil {
+			return fmt.Errorf("failed to list saved views: %w", err)
+		}
+		for i := range views {
+			if err := s.load(tx, &views[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+// Update changes name, visibility, default flag and result selector of a view owned by the user.
+// The schema version is set to the current one. Views of other users can not be updated, [ErrNotFound]
+// is returned instead.
+func (s *Store) Update(ctx context.Context, view *SavedView, user string) error {
+	if err := validateView(view); err != nil {
+		return err
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing SavedView
+		err := tx.Where("id = ? AND owner = ?", view.ID, user).Take(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get saved view: %w", err)
+		}
+
+		// owner and scope can not be changed
+		view.Owner = existing.Owner
+		view.Scope = existing.Scope
+		view.CreatedAt = existing.CreatedAt
+		view.SchemaVersion = s.config.SchemaVersion
+		if err := encodeResultSelector(view); err != nil {
+			return err
+		}
+		if err := checkNameIsUnique(tx, view); err != nil {
+			return err
+		}
+		if err := resetDefault(tx, view); err != nil {
+			return err
+		}
+		err = tx.Model(view).
+			Select("Name", "Visibility", "IsDefault", "RawResultSelector", "SchemaVersion").
+			Updates(view).Error
+		if isDuplicateName(err) {
+			return ErrDuplicateName
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update saved view: %w", err)
+		}
+		return nil
+	})
+}
+
+// Delete removes a view owned by the user. Views of other users can not be deleted, [ErrNotFound] is returned instead.
+func (s *Store) Delete(ctx context.Context, id uuid.UUID, user string) error {
+	result := s.db.WithContext(ctx).Where("id = ? AND owner = ?", id, user).Delete(&SavedView{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete saved view: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// load migrates the view to the current schema version, decodes its result selector and revalidates its filter.
+// tx is the transaction the view was read in.
+func (s *Store) load(tx *gorm.DB, view *SavedView) error {
+	if view.SchemaVersion < s.config.SchemaVersion {
+		if err := s.storeMigrated(tx, view); err != nil {
+			return err
+		}
+	}
+
+	view.ResultSelector = query.ResultSelector{}
+	if err := json.Unmarshal([]byte(view.RawResultSelector), &view.ResultSelector); err != nil {
+		return fmt.Errorf("failed to decode result selector of saved view %s: %w", view.ID, err)
+	}
+
+	view.ValidationErrors = nil
+	requestOptions, ok := s.config.RequestOptions[view.Scope]
+	if !ok {
+		return nil
+	}
+	err := filter.ValidateFilterDetailed(view.ResultSelector.Filter, requestOptions)
+	var fieldErrors filter.FieldValidationErrors
+	if errors.As(err, &fieldErrors) {
+		view.ValidationErrors = fieldErrors
+	} else if err != nil {
+		return fmt.Errorf("failed to validate saved view %s: %w", view.ID, err)
+	}
+	return nil
+}
+
+// storeMigrated migrates the view and stores it, so that the migrations are only applied once. The view is
+// only overwritten if it still has the schema version it was read with. Otherwise, it was changed concurrently,
+// e.g. migrated on another instance or updated by its owner, and is reloaded instead.
+func (s *Store) storeMigrated(tx *gorm.DB, view *SavedView) error {
+	readVersion := view.SchemaVersion
+	if err := s.migrate(view); err != nil {
+		return err
+	}
+	result := tx.Model(view).
+		Where("schema_version = ?", readVersion).
+		Select("RawResultSelector", "SchemaVersion").
+		Updates(view)
+	if result.Error != nil {
+		return fmt.Errorf("failed to store migrated saved view %s: %w", view.ID, result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var stored SavedView
+	err := tx.Where("id = ?", view.ID).Take(&stored).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reload saved view %s: %w", view.ID, err)
+	}
+	*view = stored
+	if view.SchemaVersion < s.config.SchemaVersion {
+		return s.migrate(view)
+	}
+	return nil
+}
+
+// migrate applies the migrations from the schema version of the view up to the current schema version
+// to the generic JSON form of the result selector.
+func (s *Store) migrate(view *SavedView) error {
+	var resultSelector map[string]any
+	if err := json.Unmarshal([]byte(view.RawResultSelector), &resultSelector); err != nil {
+		return fmt.Errorf("failed to decode result selector of saved view %s: %w", view.ID, err)
+	}
+	if resultSelector == nil {
+		resultSelector = map[string]any{}
+	}
+	for version := view.SchemaVersion; version < s.config.SchemaVersion; version++ {
+		migration, ok := s.config.Migrations[version]
+		if !ok {
+			return fmt.Errorf("missing migration of saved view %s from schema version %d", view.ID, version)
+		}
+		if err := migration(resultSelector); err != nil {
+			return fmt.Errorf("failed to migrate saved view %s from schema version %d: %w", view.ID, version, err)
+		}
+	}
+
+	raw, err := json.Marshal(resultSelector)
+	if err != nil {
+		return fmt.Errorf("failed to encode result selector of saved view %s: %w", view.ID, err)
+	}
+	view.RawResultSelector = string(raw)
+	view.SchemaVersion = s.config.SchemaVersion
+	return nil
+}
+
+func encodeResultSelector(view *SavedView) error {
+	raw, err := json.Marshal(view.ResultSelector)
+	if err != nil {
+		return fmt.Errorf("failed to encode result selector: %w", err)
+	}
+	view.RawResultSelector = string(raw)
+	return nil
+}
+
+func validateView(view *SavedView) error {
+	if view == nil {
+		return &Error{Msg: "saved view is nil"}
+	}
+	if strings.TrimSpace(view.Name) == "" {
+		return &Error{Msg: "name of saved view is empty"}
+	}
+	if view.Scope == "" {
+		return &Error{Msg: "scope of saved view is empty"}
+	}
+	if view.Owner == "" {
+		return &Error{Msg: "owner of saved view is empty"}
+	}
+	if !view.Visibility.IsValid() {
+		return NewSavedViewError("%s is no valid visibility, possible values are private, shared", view.Visibility)
+	}
+	return nil
+}
+
+// isDuplicateName returns true if the error is a violation of the unique index on the name of the views,
+// which can happen despite [checkNameIsUnique] if views with the same name are stored concurrently.
+// Without the error translation of GORM, the index is identified by its name in the error of the database.
+func isDuplicateName(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), nameIndex)
+}
+
+func checkNameIsUnique(tx *gorm.DB, view *SavedView) error {
+	var count int64
+	err := tx.Model(&SavedView{}).
+		Where("scope = ? AND owner = ? AND name = ? AND id <> ?", view.Scope, view.Owner, view.Name, view.ID).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to check name of saved view: %w", err)
+	}
+	if count > 0 {
+		return ErrDuplicateName
+	}
+	return nil
+}
+
+// resetDefault removes the default flag from the other views of the owner in the scope, if the view is the default.
+func resetDefault(tx *gorm.DB, view *SavedView) error {
+	if !view.IsDefault {
+		return nil
+	}
+	err := tx.Model(&SavedView{}).
+		Where("scope = ? AND owner = ? AND id <> ?", view.Scope, view.Owner, view.ID).
+		Update("is_default", false).Error
+	if err != nil {
+		return fmt.Errorf("failed to reset default saved view: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package savedView
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/sorting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const testScope = "assets"
+
+var testRequestOptions = []filter.RequestOption{
+	{
+		Name:      filter.ReadableValue[string]{Value: "hostname"},
+		Control:   filter.RequestOptionType{Type: filter.ControlTypeString},
+		Operators: []filter.ReadableValue[filter.CompareOperator]{{Value: filter.CompareOperatorBeginsWith}},
+	},
+}
+
+func newTestStore(t *testing.T, config Config) *Store {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	store := NewStore(db, config)
+	require.NoError(t, store.AutoMigrate())
+	return store
+}
+
+func hostnameSelector(operator filter.CompareOperator) query.ResultSelector {
+	return query.ResultSelector{
+		Filter: &filter.Request{
+			Operator: filter.LogicOperatorAnd,
+			Fields:   []filter.RequestField{{Name: "hostname", Operator: operator, Value: "web"}},
+		},
+		Sorting: &sorting.Request{SortColumn: "hostname", SortDirection: sorting.DirectionAscending},
+	}
+}
+
+func TestStore_CRUD(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, Config{SchemaVersion: 1, RequestOptions: map[string][]filter.RequestOption{testScope: testRequestOptions}})
+
+	view := &SavedView{
+		Scope:          testScope,
+		Owner:          "alice",
+		Name:           "web servers",
+		Visibility:     VisibilityPrivate,
+		ResultSelector: hostnameSelector(filter.CompareOperatorBeginsWith),
+	}
+	require.NoError(t, store.Create(ctx, view))
+	assert.NotEqual(t, uuid.Nil, view.ID)
+	assert.Equal(t, 1, view.SchemaVersion)
+
+	got, err := store.Get(ctx, view.ID, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "web servers", got.Name)
+	assert.Equal(t, filter.CompareOperatorBeginsWith, got.ResultSelector.Filter.Fields[0].Operator)
+	assert.Equal(t, "hostname", got.ResultSelector.Sorting.SortColumn)
+	assert.True(t, got.IsValid())
+	assert.False(t, got.CreatedAt.IsZero())
+
+	_, err = store.Get(ctx, view.ID, "bob")
+	assert.ErrorIs(t, err, ErrNotFound, "private view must not be visible to other users")
+
+	view.Visibility = VisibilityShared
+	view.Name = "all web servers"
+	require.NoError(t, store.Update(ctx, view, "alice"))
+	got, err = store.Get(ctx, view.ID, "bob")
+	require.NoError(t, err, "shared view must be visible to other users")
+	assert.Equal(t, "all web servers", got.Name)
+
+	assert.ErrorIs(t, store.Update(ctx, view, "bob"), ErrNotFound, "view must only be updated by its owner")
+	assert.ErrorIs(t, store.Delete(ctx, view.ID, "bob"), ErrNotFound, "view must only be deleted by its owner")
+
+	require.NoError(t, store.Delete(ctx, view.ID, "alice"))
+	_, err = store.Get(ctx, view.ID, "alice")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStore_List(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, Config{})
+
+	views := []*SavedView{
+		{Scope: testScope, Owner: "alice", Name: "b", Visibility: VisibilityPrivate},
+		{Scope: testScope, Owner: "alice", Name: "a", Visibility: VisibilityPrivate},
+		{Scope: testScope, Owner: "bob", Name: "c", Visibility: VisibilityShared},
+		{Scope: testScope, Owner: "bob", Name: "d", Visibility: VisibilityPrivate},
+		{Scope: "vulnerabilities", Owner: "alice", Name: "e", Visibility: VisibilityPrivate},
+	}
+	for _, view := range views {
+		require.NoError(t, store.Create(ctx, view))
+	}
+
+	got, err := store.List(ctx, testScope, "alice")
+	require.NoError(t, err)
+	names := make([]string, 0, len(got))
+	for _, view := range got {
+		names = append(names, view.Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+}
+
+func TestStore_Default(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, Config{})
+
+	first := &SavedView{Scope: testScope, Owner: "alice", Name: "first", Visibility: VisibilityPrivate, IsDefault: true}
+	second := &SavedView{Scope: testScope, Owner: "alice", Name: "second", Visibility: VisibilityPrivate, IsDefault: true}
+	other := &SavedView{Scope: testScope, Owner: "bob", Name: "other", Visibility: VisibilityShared, IsDefault: true}
+	require.NoError(t, store.Create(ctx, first))
+	require.NoError(t, store.Create(ctx, second))
+	require.NoError(t, store.Create(ctx, other))
+
+	got, err := store.GetDefault(ctx, testScope, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, got.ID, "only the latest default view must remain default")
+
+	got, err = store.Get(ctx, first.ID, "alice")
+	require.NoError(t, err)
+	assert.False(t, got.IsDefault)
+
+	second.IsDefault = false
+	require.NoError(t, store.Update(ctx, second, "alice"))
+	_, err = store.GetDefault(ctx, testScope, "alice")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	got, err = store.GetDefault(ctx, testScope, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, other.ID, got.ID, "default views of other users must not be reset")
+}
+
+func TestStore_Validation(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, Config{})
+
+	tests := map[string]struct {
+		view    *SavedView
+		wantErr string
+	}{
+		"empty name": {
+			view:    &SavedView{Scope: testScope, Owner: "alice", Name: " ", Visibility: VisibilityPrivate},
+			wantErr: "name of saved view is empty",
+		},
+		"empty scope": {
+			view:    &SavedView{Owner: "alice", Name: "a", Visibility: VisibilityPrivate},
+			wantErr: "scope of saved view is empty",
+		},
+		"empty owner": {
+			view:    &SavedView{Scope: testScope, Name: "a", Visibility: VisibilityPrivate},
+			wantErr: "owner of saved view is empty",
+		},
+		"invalid visibility": {
+			view:    &SavedView{Scope: testScope, Owner: "alice", Name: "a", Visibility: "public"},
+			wantErr: "public is no valid visibility",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorContains(t, store.Create(ctx, tt.view), tt.wantErr)
+		})
+	}
+
+	t.Run("duplicate name", func(t *testing.T) {
+		require.NoError(t, store.Create(ctx, &SavedView{Scope: testScope, Owner: "alice", Name: "dup", Visibility: VisibilityPrivate}))
+		err := store.Create(ctx, &SavedView{Scope: testScope, Owner: "alice", Name: "dup", Visibility: VisibilityShared})
+		assert.ErrorIs(t, err, ErrDuplicateName)
+		require.NoError(t, store.Create(ctx, &SavedView{Scope: testScope, Owner: "bob", Name: "dup", Visibility: VisibilityShared}))
+	})
+}
+
+func TestStore_MigrationAndRevalidation(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	oldStore := NewStore(db, Config{SchemaVersion: 1})
+	require.NoError(t, oldStore.AutoMigrate())
+	migrated := &SavedView{
+		Scope: testScope, Owner: "alice", Name: "migrated", Visibility: VisibilityPrivate,
+		ResultSelector: hostnameSelector("startsWith"),
+	}
+	invalid := &SavedView{
+		Scope: testScope, Owner: "alice", Name: "invalid", Visibility: VisibilityPrivate,
+		ResultSelector: hostnameSelector(filter.CompareOperatorContains),
+	}
+	require.NoError(t, oldStore.Create(ctx, migrated))
+	require.NoError(t, oldStore.Create(ctx, invalid))
+
+	store := NewStore(db, Config{
+		SchemaVersion:  2,
+		Migrations:     map[int]Migration{1: RenameOperator("startsWith", filter.CompareOperatorBeginsWith)},
+		RequestOptions: map[string][]filter.RequestOption{testScope: testRequestOptions},
+	})
+
+	got, err := store.Get(ctx, migrated.ID, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.SchemaVersion)
+	assert.Equal(t, filter.CompareOperatorBeginsWith, got.ResultSelector.Filter.Fields[0].Operator)
+	assert.True(t, got.IsValid())
+
+	var stored SavedView
+	require.NoError(t, db.Take(&stored, "id = ?", migrated.ID).Error)
+	assert.Equal(t, 2, stored.SchemaVersion, "migrated view must be stored")
+	assert.Contains(t, stored.RawResultSelector, `"operator":"beginsWith"`)
+
+	got, err = store.Get(ctx, invalid.ID, "alice")
+	require.NoError(t, err, "invalid views must still be loaded")
+	require.False(t, got.IsValid())
+	assert.Equal(t, "fields[0]", got.ValidationErrors[0].Key())
+	assert.Equal(t, filter.FieldValidationErrorCodeInvalidOperator, got.ValidationErrors[0].Code)
+
+	t.Run("concurrently migrated view is reloaded", func(t *testing.T) {
+		concurrent := &SavedView{
+			Scope: testScope, Owner: "alice", Name: "concurrent", Visibility: VisibilityPrivate,
+			ResultSelector: hostnameSelector(filter.CompareOperatorBeginsWith),
+		}
+		require.NoError(t, store.Create(ctx, concurrent))
+
+		// the view as read before another instance migrated it
+		stale := *concurrent
+		stale.SchemaVersion = 1
+		stale.RawResultSelector = `{"filter":{"operator":"and","fields":[{"name":"hostname","operator":"startsWith","value":"old"}]}}`
+		require.NoError(t, store.storeMigrated(db, &stale))
+		assert.Equal(t, 2, stale.SchemaVersion)
+		assert.Equal(t, concurrent.RawResultSelector, stale.RawResultSelector, "stored view must be reloaded")
+
+		var stored SavedView
+		require.NoError(t, db.Take(&stored, "id = ?", concurrent.ID).Error)
+		assert.Equal(t, concurrent.RawResultSelector, stored.RawResultSelector, "stored view must not be overwritten")
+	})
+
+	t.Run("missing migration", func(t *testing.T) {
+		store := NewStore(db, Config{SchemaVersion: 3})
+		_, err := store.Get(ctx, migrated.ID, "alice")
+		assert.ErrorContains(t, err, "missing migration")
+	})
+}
+
+func TestIsDuplicateName(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	require.NoError(t, err)
+	require.NoError(t, NewStore(db, Config{}).AutoMigrate())
+	require.NoError(t, db.Create(&SavedView{ID: uuid.New(), Scope: testScope, Owner: "alice", Name: "dup"}).Error)
+	duplicateErr := db.Create(&SavedView{ID: uuid.New(), Scope: testScope, Owner: "alice", Name: "dup"}).Error
+
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"translated unique violation": {err: duplicateErr, want: true},
+		"postgres unique violation": {
+			err:  errors.New(`ERROR: duplicate key value violates unique constraint "idx_saved_views_name" (SQLSTATE 23505)`),
+			want: true,
+		},
+		"other error": {err: errors.New("connection refused")},
+		"no error":    {err: nil},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isDuplicateName(tt.err))
+		})
+	}
+}
+
+func TestRenameField(t *testing.T) {
+	var resultSelector map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"filter": {
+			"operator": "or",
+			"fields": [{"name": "name", "operator": "beginsWith", "value": "a"}],
+			"groups": [{"operator": "and", "fields": [{"name": "name", "operator": "contains", "value": "b"}]}]
+		},
+		"sorting": {"keys": [{"column": "name", "direction": "desc"}]}
+	}`), &resultSelector))
+
+	require.NoError(t, RenameField("name", "hostname")(resultSelector))
+	got, err := json.Marshal(resultSelector)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"filter": {
+			"operator": "or",
+			"fields": [{"name": "hostname", "operator": "beginsWith", "value": "a"}],
+			"groups": [{"operator": "and", "fields": [{"name": "hostname", "operator": "contains", "value": "b"}]}]
+		},
+		"sorting": {"keys": [{"column": "hostname", "direction": "desc"}]}
+	}`, string(got))
+}
@@ -31,3 +31,23 @@ func SortDirectionFromString(str string) SortDirection {
 }
 
 type SortDirection string
+
+// NullsOrder defines the position of null (or missing) values within the sort order.
+type NullsOrder string
+
+const (
+	NullsFirst NullsOrder = "first"
+	NullsLast  NullsOrder = "last"
+	// NullsDefault keeps the default position of the data source.
+	NullsDefault NullsOrder = ""
+)
+
+// IsValid returns true if the nulls order is one of the defined values.
+func (n NullsOrder) IsValid() bool {
+	switch n {
+	case NullsFirst, NullsLast, NullsDefault:
+		return true
+	default:
+		return false
+	}
+}
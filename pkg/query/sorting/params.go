@@ -4,8 +4,19 @@
 
 package sorting
 
+// Params holds the effective sorting parameters. For requests with multiple sort keys, the fields refer
+// to the first key and Keys holds the parameters of all keys in order.
 type Params struct {
 	OriginalSortColumn  string
 	SortDirection       SortDirection
 	EffectiveSortColumn string
+	Keys                []KeyParams
+}
+
+// KeyParams holds the effective sorting parameters of a single sort key.
+type KeyParams struct {
+	OriginalSortColumn  string
+	SortDirection       SortDirection
+	EffectiveSortColumn string
+	Nulls               NullsOrder
 }
@@ -5,11 +5,38 @@
 package sorting
 
 // Request represents a sorting request with a specified sort column and sort direction.
+// To sort by multiple columns, the sort keys can be passed as ordered list instead.
 //
 // Fields:
 // - SortColumn: the column to sort on
 // - SortDirection: the direction of sorting (asc or desc)
+// - Keys: ordered list of sort keys, mutually exclusive with SortColumn and SortDirection
 type Request struct {
 	SortColumn    string        `json:"column"`
 	SortDirection SortDirection `json:"direction"`
+	Keys          []Key         `json:"keys,omitempty"`
+}
+
+// Key represents a single sort key of a sorting request.
+//
+// Fields:
+// - Column: the column to sort on
+// - Direction: the direction of sorting (asc or desc)
+// - Nulls: optional position of null values (first or last), by default the data source decides
+type Key struct {
+	Column    string        `json:"column"`
+	Direction SortDirection `json:"direction"`
+	Nulls     NullsOrder    `json:"nulls,omitempty"`
+}
+
+// SortKeys returns the ordered list of sort keys of the request. For a request using
+// SortColumn and SortDirection a list with a single key is returned.
+func (r *Request) SortKeys() []Key {
+	if r == nil {
+		return nil
+	}
+	if len(r.Keys) > 0 {
+		return r.Keys
+	}
+	return []Key{{Column: r.SortColumn, Direction: r.SortDirection}}
 }
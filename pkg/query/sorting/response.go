@@ -7,7 +7,10 @@ package sorting
 // Response represents the response structure for sorting column and direction.
 // SortingColumn stores the name of the column which was used for sorting.
 // SortingDirection stores the direction which was applied by the sorting.
+// Keys stores all sort keys, if sorting was applied by multiple keys. In this case SortingColumn and
+// SortingDirection refer to the first key.
 type Response struct {
 	SortingColumn    string        `json:"column"`
 	SortingDirection SortDirection `json:"direction"`
+	Keys             []Key         `json:"keys,omitempty"`
 }
@@ -34,12 +34,33 @@ const (
 )
 
 func AddRequest(transaction *gorm.DB, params Params) *gorm.DB {
+	if len(params.Keys) > 0 {
+		for _, key := range params.Keys {
+			if key.SortDirection != NoDirection && key.EffectiveSortColumn != "" {
+				transaction = transaction.Order(key.EffectiveSortColumn + " " + key.SortDirection.String() +
+					nullsClause(key.Nulls))
+			}
+		}
+		return transaction
+	}
 	if params.SortDirection != NoDirection && params.EffectiveSortColumn != "" {
 		transaction = transaction.Order(params.EffectiveSortColumn + " " + params.SortDirection.String())
 	}
 	return transaction
 }
 
+// nullsClause returns the SQL clause for the position of null values, or an empty string for the default position.
+func nullsClause(nulls NullsOrder) string {
+	switch nulls {
+	case NullsFirst:
+		return " NULLS FIRST"
+	case NullsLast:
+		return " NULLS LAST"
+	default:
+		return ""
+	}
+}
+
 // SortableColumn is a struct to hold the fields which the paging can sort by.
 type SortableColumn struct {
 	Column         string
@@ -96,11 +117,16 @@ func getEffectiveSortColumn(model SortingSettingsInterface, field string) string
 
 // DetermineEffectiveSortingParams checks the requested sorting and sets the defaults in case of an error.
 // If a SortColumnOverrideTag (sortColumnOverride) is given, it's value will be used for sorting instead
-// of SortColumnTag (sortColumn). For a detailed explanation see SortColumnOverrideTag
+// of SortColumnTag (sortColumn). For a detailed explanation see SortColumnOverrideTag.
+// For a request with multiple sort keys, all of them need to be sortable, otherwise the defaults are applied.
 func DetermineEffectiveSortingParams(model SortingSettingsInterface, sortingReq *Request) (Params, error) {
 	// Validate the sorting request
 	if err := ValidateSortingRequest(sortingReq); err == nil {
-		if fieldIsSortable(model, sortingReq.SortColumn) {
+		if len(sortingReq.Keys) > 0 {
+			if params, ok := keyParamsOf(model, sortingReq.Keys); ok {
+				return params, nil
+			}
+		} else if fieldIsSortable(model, sortingReq.SortColumn) {
 			params := paramsOf(*sortingReq)
 			params.EffectiveSortColumn = getEffectiveSortColumn(model, sortingReq.SortColumn)
 			return params, nil
@@ -113,6 +139,7 @@ func DetermineEffectiveSortingParams(model SortingSettingsInterface, sortingReq
 		err := fmt.Errorf("failed to get sorting defaults: %w", pdErr)
 		sortingReq.SortColumn = ""
 		sortingReq.SortDirection = DirectionDescending
+		sortingReq.Keys = nil
 		return paramsOf(*sortingReq), err
 	}
 
@@ -121,6 +148,7 @@ func DetermineEffectiveSortingParams(model SortingSettingsInterface, sortingReq
 	}
 	sortingReq.SortColumn = sortingDefaults.Column
 	sortingReq.SortDirection = sortingDefaults.Direction
+	sortingReq.Keys = nil
 	return paramsOf(*sortingReq), nil
 }
 
@@ -130,3 +158,25 @@ func paramsOf(sortingReq Request) Params {
 		SortDirection:      sortingReq.SortDirection, EffectiveSortColumn: sortingReq.SortColumn,
 	}
 }
+
+// keyParamsOf returns the params for multiple sort keys. It returns false if any of the keys is not sortable.
+func keyParamsOf(model SortingSettingsInterface, keys []Key) (Params, bool) {
+	keyParams := make([]KeyParams, 0, len(keys))
+	for _, key := range keys {
+		if !fieldIsSortable(model, key.Column) {
+			return Params{}, false
+		}
+		keyParams = append(keyParams, KeyParams{
+			OriginalSortColumn:  key.Column,
+			SortDirection:       key.Direction,
+			EffectiveSortColumn: getEffectiveSortColumn(model, key.Column),
+			Nulls:               key.Nulls,
+		})
+	}
+	return Params{
+		OriginalSortColumn:  keyParams[0].OriginalSortColumn,
+		SortDirection:       keyParams[0].SortDirection,
+		EffectiveSortColumn: keyParams[0].EffectiveSortColumn,
+		Keys:                keyParams,
+	}, true
+}
@@ -5,9 +5,15 @@
 package sorting
 
 import (
+	"encoding/json"
+	"regexp"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 )
 
 type TestSortingModel struct {
@@ -83,6 +89,68 @@ func TestSortingRule(t *testing.T) {
 		}, finalReq)
 	})
 
+	t.Run("valid sorting keys", func(t *testing.T) {
+		validRequest := &Request{Keys: []Key{
+			{Column: "test2", Direction: DirectionAscending, Nulls: NullsFirst},
+			{Column: "name", Direction: DirectionDescending},
+		}}
+		finalReq, vErr := DetermineEffectiveSortingParams(&TestSortingModel{}, validRequest)
+		assert.NoError(t, vErr)
+		assert.EqualValues(t, Params{
+			OriginalSortColumn:  "test2",
+			SortDirection:       DirectionAscending,
+			EffectiveSortColumn: "\"appliance\".\"name\"",
+			Keys: []KeyParams{
+				{
+					OriginalSortColumn:  "test2",
+					SortDirection:       DirectionAscending,
+					EffectiveSortColumn: "\"appliance\".\"name\"",
+					Nulls:               NullsFirst,
+				},
+				{
+					OriginalSortColumn:  "name",
+					SortDirection:       DirectionDescending,
+					EffectiveSortColumn: "\"appliance\".\"name\"",
+				},
+			},
+		}, finalReq)
+	})
+
+	t.Run("invalid sorting key", func(t *testing.T) {
+		invalidRequest := &Request{Keys: []Key{
+			{Column: "name", Direction: DirectionAscending},
+			{Column: "InvalidField", Direction: DirectionDescending},
+		}}
+		request, vErr := DetermineEffectiveSortingParams(&TestSortingModel{}, invalidRequest)
+		assert.NoError(t, vErr)
+		assert.EqualValues(t, Params{
+			OriginalSortColumn:  defaultPagingRequest.SortColumn,
+			SortDirection:       defaultPagingRequest.SortDirection,
+			EffectiveSortColumn: defaultPagingRequest.SortColumn,
+		}, request)
+		assert.Equal(t, defaultPagingRequest, invalidRequest)
+	})
+
+	t.Run("json of single column request is unchanged", func(t *testing.T) {
+		request := Request{SortColumn: "name", SortDirection: DirectionAscending}
+		jsonRequest, err := json.Marshal(request)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"column":"name","direction":"asc"}`, string(jsonRequest))
+
+		var multiKeyRequest Request
+		err = json.Unmarshal([]byte(`{"keys":[{"column":"name","direction":"asc","nulls":"last"},{"column":"test2","direction":"desc"}]}`), &multiKeyRequest)
+		require.NoError(t, err)
+		assert.Equal(t, []Key{
+			{Column: "name", Direction: DirectionAscending, Nulls: NullsLast},
+			{Column: "test2", Direction: DirectionDescending},
+		}, multiKeyRequest.SortKeys())
+	})
+
+	t.Run("sort keys of single column request", func(t *testing.T) {
+		request := &Request{SortColumn: "name", SortDirection: DirectionAscending}
+		assert.Equal(t, []Key{{Column: "name", Direction: DirectionAscending}}, request.SortKeys())
+	})
+
 	t.Run("sorting direction asc", func(t *testing.T) {
 		assert.EqualValues(t, DirectionDescending.String(), "DESC")
 	})
@@ -107,3 +175,33 @@ func TestSortingRule(t *testing.T) {
 		assert.EqualValues(t, SortDirectionFromString(""), NoDirection)
 	})
 }
+
+func TestAddSortingRequest(t *testing.T) {
+	sqlDB, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	type testObject struct {
+		TheString  string
+		TheInteger int
+	}
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta(
+		`SELECT * FROM "test_objects" ORDER BY the_string ASC NULLS LAST,the_integer DESC`)).
+		WillReturnRows(sqlmock.NewRows([]string{}))
+
+	gormDB = AddRequest(gormDB, Params{
+		OriginalSortColumn:  "theString",
+		SortDirection:       DirectionAscending,
+		EffectiveSortColumn: "the_string",
+		Keys: []KeyParams{
+			{OriginalSortColumn: "theString", SortDirection: DirectionAscending, EffectiveSortColumn: "the_string", Nulls: NullsLast},
+			{OriginalSortColumn: "theInteger", SortDirection: DirectionDescending, EffectiveSortColumn: "the_integer"},
+		},
+	})
+	gormDB.Find(&testObject{})
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+}
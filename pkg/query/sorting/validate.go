@@ -11,17 +11,42 @@ func ValidateSortingRequest(req *Request) error {
 	if req == nil {
 		return &Error{Msg: "sorting request is nil"}
 	}
-	if req.SortColumn == "" {
+	if len(req.Keys) > 0 {
+		if req.SortColumn != "" || req.SortDirection != "" {
+			return &Error{Msg: "sorting column and direction must not be set together with sorting keys"}
+		}
+		columns := make(map[string]bool, len(req.Keys))
+		for i, key := range req.Keys {
+			if err := validateSortKey(key.Column, key.Direction); err != nil {
+				return NewSortingError("sorting key %d: %s", i, err.Error())
+			}
+			if !key.Nulls.IsValid() {
+				return NewSortingError("sorting key %d: %s is no valid nulls order, possible values are first, last",
+					i, key.Nulls)
+			}
+			if columns[key.Column] {
+				return NewSortingError("sorting key %d: duplicate sorting column %s", i, key.Column)
+			}
+			columns[key.Column] = true
+		}
+		return nil
+	}
+
+	return validateSortKey(req.SortColumn, req.SortDirection)
+}
+
+func validateSortKey(column string, direction SortDirection) error {
+	if column == "" {
 		return &Error{Msg: "sorting column is empty"}
 	}
 
-	if req.SortDirection == "" {
+	if direction == "" {
 		return &Error{Msg: "sorting direction is empty"}
 	}
 
-	if SortDirectionFromString(req.SortDirection.String()) == NoDirection {
+	if SortDirectionFromString(direction.String()) == NoDirection {
 		return &Error{
-			Msg: fmt.Sprintf("%s is no valid sorting direction, possible values are asc, desc", req.SortDirection.String()),
+			Msg: fmt.Sprintf("%s is no valid sorting direction, possible values are asc, desc", direction.String()),
 		}
 	}
 
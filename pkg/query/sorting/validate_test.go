@@ -50,4 +50,60 @@ func TestSortingValidations(t *testing.T) {
 		err := ValidateSortingRequest(req)
 		assert.ErrorContains(t, err, "NONSENSE is no valid sorting direction, possible values are asc, desc")
 	})
+
+	t.Run("shallHaveNoSortingKeysError", func(t *testing.T) {
+		req := &Request{
+			Keys: []Key{
+				{Column: "col1", Direction: DirectionAscending},
+				{Column: "col2", Direction: DirectionDescending, Nulls: NullsLast},
+			},
+		}
+
+		err := ValidateSortingRequest(req)
+		assert.NoError(t, err)
+	})
+
+	t.Run("shallRaiseSortingKeysWithColumnError", func(t *testing.T) {
+		req := &Request{
+			SortColumn:    "col1",
+			SortDirection: DirectionAscending,
+			Keys:          []Key{{Column: "col2", Direction: DirectionAscending}},
+		}
+
+		err := ValidateSortingRequest(req)
+		assert.ErrorContains(t, err, "sorting column and direction must not be set together with sorting keys")
+	})
+
+	t.Run("shallRaiseSortingKeyDirectionError", func(t *testing.T) {
+		req := &Request{
+			Keys: []Key{
+				{Column: "col1", Direction: DirectionAscending},
+				{Column: "col2", Direction: "nonsense"},
+			},
+		}
+
+		err := ValidateSortingRequest(req)
+		assert.ErrorContains(t, err, "sorting key 1: NONSENSE is no valid sorting direction")
+	})
+
+	t.Run("shallRaiseSortingKeyNullsError", func(t *testing.T) {
+		req := &Request{
+			Keys: []Key{{Column: "col1", Direction: DirectionAscending, Nulls: "middle"}},
+		}
+
+		err := ValidateSortingRequest(req)
+		assert.ErrorContains(t, err, "sorting key 0: middle is no valid nulls order")
+	})
+
+	t.Run("shallRaiseDuplicateSortingKeyError", func(t *testing.T) {
+		req := &Request{
+			Keys: []Key{
+				{Column: "col1", Direction: DirectionAscending},
+				{Column: "col1", Direction: DirectionDescending},
+			},
+		}
+
+		err := ValidateSortingRequest(req)
+		assert.ErrorContains(t, err, "sorting key 1: duplicate sorting column col1")
+	})
 }
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package suggestion contains the model to request value suggestions for filter fields with the control type
+// `autocomplete`, e.g. to offer typeahead while a user enters a filter value.
+package suggestion
+
+import "github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+
+const (
+	// DefaultSize is the number of suggestions returned if the request does not specify a size.
+	DefaultSize = 10
+	// MaxSize is the maximum number of suggestions which can be requested.
+	MaxSize = 100
+)
+
+// Request represents a request for value suggestions of a filter field.
+//
+// Fields:
+// - Field: name of the filter field to suggest values for
+// - Prefix: the values start with this prefix, compared case-insensitively. An empty prefix matches all values.
+// - Filter: optional, the filter currently entered by the user. Only values of matching results are suggested.
+// - Size: optional, maximum number of suggestions, defaults to [DefaultSize]
+type Request struct {
+	Field  string          `json:"field" binding:"required"`
+	Prefix string          `json:"prefix"`
+	Filter *filter.Request `json:"filter,omitempty" binding:"omitempty"`
+	Size   int             `json:"size,omitempty"`
+}
+
+// EffectiveSize returns the requested number of suggestions or [DefaultSize] if none is specified.
+func (r Request) EffectiveSize() int {
+	if r.Size <= 0 {
+		return DefaultSize
+	}
+	return r.Size
+}
+
+// Response contains the suggested values, which are distinct and sorted in ascending order.
+type Response struct {
+	Values []string `json:"values"`
+}
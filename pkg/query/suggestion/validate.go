@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package suggestion
+
+import (
+	"fmt"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+)
+
+// Error is returned for invalid suggestion requests.
+type Error struct {
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
+
+func NewSuggestionError(format string, value ...any) error {
+	return &Error{
+		Msg: fmt.Sprintf(format, value...),
+	}
+}
+
+// ValidateSuggestionRequest validates a suggestion request against the request options of the endpoint.
+// Suggestions are only available for fields with the control type `autocomplete`. The filter of the request
+// is validated with [filter.ValidateFilter].
+func ValidateSuggestionRequest(req *Request, requestOptions []filter.RequestOption) error {
+	if req == nil {
+		return &Error{Msg: "suggestion request is nil"}
+	}
+	if req.Size < 0 || req.Size > MaxSize {
+		return NewSuggestionError("size must be between 0 and %d, got %d", MaxSize, req.Size)
+	}
+
+	fieldIsValid := false
+	for _, requestOption := range requestOptions {
+		if requestOption.Name.Value != req.Field {
+			continue
+		}
+		if requestOption.Control.Type != filter.ControlTypeAutocomplete {
+			return NewSuggestionError("field %s does not support suggestions, control type is %s",
+				req.Field, requestOption.Control.Type)
+		}
+		fieldIsValid = true
+		break
+	}
+	if !fieldIsValid {
+		return NewSuggestionError("%s is no valid field", req.Field)
+	}
+
+	return filter.ValidateFilter(req.Filter, requestOptions)
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package suggestion
+
+import (
+	"testing"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSuggestionRequest(t *testing.T) {
+	requestOptions := []filter.RequestOption{
+		{
+			Name:      filter.ReadableValue[string]{Value: "hostname"},
+			Control:   filter.RequestOptionType{Type: filter.ControlTypeAutocomplete},
+			Operators: []filter.ReadableValue[filter.CompareOperator]{{Value: filter.CompareOperatorBeginsWith}},
+		},
+		{
+			Name:      filter.ReadableValue[string]{Value: "severity"},
+			Control:   filter.RequestOptionType{Type: filter.ControlTypeFloat},
+			Operators: []filter.ReadableValue[filter.CompareOperator]{{Value: filter.CompareOperatorIsGreaterThan}},
+		},
+	}
+
+	tests := map[string]struct {
+		request      *Request
+		wantErrorMsg string
+	}{
+		"valid request": {
+			request: &Request{Field: "hostname", Prefix: "ho", Size: MaxSize},
+		},
+		"valid request with filter": {
+			request: &Request{
+				Field:  "hostname",
+				Prefix: "ho",
+				Filter: &filter.Request{
+					Operator: filter.LogicOperatorAnd,
+					Fields:   []filter.RequestField{{Name: "severity", Operator: filter.CompareOperatorIsGreaterThan, Value: 5.0}},
+				},
+			},
+		},
+		"nil request": {
+			request:      nil,
+			wantErrorMsg: "suggestion request is nil",
+		},
+		"unknown field": {
+			request:      &Request{Field: "unknown"},
+			wantErrorMsg: "unknown is no valid field",
+		},
+		"field without autocomplete": {
+			request:      &Request{Field: "severity"},
+			wantErrorMsg: "field severity does not support suggestions, control type is float",
+		},
+		"size too large": {
+			request:      &Request{Field: "hostname", Size: MaxSize + 1},
+			wantErrorMsg: "size must be between 0 and 100, got 101",
+		},
+		"invalid filter": {
+			request: &Request{
+				Field: "hostname",
+				Filter: &filter.Request{
+					Operator: filter.LogicOperatorAnd,
+					Fields:   []filter.RequestField{{Name: "unknown", Operator: filter.CompareOperatorIsEqualTo, Value: "a"}},
+				},
+			},
+			wantErrorMsg: "unknown",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateSuggestionRequest(tt.request, requestOptions)
+			if tt.wantErrorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrorMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEffectiveSize(t *testing.T) {
+	assert.Equal(t, DefaultSize, Request{}.EffectiveSize())
+	assert.Equal(t, 3, Request{Size: 3}.EffectiveSize())
+}
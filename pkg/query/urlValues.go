@@ -0,0 +1,485 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/sorting"
+)
+
+// URL query parameter names of the parts of a result selector, see [EncodeURLValues].
+const (
+	URLParameterFilter  = "filter"
+	URLParameterSorting = "sorting"
+	URLParameterPaging  = "paging"
+	URLParameterSearch  = "search"
+)
+
+// EncodeURLValues encodes filter, sorting and paging of the result selector as URL query parameters,
+// e.g. to build deep links to list endpoints. [DecodeURLValues] restores the result selector.
+//
+// The parameters use a bracket notation:
+//
+//	filter[op]=and                     logic operator of the filter
+//	filter[0][name]=hostname           name of the first field
+//	filter[0][op]=contains             compare operator of the first field
+//	filter[0][value]=web               single value, or a list of values like filter[0][value][0]=a
+//	filter[0][keys][0]=tag             keys of a nested field
+//	filter[0][match]=all               whether any or all values of a list need to match
+//	filter[groups][0][op]=or           nested groups, with the same notation as the filter itself
+//	filter[groups][0][0][name]=...
+//	sorting[column]=name&sorting[direction]=asc
+//	sorting[0][column]=name&sorting[0][direction]=asc&sorting[0][nulls]=last
+//	paging[index]=1&paging[size]=50&paging[cursor]=...
+//	search[text]=linux&search[sortByRank]=true
+//
+// Values are encoded as strings, numbers without exponent, times in RFC3339 format. As the type of the values
+// is lost, the decoded filter should be normalized with [filter.NormalizeFilter]. The aggregation request
+// is not encoded.
+func EncodeURLValues(resultSelector ResultSelector) url.Values {
+	values := url.Values{}
+	if resultSelector.Filter != nil {
+		encodeFilter(values, URLParameterFilter, resultSelector.Filter)
+	}
+	if sortingRequest := resultSelector.Sorting; sortingRequest != nil {
+		if len(sortingRequest.Keys) > 0 {
+			for i, key := range sortingRequest.Keys {
+				prefix := fmt.Sprintf("%s[%d]", URLParameterSorting, i)
+				values.Set(prefix+"[column]", key.Column)
+				values.Set(prefix+"[direction]", string(key.Direction))
+				if key.Nulls != sorting.NullsDefault {
+					values.Set(prefix+"[nulls]", string(key.Nulls))
+				}
+			}
+		} else {
+			values.Set(URLParameterSorting+"[column]", sortingRequest.SortColumn)
+			values.Set(URLParameterSorting+"[direction]", string(sortingRequest.SortDirection))
+		}
+	}
+	if pagingRequest := resultSelector.Paging; pagingRequest != nil {
+		values.Set(URLParameterPaging+"[index]", strconv.Itoa(pagingRequest.PageIndex))
+		values.Set(URLParameterPaging+"[size]", strconv.Itoa(pagingRequest.PageSize))
+		if pagingRequest.Cursor != "" {
+			values.Set(URLParameterPaging+"[cursor]", pagingRequest.Cursor)
+		}
+	}
+	if search := resultSelector.Search; search != nil {
+		values.Set(URLParameterSearch+"[text]", search.Text)
+		if search.SortByRank {
+			values.Set(URLParameterSearch+"[sortByRank]", "true")
+		}
+	}
+	return values
+}
+
+func encodeFilter(values url.Values, prefix string, request *filter.Request) {
+	if request.Operator != "" {
+		values.Set(prefix+"[op]", string(request.Operator))
+	}
+	for i, field := range request.Fields {
+		fieldPrefix := fmt.Sprintf("%s[%d]", prefix, i)
+		values.Set(fieldPrefix+"[name]", field.Name)
+		values.Set(fieldPrefix+"[op]", string(field.Operator))
+		for j, key := range field.Keys {
+			values.Set(fmt.Sprintf("%s[keys][%d]", fieldPrefix, j), key)
+		}
+		if field.Match != "" {
+			values.Set(fieldPrefix+"[match]", string(field.Match))
+		}
+		if field.Value == nil {
+			continue
+		}
+		// lists of any element type, e.g. []string or []time.Time, are encoded element-wise
+		if list := reflect.ValueOf(field.Value); list.Kind() == reflect.Slice {
+			for j := 0; j < list.Len(); j++ {
+				values.Set(fmt.Sprintf("%s[value][%d]", fieldPrefix, j), urlValueOf(list.Index(j).Interface()))
+			}
+		} else {
+			values.Set(fieldPrefix+"[value]", urlValueOf(field.Value))
+		}
+	}
+	for i := range request.Groups {
+		encodeFilter(values, fmt.Sprintf("%s[groups][%d]", prefix, i), &request.Groups[i])
+	}
+}
+
+func urlValueOf(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case uuid.UUID:
+		return v.String()
+	case netip.Prefix:
+		return v.String()
+	case netip.Addr:
+		return v.String()
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// urlNode is a node of the tree of the bracket notation, e.g. `filter[0][name]` is the node `name` of the node `0`
+// of the root node `filter`.
+type urlNode struct {
+	path     string
+	values   []string
+	children map[string]*urlNode
+}
+
+func (n *urlNode) child(name string) *urlNode {
+	if n.children == nil {
+		n.children = map[string]*urlNode{}
+	}
+	child, ok := n.children[name]
+	if !ok {
+		child = &urlNode{path: n.path + "[" + name + "]"}
+		n.children[name] = child
+	}
+	return child
+}
+
+// indexedChildren returns the children with numeric names in ascending order. Names which are no
+// numbers are returned separately.
+func (n *urlNode) indexedChildren() (indexed []*urlNode, named map[string]*urlNode, err error) {
+	indices := make(map[int]*urlNode)
+	named = make(map[string]*urlNode)
+	for name, child := range n.children {
+		index, err := strconv.Atoi(name)
+		if err != nil {
+			named[name] = child
+			continue
+		}
+		if index < 0 {
+			return nil, nil, fmt.Errorf("invalid query parameter %s: negative index", child.path)
+		}
+		indices[index] = child
+	}
+	keys := make([]int, 0, len(indices))
+	for index := range indices {
+		keys = append(keys, index)
+	}
+	slices.Sort(keys)
+	for _, index := range keys {
+		indexed = append(indexed, indices[index])
+	}
+	return indexed, named, nil
+}
+
+// singleValue returns the value of a leaf node.
+func (n *urlNode) singleValue() (string, error) {
+	if len(n.children) > 0 || len(n.values) != 1 {
+		return "", fmt.Errorf("invalid query parameter %s: expected a single value", n.path)
+	}
+	return n.values[0], nil
+}
+
+// listValue returns the values of a node, either as indexed children or as repeated parameter.
+func (n *urlNode) listValue() ([]string, error) {
+	indexed, named, err := n.indexedChildren()
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range named {
+		return nil, fmt.Errorf("invalid query parameter %s: expected an index", child.path)
+	}
+	list := slices.Clone(n.values)
+	for _, child := range indexed {
+		value, err := child.singleValue()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+	}
+	return list, nil
+}
+
+// urlParameterRoot returns the name of a parameter in bracket notation without its segments,
+// e.g. `filter` for `filter[0][name]`.
+func urlParameterRoot(name string) string {
+	root, _, _ := strings.Cut(name, "[")
+	return root
+}
+
+// parseURLParameterSegments returns the segments of a parameter name in bracket notation,
+// e.g. `[0 name]` for `filter[0][name]`.
+func parseURLParameterSegments(name string) (segments []string, err error) {
+	open := strings.IndexByte(name, '[')
+	if open < 0 {
+		return nil, nil
+	}
+	rest := name[open:]
+	for rest != "" {
+		if rest[0] != '[' {
+			return nil, fmt.Errorf("invalid query parameter %s: expected '['", name)
+		}
+		closing := strings.IndexByte(rest, ']')
+		if closing < 0 {
+			return nil, fmt.Errorf("invalid query parameter %s: missing ']'", name)
+		}
+		segments = append(segments, rest[1:closing])
+		rest = rest[closing+1:]
+	}
+	return segments, nil
+}
+
+// DecodeURLValues decodes a result selector from URL query parameters encoded with [EncodeURLValues].
+// Other query parameters are ignored. Filter values are decoded as strings, for lists of values as []any
+// of strings. The result is not validated.
+func DecodeURLValues(values url.Values) (ResultSelector, error) {
+	roots := map[string]*urlNode{}
+	for name, parameterValues := range values {
+		root := urlParameterRoot(name)
+		if root != URLParameterFilter && root != URLParameterSorting && root != URLParameterPaging &&
+			root != URLParameterSearch {
+			continue
+		}
+		segments, err := parseURLParameterSegments(name)
+		if err != nil {
+			return ResultSelector{}, err
+		}
+		node, ok := roots[root]
+		if !ok {
+			node = &urlNode{path: root}
+			roots[root] = node
+		}
+		for _, segment := range segments {
+			node = node.child(segment)
+		}
+		node.values = append(node.values, parameterValues...)
+	}
+
+	var resultSelector ResultSelector
+	var err error
+	if node, ok := roots[URLParameterFilter]; ok {
+		if resultSelector.Filter, err = decodeFilter(node); err != nil {
+			return ResultSelector{}, err
+		}
+	}
+	if node, ok := roots[URLParameterSorting]; ok {
+		if resultSelector.Sorting, err = decodeSorting(node); err != nil {
+			return ResultSelector{}, err
+		}
+	}
+	if node, ok := roots[URLParameterPaging]; ok {
+		if resultSelector.Paging, err = decodePaging(node); err != nil {
+			return ResultSelector{}, err
+		}
+	}
+	if node, ok := roots[URLParameterSearch]; ok {
+		if resultSelector.Search, err = decodeSearch(node); err != nil {
+			return ResultSelector{}, err
+		}
+	}
+	return resultSelector, nil
+}
+
+func decodeFilter(node *urlNode) (*filter.Request, error) {
+	if len(node.values) > 0 {
+		return nil, fmt.Errorf("invalid query parameter %s: expected fields in bracket notation", node.path)
+	}
+	fieldNodes, named, err := node.indexedChildren()
+	if err != nil {
+		return nil, err
+	}
+
+	request := &filter.Request{}
+	for name, child := range named {
+		switch name {
+		case "op":
+			operator, err := child.singleValue()
+			if err != nil {
+				return nil, err
+			}
+			if request.Operator, err = filter.ParseLogicOperator(operator); err != nil {
+				return nil, fmt.Errorf("invalid query parameter %s: %w", child.path, err)
+			}
+		case "groups":
+			groupNodes, groupNamed, err := child.indexedChildren()
+			if err != nil {
+				return nil, err
+			}
+			for _, unknown := range groupNamed {
+				return nil, fmt.Errorf("invalid query parameter %s: expected an index", unknown.path)
+			}
+			for _, groupNode := range groupNodes {
+				group, err := decodeFilter(groupNode)
+				if err != nil {
+					return nil, err
+				}
+				request.Groups = append(request.Groups, *group)
+			}
+		default:
+			return nil, fmt.Errorf("invalid query parameter %s: unknown key", child.path)
+		}
+	}
+
+	for _, fieldNode := range fieldNodes {
+		field, err := decodeFilterField(fieldNode)
+		if err != nil {
+			return nil, err
+		}
+		request.Fields = append(request.Fields, field)
+	}
+	return request, nil
+}
+
+func decodeFilterField(node *urlNode) (field filter.RequestField, err error) {
+	for name, child := range node.children {
+		switch name {
+		case "name":
+			if field.Name, err = child.singleValue(); err != nil {
+				return filter.RequestField{}, err
+			}
+		case "op":
+			operator, err := child.singleValue()
+			if err != nil {
+				return filter.RequestField{}, err
+			}
+			if field.Operator, err = filter.ParseCompareOperator(operator); err != nil {
+				return filter.RequestField{}, fmt.Errorf("invalid query parameter %s: %w", child.path, err)
+			}
+		case "keys":
+			if field.Keys, err = child.listValue(); err != nil {
+				return filter.RequestField{}, err
+			}
+		case "match":
+			match, err := child.singleValue()
+			if err != nil {
+				return filter.RequestField{}, err
+			}
+			field.Match = filter.ValueMatch(match)
+		case "value":
+			if len(child.children) == 0 && len(child.values) == 1 {
+				field.Value = child.values[0]
+				continue
+			}
+			list, err := child.listValue()
+			if err != nil {
+				return filter.RequestField{}, err
+			}
+			values := make([]any, 0, len(list))
+			for _, value := range list {
+				values = append(values, value)
+			}
+			field.Value = values
+		default:
+			return filter.RequestField{}, fmt.Errorf("invalid query parameter %s: unknown key", child.path)
+		}
+	}
+	if field.Name == "" {
+		return filter.RequestField{}, fmt.Errorf("invalid query parameter %s: missing name", node.path)
+	}
+	return field, nil
+}
+
+func decodeSorting(node *urlNode) (*sorting.Request, error) {
+	keyNodes, named, err := node.indexedChildren()
+	if err != nil {
+		return nil, err
+	}
+
+	request := &sorting.Request{}
+	for name, child := range named {
+		value, err := child.singleValue()
+		if err != nil {
+			return nil, err
+		}
+		switch name {
+		case "column":
+			request.SortColumn = value
+		case "direction":
+			request.SortDirection = sorting.SortDirection(value)
+		default:
+			return nil, fmt.Errorf("invalid query parameter %s: unknown key", child.path)
+		}
+	}
+
+	for _, keyNode := range keyNodes {
+		var key sorting.Key
+		for name, child := range keyNode.children {
+			value, err := child.singleValue()
+			if err != nil {
+				return nil, err
+			}
+			switch name {
+			case "column":
+				key.Column = value
+			case "direction":
+				key.Direction = sorting.SortDirection(value)
+			case "nulls":
+				key.Nulls = sorting.NullsOrder(value)
+			default:
+				return nil, fmt.Errorf("invalid query parameter %s: unknown key", child.path)
+			}
+		}
+		request.Keys = append(request.Keys, key)
+	}
+	return request, nil
+}
+
+func decodePaging(node *urlNode) (*paging.Request, error) {
+	request := &paging.Request{}
+	for name, child := range node.children {
+		value, err := child.singleValue()
+		if err != nil {
+			return nil, err
+		}
+		switch name {
+		case "index", "size":
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid query parameter %s: %s is no valid number", child.path, value)
+			}
+			if name == "index" {
+				request.PageIndex = number
+			} else {
+				request.PageSize = number
+			}
+		case "cursor":
+			request.Cursor = value
+		default:
+			return nil, fmt.Errorf("invalid query parameter %s: unknown key", child.path)
+		}
+	}
+	return request, nil
+}
+
+func decodeSearch(node *urlNode) (*Search, error) {
+	search := &Search{}
+	for name, child := range node.children {
+		value, err := child.singleValue()
+		if err != nil {
+			return nil, err
+		}
+		switch name {
+		case "text":
+			search.Text = value
+		case "sortByRank":
+			if search.SortByRank, err = strconv.ParseBool(value); err != nil {
+				return nil, fmt.Errorf("invalid query parameter %s: %s is no valid boolean", child.path, value)
+			}
+		default:
+			return nil, fmt.Errorf("invalid query parameter %s: unknown key", child.path)
+		}
+	}
+	return search, nil
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2025 Greenbone AG <https://greenbone.net>
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package query
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/filter"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/paging"
+	"github.com/greenbone/opensight-golang-libraries/pkg/query/sorting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeURLValues(t *testing.T) {
+	tests := map[string]struct {
+		resultSelector ResultSelector
+		wantQuery      string
+	}{
+		"empty result selector": {
+			resultSelector: ResultSelector{},
+			wantQuery:      "",
+		},
+		"filter with list value and group": {
+			resultSelector: ResultSelector{
+				Filter: &filter.Request{
+					Operator: filter.LogicOperatorAnd,
+					Fields: []filter.RequestField{
+						{Name: "hostname", Operator: filter.CompareOperatorContains, Value: "web"},
+						{Name: "severity", Operator: filter.CompareOperatorIsGreaterThan, Value: 7.5},
+						{Name: "tag", Operator: filter.CompareOperatorIsEqualTo, Value: []any{"a", "b"}, Keys: []string{"env"}, Match: filter.ValueMatchAll},
+					},
+					Groups: []filter.Request{{
+						Operator: filter.LogicOperatorOr,
+						Fields:   []filter.RequestField{{Name: "name", Operator: filter.CompareOperatorIsEqualTo, Value: "x"}},
+					}},
+				},
+			},
+			wantQuery: "filter[0][name]=hostname&filter[0][op]=contains&filter[0][value]=web" +
+				"&filter[1][name]=severity&filter[1][op]=isGreaterThan&filter[1][value]=7.5" +
+				"&filter[2][keys][0]=env&filter[2][match]=all&filter[2][name]=tag&filter[2][op]=isEqualTo" +
+				"&filter[2][value][0]=a&filter[2][value][1]=b" +
+				"&filter[groups][0][0][name]=name&filter[groups][0][0][op]=isEqualTo&filter[groups][0][0][value]=x" +
+				"&filter[groups][0][op]=or&filter[op]=and",
+		},
+		"sorting and paging": {
+			resultSelector: ResultSelector{
+				Sorting: &sorting.Request{SortColumn: "name", SortDirection: sorting.DirectionDescending},
+				Paging:  &paging.Request{PageIndex: 2, PageSize: 50},
+			},
+			wantQuery: "paging[index]=2&paging[size]=50&sorting[column]=name&sorting[direction]=desc",
+		},
+		"sorting keys and cursor": {
+			resultSelector: ResultSelector{
+				Sorting: &sorting.Request{Keys: []sorting.Key{
+					{Column: "severity", Direction: sorting.DirectionDescending, Nulls: sorting.NullsLast},
+					{Column: "name", Direction: sorting.DirectionAscending},
+				}},
+				Paging: &paging.Request{PageSize: 10, Cursor: "abc"},
+			},
+			wantQuery: "paging[cursor]=abc&paging[index]=0&paging[size]=10" +
+				"&sorting[0][column]=severity&sorting[0][direction]=desc&sorting[0][nulls]=last" +
+				"&sorting[1][column]=name&sorting[1][direction]=asc",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			query, err := url.QueryUnescape(EncodeURLValues(tt.resultSelector).Encode())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantQuery, query)
+		})
+	}
+}
+
+func TestDecodeURLValues(t *testing.T) {
+	tests := map[string]struct {
+		query              string
+		wantResultSelector ResultSelector
+		wantErr            string
+	}{
+		"no parameters": {
+			query:              "other=1",
+			wantResultSelector: ResultSelector{},
+		},
+		"filter": {
+			query: "filter[op]=and&filter[1][name]=severity&filter[1][op]=isGreaterThan&filter[1][value]=7.5" +
+				"&filter[0][name]=hostname&filter[0][op]=contains&filter[0][value]=web" +
+				"&filter[2][name]=tag&filter[2][op]=isEqualTo&filter[2][value]=a&filter[2][value]=b&filter[2][keys][0]=env&filter[2][match]=all" +
+				"&filter[groups][0][op]=or&filter[groups][0][0][name]=name&filter[groups][0][0][op]=isEqualTo" +
+				"&filter[groups][0][0][value][10]=y&filter[groups][0][0][value][9]=x",
+			wantResultSelector: ResultSelector{
+				Filter: &filter.Request{
+					Operator: filter.LogicOperatorAnd,
+					Fields: []filter.RequestField{
+						{Name: "hostname", Operator: filter.CompareOperatorContains, Value: "web"},
+						{Name: "severity", Operator: filter.CompareOperatorIsGreaterThan, Value: "7.5"},
+						{Name: "tag", Operator: filter.CompareOperatorIsEqualTo, Value: []any{"a", "b"}, Keys: []string{"env"}, Match: filter.ValueMatchAll},
+					},
+					Groups: []filter.Request{{
+						Operator: filter.LogicOperatorOr,
+						Fields:   []filter.RequestField{{Name: "name", Operator: filter.CompareOperatorIsEqualTo, Value: []any{"x", "y"}}},
+					}},
+				},
+			},
+		},
+		"sorting keys and paging": {
+			query: "sorting[1][column]=name&sorting[1][direction]=asc&sorting[0][column]=severity" +
+				"&sorting[0][direction]=desc&sorting[0][nulls]=last&paging[size]=10&paging[cursor]=abc",
+			wantResultSelector: ResultSelector{
+				Sorting: &sorting.Request{Keys: []sorting.Key{
+					{Column: "severity", Direction: sorting.DirectionDescending, Nulls: sorting.NullsLast},
+					{Column: "name", Direction: sorting.DirectionAscending},
+				}},
+				Paging: &paging.Request{PageSize: 10, Cursor: "abc"},
+			},
+		},
+		"invalid compare operator": {
+			query:   "filter[0][name]=hostname&filter[0][op]=like",
+			wantErr: "invalid query parameter filter[0][op]",
+		},
+		"invalid logic operator": {
+			query:   "filter[op]=xor",
+			wantErr: "invalid query parameter filter[op]",
+		},
+		"missing field name": {
+			query:   "filter[0][op]=contains",
+			wantErr: "invalid query parameter filter[0]: missing name",
+		},
+		"unknown key": {
+			query:   "paging[offset]=1",
+			wantErr: "invalid query parameter paging[offset]: unknown key",
+		},
+		"invalid boolean": {
+			query:   "search[text]=web&search[sortByRank]=maybe",
+			wantErr: "invalid query parameter search[sortByRank]: maybe is no valid boolean",
+		},
+		"invalid number": {
+			query:   "paging[index]=first",
+			wantErr: "invalid query parameter paging[index]: first is no valid number",
+		},
+		"repeated single value": {
+			query:   "sorting[column]=a&sorting[column]=b",
+			wantErr: "invalid query parameter sorting[column]: expected a single value",
+		},
+		"missing bracket": {
+			query:   "filter[0]name=a",
+			wantErr: "invalid query parameter filter[0]name: expected '['",
+		},
+		"missing closing bracket": {
+			query:   "paging[size=10",
+			wantErr: "invalid query parameter paging[size: missing ']'",
+		},
+		"other parameters in bracket notation": {
+			query:              "utm[x=1&utm[source]]=a&paging[size]=10",
+			wantResultSelector: ResultSelector{Paging: &paging.Request{PageSize: 10}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			resultSelector, err := DecodeURLValues(values)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantResultSelector, resultSelector)
+		})
+	}
+}
+
+func TestURLValuesRoundTrip(t *testing.T) {
+	resultSelector := ResultSelector{
+		Filter: &filter.Request{
+			Operator: filter.LogicOperatorOr,
+			Fields: []filter.RequestField{
+				{Name: "hostname", Operator: filter.CompareOperatorBeginsWith, Value: "a&b=c[0]"},
+				{Name: "ip", Operator: filter.CompareOperatorIsEqualTo, Value: []any{"10.0.0.1", "10.0.0.2"}},
+				{Name: "exists", Operator: filter.CompareOperatorExists},
+			},
+		},
+		Sorting: &sorting.Request{SortColumn: "hostname", SortDirection: sorting.DirectionAscending},
+		Paging:  &paging.Request{PageIndex: 3, PageSize: 25},
+		Search:  &Search{Text: `web -"test server"`, SortByRank: true},
+	}
+
+	decoded, err := DecodeURLValues(EncodeURLValues(resultSelector))
+	require.NoError(t, err)
+	assert.Equal(t, resultSelector, decoded)
+}
+
+func TestURLValuesRoundTripTypedLists(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 5, 31, 23, 59, 59, 500, time.UTC)
+	resultSelector := ResultSelector{
+		Filter: &filter.Request{
+			Operator: filter.LogicOperatorAnd,
+			Fields: []filter.RequestField{
+				{Name: "created", Operator: filter.CompareOperatorBetweenDates, Value: []time.Time{start, end}},
+				{Name: "score", Operator: filter.CompareOperatorIsEqualTo, Value: []float64{1.5, 7}},
+			},
+		},
+	}
+	requestOptions := []filter.RequestOption{
+		{Name: filter.NewReadableValue("", "created"), Control: filter.RequestOptionType{Type: filter.ControlTypeDateTime}},
+		{Name: filter.NewReadableValue("", "score"), Control: filter.RequestOptionType{Type: filter.ControlTypeFloat}},
+	}
+
+	values := EncodeURLValues(resultSelector)
+	assert.Equal(t, "2024-05-31T23:59:59.0000005Z", values.Get("filter[0][value][1]"))
+	assert.Equal(t, "7", values.Get("filter[1][value][1]"))
+
+	decoded, err := DecodeURLValues(values)
+	require.NoError(t, err)
+	normalized, err := filter.NormalizeFilter(decoded.Filter, requestOptions)
+	require.NoError(t, err)
+	assert.Equal(t, []any{start, end}, normalized.Fields[0].Value)
+	assert.Equal(t, []any{1.5, 7.0}, normalized.Fields[1].Value)
+}